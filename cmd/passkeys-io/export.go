@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	"github.com/a-novel/uservice-passkeys/pkg/transfer"
+)
+
+type exportOptions struct {
+	file      string
+	format    string
+	namespace string
+	tenant    string
+	batchSize int
+	// activeOnly leaves out inactive passkeys. They are exported by default, so a migration keeps them.
+	activeOnly bool
+}
+
+func parseExportFlags(args []string) (*exportOptions, error) {
+	options := &exportOptions{}
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.StringVar(&options.file, "file", "", "path of the file to write")
+	flags.StringVar(&options.format, "format", "", formatUsage)
+	flags.StringVar(&options.namespace, "namespace", "", "only export passkeys from this namespace")
+	flags.StringVar(&options.tenant, "tenant", dao.DefaultTenant, "tenant to export passkeys from")
+	flags.IntVar(&options.batchSize, "batch-size", defaultBatchSize, "number of passkeys read per batch")
+	flags.BoolVar(
+		&options.activeOnly, "active-only", false, "leave out the expired, redeemed and revoked passkeys",
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("parse flags: %w", err)
+	}
+
+	if options.file == "" {
+		return nil, fmt.Errorf("%w: --file is required", errUsage)
+	}
+
+	if err := checkBatchSize(options.batchSize); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+type exportRun struct {
+	service services.ExportPasskeys
+	writer  transfer.Writer
+
+	exported int
+}
+
+func (run *exportRun) progress() string {
+	return fmt.Sprintf("%d passkeys exported", run.exported)
+}
+
+// consume pages through the database until every passkey matching the request has been written.
+func (run *exportRun) consume(
+	ctx context.Context,
+	request *services.ExportPasskeysRequest,
+	logger formatters.Formatter,
+	loader formatters.LogLoader,
+) error {
+	for {
+		res, err := run.service.Exec(ctx, request)
+		if err != nil {
+			return fmt.Errorf("export batch: %w", err)
+		}
+
+		for _, passkey := range res.Passkeys {
+			err := run.writer.Write(&transfer.Record{
+				ID:           passkey.ID,
+				Namespace:    passkey.Namespace,
+				EncryptedKey: passkey.EncryptedKey,
+				Reward:       passkey.Reward,
+				SingleUse:    lo.ToPtr(passkey.SingleUse),
+
+				RedeemedAt:       passkey.RedeemedAt,
+				RedeemedBy:       lo.FromPtr(passkey.RedeemedBy),
+				RevokedAt:        passkey.RevokedAt,
+				RevokedBy:        lo.FromPtr(passkey.RevokedBy),
+				RevocationReason: lo.FromPtr(passkey.RevocationReason),
+
+				ExpiresAt: passkey.ExpiresAt,
+				CreatedAt: lo.ToPtr(passkey.CreatedAt),
+				UpdatedAt: passkey.UpdatedAt,
+			})
+			if err != nil {
+				return fmt.Errorf("write record: %w", err)
+			}
+		}
+
+		run.exported += len(res.Passkeys)
+
+		if len(res.Passkeys) < request.Limit {
+			return nil
+		}
+
+		last := res.Passkeys[len(res.Passkeys)-1]
+		request.AfterNamespace = last.Namespace
+		request.AfterID = last.ID
+
+		logger.Log(loader.SetDescription(run.progress()+"..."), loggers.LogLevelInfo)
+	}
+}
+
+func runExport(logger formatters.Formatter, args []string) error {
+	options, err := parseExportFlags(args)
+	if err != nil {
+		return err
+	}
+
+	format, err := resolveFormat(options.file, options.format)
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.Create(options.file)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer dst.Close()
+
+	writer, err := transfer.NewWriter(format, dst)
+	if err != nil {
+		return fmt.Errorf("create writer: %w", err)
+	}
+
+	postgresDB, closePostgresDB := openDatabase(logger)
+	defer closePostgresDB()
+
+	run := &exportRun{
+		service: services.NewExportPasskeys(dao.NewListPasskeys(postgresDB)),
+		writer:  writer,
+	}
+
+	loader := formatters.NewLoader("Exporting passkeys...", spinner.Meter)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	request := &services.ExportPasskeysRequest{
+		Namespace:  options.namespace,
+		Limit:      options.batchSize,
+		ActiveOnly: options.activeOnly,
+	}
+	if err := run.consume(dao.WithTenant(context.Background(), options.tenant), request, logger, loader); err != nil {
+		logger.Log(loader.SetDescription(run.progress()).SetError(), loggers.LogLevelError)
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("flush file: %w", err)
+	}
+
+	logger.Log(
+		loader.SetDescription(fmt.Sprintf("%s to %s.", run.progress(), options.file)).SetCompleted(),
+		loggers.LogLevelInfo,
+	)
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/bubbles/spinner"
+
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/config"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	"github.com/a-novel/uservice-passkeys/pkg/transfer"
+)
+
+var errImportFailures = errors.New("some records could not be imported")
+
+type importOptions struct {
+	file       string
+	format     string
+	tenant     string
+	batchSize  int
+	dryRun     bool
+	errorsFile string
+}
+
+func parseImportFlags(args []string) (*importOptions, error) {
+	options := &importOptions{}
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.StringVar(&options.file, "file", "", "path of the file to import")
+	flags.StringVar(&options.format, "format", "", formatUsage)
+	flags.StringVar(&options.tenant, "tenant", dao.DefaultTenant, "tenant to import passkeys into")
+	flags.IntVar(&options.batchSize, "batch-size", defaultBatchSize, "number of passkeys inserted per batch")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "validate the file without writing to the database")
+	flags.StringVar(&options.errorsFile, "errors", "", "path of a JSONL file to write rejected records to")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("parse flags: %w", err)
+	}
+
+	if options.file == "" {
+		return nil, fmt.Errorf("%w: --file is required", errUsage)
+	}
+
+	if err := checkBatchSize(options.batchSize); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// importFailure is a single entry of the error report.
+type importFailure struct {
+	Line      int    `json:"line"`
+	ID        string `json:"id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Error     string `json:"error"`
+}
+
+type importRun struct {
+	service   services.ImportPasskeys
+	dryRun    bool
+	batchSize int
+
+	report  *json.Encoder
+	records []*services.ImportPasskeyRecord
+	lines   []int
+
+	imported int
+	failed   int
+}
+
+func (run *importRun) fail(failure *importFailure) error {
+	run.failed++
+
+	if run.report == nil {
+		return nil
+	}
+
+	if err := run.report.Encode(failure); err != nil {
+		return fmt.Errorf("write error report: %w", err)
+	}
+
+	return nil
+}
+
+func (run *importRun) flush(ctx context.Context) error {
+	if len(run.records) == 0 {
+		return nil
+	}
+
+	res, err := run.service.Exec(ctx, &services.ImportPasskeysRequest{Records: run.records, DryRun: run.dryRun})
+	if err != nil {
+		return fmt.Errorf("import batch: %w", err)
+	}
+
+	run.imported += res.Imported
+
+	for _, failure := range res.Failures {
+		record := run.records[failure.Index]
+
+		err := run.fail(&importFailure{
+			Line:      run.lines[failure.Index],
+			ID:        record.ID,
+			Namespace: record.Namespace,
+			Error:     failure.Err.Error(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	run.records = run.records[:0]
+	run.lines = run.lines[:0]
+
+	return nil
+}
+
+func (run *importRun) progress() string {
+	verb := "imported"
+	if run.dryRun {
+		verb = "validated"
+	}
+
+	return fmt.Sprintf("%d passkeys %s, %d rejected", run.imported, verb, run.failed)
+}
+
+// consume reads the whole source, and imports its records in batches.
+func (run *importRun) consume(
+	ctx context.Context, reader transfer.Reader, logger formatters.Formatter, loader formatters.LogLoader,
+) error {
+	for {
+		record, line, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return run.flush(ctx)
+		}
+
+		var lineErr *transfer.LineError
+		if errors.As(err, &lineErr) {
+			if err := run.fail(&importFailure{Line: lineErr.Line, Error: lineErr.Err.Error()}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("read file: %w", err)
+		}
+
+		run.records = append(run.records, &services.ImportPasskeyRecord{
+			ID:           record.ID,
+			Namespace:    record.Namespace,
+			Passkey:      record.Passkey,
+			EncryptedKey: record.EncryptedKey,
+			Reward:       record.Reward,
+			SingleUse:    record.SingleUse,
+
+			RedeemedAt:       record.RedeemedAt,
+			RedeemedBy:       record.RedeemedBy,
+			RevokedAt:        record.RevokedAt,
+			RevokedBy:        record.RevokedBy,
+			RevocationReason: record.RevocationReason,
+
+			ExpiresAt: record.ExpiresAt,
+			CreatedAt: record.CreatedAt,
+		})
+		run.lines = append(run.lines, line)
+
+		if len(run.records) < run.batchSize {
+			continue
+		}
+
+		if err := run.flush(ctx); err != nil {
+			return err
+		}
+
+		logger.Log(loader.SetDescription(run.progress()+"..."), loggers.LogLevelInfo)
+	}
+}
+
+func runImport(logger formatters.Formatter, args []string) error {
+	options, err := parseImportFlags(args)
+	if err != nil {
+		return err
+	}
+
+	format, err := resolveFormat(options.file, options.format)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(options.file)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer src.Close()
+
+	reader, err := transfer.NewReader(format, src)
+	if err != nil {
+		return fmt.Errorf("create reader: %w", err)
+	}
+
+	postgresDB, closePostgresDB := openDatabase(logger)
+	defer closePostgresDB()
+
+	// Records follow the policies of their namespaces, as when created through the service.
+	policies := services.NewResolveNamespacePolicy(
+		dao.NewGetNamespace(postgresDB), config.App.Namespaces.RequireRegistered,
+	)
+
+	run := &importRun{
+		service:   services.NewImportPasskeys(dao.NewInsertPasskeys(postgresDB), policies),
+		dryRun:    options.dryRun,
+		batchSize: options.batchSize,
+	}
+
+	if options.errorsFile != "" {
+		report, err := os.Create(options.errorsFile)
+		if err != nil {
+			return fmt.Errorf("create error report: %w", err)
+		}
+		defer report.Close()
+
+		run.report = json.NewEncoder(report)
+	}
+
+	loader := formatters.NewLoader("Importing passkeys...", spinner.Meter)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	if err := run.consume(dao.WithTenant(context.Background(), options.tenant), reader, logger, loader); err != nil {
+		logger.Log(loader.SetDescription(run.progress()).SetError(), loggers.LogLevelError)
+		return err
+	}
+
+	if run.failed > 0 {
+		logger.Log(loader.SetDescription(run.progress()+".").SetError(), loggers.LogLevelWarning)
+		return fmt.Errorf("%w: %d rejected", errImportFailures, run.failed)
+	}
+
+	logger.Log(loader.SetDescription(run.progress()+".").SetCompleted(), loggers.LogLevelInfo)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Command passkeys-io moves passkeys in and out of the database, using CSV or JSONL files.
+//
+//	passkeys-io import --file passkeys.csv [--tenant my-tenant] [--dry-run] [--errors report.jsonl]
+//	passkeys-io export --file passkeys.jsonl [--tenant my-tenant] [--namespace my-namespace] [--active-only]
+//
+// Imported records either carry a plaintext passkey, which is hashed before being stored, or an argon2id hash
+// produced by this service. Exports only ever contain hashes. They include expired, redeemed and revoked passkeys,
+// along with their state, unless --active-only is set.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/golib/database"
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/config"
+	"github.com/a-novel/uservice-passkeys/pkg/transfer"
+)
+
+const (
+	defaultBatchSize = 100
+	formatUsage      = "format of the file (csv or jsonl), guessed from the extension if empty"
+)
+
+var errUsage = errors.New("usage: passkeys-io <import|export> [flags]")
+
+func openDatabase(logger formatters.Formatter) (*bun.DB, func()) {
+	loader := formatters.NewLoader("Acquiring database connection...", spinner.Meter)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	postgresDB, closePostgresDB, err := database.OpenDB(config.App.Postgres.DSN)
+	if err != nil {
+		logger.Log(formatters.NewError(err, "open database conn"), loggers.LogLevelFatal)
+	}
+
+	logger.Log(
+		loader.SetDescription("Database connection successfully acquired.").SetCompleted(),
+		loggers.LogLevelInfo,
+	)
+
+	return postgresDB, closePostgresDB
+}
+
+func checkBatchSize(batchSize int) error {
+	if batchSize < 1 || batchSize > 1000 {
+		return fmt.Errorf("%w: --batch-size must be between 1 and 1000", errUsage)
+	}
+
+	return nil
+}
+
+func resolveFormat(path, format string) (transfer.Format, error) {
+	if format != "" {
+		return transfer.Format(format), nil
+	}
+
+	resolved, err := transfer.FormatFromPath(path)
+	if err != nil {
+		return "", fmt.Errorf("guess format: %w", err)
+	}
+
+	return resolved, nil
+}
+
+func main() {
+	logger := config.Logger.Formatter
+
+	if len(os.Args) < 2 {
+		logger.Log(formatters.NewError(errUsage, "parse arguments"), loggers.LogLevelFatal)
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "import":
+		err = runImport(logger, os.Args[2:])
+	case "export":
+		err = runExport(logger, os.Args[2:])
+	default:
+		err = fmt.Errorf("%w: unknown command '%s'", errUsage, os.Args[1])
+	}
+
+	if err != nil {
+		logger.Log(formatters.NewError(err, os.Args[1]), loggers.LogLevelFatal)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+const (
+	configPathEnv   = "PASSKEYSCTL_CONFIG"
+	tokenEnv        = "PASSKEYSCTL_TOKEN" //nolint:gosec // Name of the variable, not its value.
+	configPathUsage = "defaults to $" + configPathEnv + ", or passkeysctl/config.yaml in the user config directory"
+
+	// defaultAddress is used when there is no configuration file, to reach a service started locally.
+	defaultAddress = "localhost:4003"
+)
+
+var (
+	ErrUnknownContext = errors.New("unknown context")
+	ErrNoContext      = errors.New("no context selected")
+	ErrInvalidCA      = errors.New("no certificate found in CA file")
+	ErrInsecureToken  = errors.New("bearer tokens are only sent over TLS")
+)
+
+// contextConfig describes how to reach the service in an environment. TLS and bearer tokens match the options of the
+// server: a client certificate is required when the server sets tls.client_ca_file, and a token when it sets jwt.
+type contextConfig struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	// Tenant is sent in the metadata of every request. The server ignores it when it reads the tenant from the
+	// credentials of the caller.
+	Tenant string `yaml:"tenant"`
+	TLS    struct {
+		// CAFile verifies the certificate of the server. The system pool is used when empty.
+		CAFile string `yaml:"ca_file"`
+		// CertFile and KeyFile are the client certificate the server authorizes callers with.
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+		// ServerName overrides the name checked against the certificate of the server.
+		ServerName string `yaml:"server_name"`
+		// Enabled forces TLS when none of the files are needed.
+		Enabled bool `yaml:"enabled"`
+	} `yaml:"tls"`
+	// TokenFile holds a bearer token, sent in the authorization metadata. The PASSKEYSCTL_TOKEN environment variable
+	// takes precedence.
+	TokenFile string `yaml:"token_file"`
+}
+
+type configFile struct {
+	CurrentContext string           `yaml:"current_context"`
+	Contexts       []*contextConfig `yaml:"contexts"`
+}
+
+func configPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	if path = os.Getenv(configPathEnv); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("get user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, "passkeysctl", "config.yaml"), nil
+}
+
+// loadContext returns the context called name, or the current context of the configuration file if name is empty.
+// Without a configuration file, it defaults to a local service in plaintext.
+func loadContext(path, name string) (*contextConfig, error) {
+	path, err := configPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && name == "" {
+		return &contextConfig{Name: "default", Address: defaultAddress}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read configuration file: %w", err)
+	}
+
+	file := new(configFile)
+	if err := yaml.Unmarshal(content, file); err != nil {
+		return nil, fmt.Errorf("decode configuration file: %w", err)
+	}
+
+	if name == "" {
+		name = file.CurrentContext
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("%w: set current_context in %s, or use --context", ErrNoContext, path)
+	}
+
+	for _, candidate := range file.Contexts {
+		if candidate.Name == name {
+			return candidate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s' is not defined in %s", ErrUnknownContext, name, path)
+}
+
+func (selected *contextConfig) transportCredentials() (credentials.TransportCredentials, error) {
+	if !selected.usesTLS() {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: selected.TLS.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if selected.TLS.CAFile != "" {
+		authorities, err := os.ReadFile(selected.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(authorities) {
+			return nil, ErrInvalidCA
+		}
+	}
+
+	if selected.TLS.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(selected.TLS.CertFile, selected.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func (selected *contextConfig) usesTLS() bool {
+	return selected.TLS.Enabled || selected.TLS.CAFile != "" || selected.TLS.CertFile != ""
+}
+
+func (selected *contextConfig) token() (string, error) {
+	if token := os.Getenv(tokenEnv); token != "" {
+		return token, nil
+	}
+
+	if selected.TokenFile == "" {
+		return "", nil
+	}
+
+	token, err := os.ReadFile(selected.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// bearerToken sends the token of the context with every request. It requires TLS, unless the caller allows plaintext,
+// for example behind a gateway that terminates TLS.
+type bearerToken struct {
+	token      string
+	requireTLS bool
+}
+
+func (token bearerToken) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + token.token}, nil
+}
+
+func (token bearerToken) RequireTransportSecurity() bool {
+	return token.requireTLS
+}
+
+// dial returns a client of the service of the context, and a function to close its connection. Tokens are refused
+// over plaintext, unless allowInsecure is set.
+func (selected *contextConfig) dial(allowInsecure bool) (client.Client, func(), error) {
+	transport, err := selected.transportCredentials()
+	if err != nil {
+		return nil, nil, fmt.Errorf("context '%s': %w", selected.Name, err)
+	}
+
+	token, err := selected.token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("context '%s': %w", selected.Name, err)
+	}
+
+	if token != "" && !selected.usesTLS() && !allowInsecure {
+		return nil, nil, fmt.Errorf(
+			"context '%s': %w: enable tls in the context, or use --insecure to send it in plaintext",
+			selected.Name, ErrInsecureToken,
+		)
+	}
+
+	options := []googlegrpc.DialOption{googlegrpc.WithTransportCredentials(transport)}
+	if token != "" {
+		options = append(options, googlegrpc.WithPerRPCCredentials(bearerToken{token: token, requireTLS: !allowInsecure}))
+	}
+
+	conn, err := googlegrpc.NewClient(selected.Address, options...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect to '%s': %w", selected.Address, err)
+	}
+
+	return client.NewClient(conn, client.Config{Tenant: selected.Tenant}), func() { _ = conn.Close() }, nil
+}
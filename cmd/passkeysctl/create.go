@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+func parseCreate(args []string) (*globalOptions, command, error) {
+	request := new(client.CreateRequest)
+
+	flags, options := newFlagSet("create")
+	flags.StringVar(&request.Namespace, "namespace", "", "namespace of the new passkey")
+	flags.StringVar(&request.ID, "id", "", "ID of the new passkey, generated by the service if empty")
+	flags.Func("reward", "reward of the passkey, as a JSON object", rewardFlag(&request.Reward))
+	flags.Func("expires-in", "lifetime of the passkey, such as 24h", durationFlag(&request.ExpiresIn))
+	flags.StringVar(
+		&request.IdempotencyKey, "idempotency-key", "", "retry safely: the service replays the response for the key",
+	)
+
+	if err := parseFlags(flags, args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := requireFlags(map[string]string{"namespace": request.Namespace}); err != nil {
+		return nil, nil, err
+	}
+
+	return options, func(ctx context.Context, passkeys client.Client, secrets *secretReader, out *printer) error {
+		secret, err := secrets.read("Passkey")
+		if err != nil {
+			return err
+		}
+
+		request.Passkey = secret
+
+		passkey, err := passkeys.Create(ctx, request)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return out.passkey(passkey)
+	}, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+func parseDelete(args []string) (*globalOptions, command, error) {
+	request := new(client.DeleteRequest)
+
+	var validate bool
+
+	flags, options := newFlagSet("delete")
+	flags.StringVar(&request.Namespace, "namespace", "", "namespace of the passkey")
+	flags.StringVar(&request.ID, "id", "", "ID of the passkey")
+	flags.BoolVar(&validate, "validate", false, "read the secret, and only delete the passkey if it matches")
+	flags.Func("expected-version", "only delete if the passkey still has this version", versionFlag(
+		&request.ExpectedVersion,
+	))
+
+	if err := parseFlags(flags, args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := requireFlags(map[string]string{"namespace": request.Namespace, "id": request.ID}); err != nil {
+		return nil, nil, err
+	}
+
+	return options, func(ctx context.Context, passkeys client.Client, secrets *secretReader, out *printer) error {
+		if validate {
+			secret, err := secrets.read("Passkey")
+			if err != nil {
+				return err
+			}
+
+			request.Passkey = secret
+		}
+
+		passkey, err := passkeys.Delete(ctx, request)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return out.passkey(passkey)
+	}, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// newFlagSet returns the flags of a command, along with the global ones.
+func newFlagSet(name string) (*flag.FlagSet, *globalOptions) {
+	options := new(globalOptions)
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	addGlobalFlags(flags, options)
+
+	return flags, options
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	if flags.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected argument '%s', secrets are read from stdin", errUsage, flags.Arg(0))
+	}
+
+	return nil
+}
+
+// requireFlags fails if any of the named flags is empty.
+func requireFlags(values map[string]string) error {
+	for name, value := range values {
+		if value == "" {
+			return fmt.Errorf("%w: --%s is required", errUsage, name)
+		}
+	}
+
+	return nil
+}
+
+// rewardFlag reads a JSON object.
+func rewardFlag(target *map[string]any) func(value string) error {
+	return func(value string) error {
+		if err := json.Unmarshal([]byte(value), target); err != nil {
+			return fmt.Errorf("reward must be a JSON object: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// durationFlag sets target only when the flag is used, so a missing flag can be told apart from a zero duration.
+func durationFlag(target **time.Duration) func(value string) error {
+	return func(value string) error {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("parse duration: %w", err)
+		}
+
+		*target = &duration
+
+		return nil
+	}
+}
+
+// versionFlag sets target only when the flag is used.
+func versionFlag(target **int64) func(value string) error {
+	return func(value string) error {
+		version, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse version: %w", err)
+		}
+
+		*target = &version
+
+		return nil
+	}
+}
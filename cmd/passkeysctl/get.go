@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+func parseGet(args []string) (*globalOptions, command, error) {
+	request := new(client.GetRequest)
+
+	flags, options := newFlagSet("get")
+	flags.StringVar(&request.Namespace, "namespace", "", "namespace of the passkey")
+	flags.StringVar(&request.ID, "id", "", "ID of the passkey")
+
+	if err := parseFlags(flags, args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := requireFlags(map[string]string{"namespace": request.Namespace, "id": request.ID}); err != nil {
+		return nil, nil, err
+	}
+
+	return options, func(ctx context.Context, passkeys client.Client, _ *secretReader, out *printer) error {
+		passkey, err := passkeys.Get(ctx, request)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return out.passkey(passkey)
+	}, nil
+}
+
+// parseValidate checks a secret against a passkey. Single-use passkeys are redeemed on success.
+func parseValidate(args []string) (*globalOptions, command, error) {
+	request := new(client.ValidateRequest)
+
+	flags, options := newFlagSet("validate")
+	flags.StringVar(&request.Namespace, "namespace", "", "namespace of the passkey")
+	flags.StringVar(&request.ID, "id", "", "ID of the passkey")
+	flags.StringVar(
+		&request.IdempotencyKey, "idempotency-key", "", "retry safely: the service replays the response for the key",
+	)
+
+	if err := parseFlags(flags, args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := requireFlags(map[string]string{"namespace": request.Namespace, "id": request.ID}); err != nil {
+		return nil, nil, err
+	}
+
+	return options, func(ctx context.Context, passkeys client.Client, secrets *secretReader, out *printer) error {
+		secret, err := secrets.read("Passkey")
+		if err != nil {
+			return err
+		}
+
+		request.Passkey = secret
+
+		passkey, err := passkeys.Validate(ctx, request)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return out.passkey(passkey)
+	}, nil
+}
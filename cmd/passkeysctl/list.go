@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 100
+)
+
+func parseList(args []string) (*globalOptions, command, error) {
+	request := new(client.ListRequest)
+
+	var all bool
+
+	flags, options := newFlagSet("list")
+	flags.StringVar(&request.Namespace, "namespace", "", "only list passkeys from this namespace")
+	flags.IntVar(&request.Limit, "limit", defaultListLimit, "number of passkeys per page")
+	flags.StringVar(&request.AfterNamespace, "after-namespace", "", "resume after the passkey of this namespace")
+	flags.StringVar(&request.AfterID, "after-id", "", "resume after the passkey with this ID")
+	flags.BoolVar(&all, "all", false, "list every page, rather than the first one")
+
+	if err := parseFlags(flags, args); err != nil {
+		return nil, nil, err
+	}
+
+	if request.Limit < 1 || request.Limit > maxListLimit {
+		return nil, nil, fmt.Errorf("%w: --limit must be between 1 and %d", errUsage, maxListLimit)
+	}
+
+	return options, func(ctx context.Context, passkeys client.Client, _ *secretReader, out *printer) error {
+		var listed []*client.Passkey
+
+		for {
+			page, err := passkeys.List(ctx, request)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			listed = append(listed, page...)
+
+			if !all || len(page) < request.Limit {
+				return out.passkeys(listed)
+			}
+
+			last := page[len(page)-1]
+			request.AfterNamespace = last.Namespace
+			request.AfterID = last.ID
+		}
+	}, nil
+}
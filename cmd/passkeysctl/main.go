@@ -0,0 +1,113 @@
+// Command passkeysctl manages passkeys through the gRPC API of the service.
+//
+//	passkeysctl create   --namespace my-namespace [--id ID] [--reward '{"key": "value"}'] [--expires-in 24h]
+//	passkeysctl get      --namespace my-namespace --id ID
+//	passkeysctl validate --namespace my-namespace --id ID
+//	passkeysctl update   --namespace my-namespace --id ID [--update-mask reward] [--check-current]
+//	passkeysctl delete   --namespace my-namespace --id ID [--validate]
+//	passkeysctl list     [--namespace my-namespace] [--limit 100] [--all]
+//
+// Secrets are prompted for when stdin is a terminal, and read from stdin otherwise, one per line. They are never
+// accepted as arguments, so they do not end up in the shell history or the process list.
+//
+// The service to call is read from the contexts of the configuration file, each describing an environment.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+const defaultTimeout = 30 * time.Second
+
+var errUsage = errors.New("usage: passkeysctl <create|get|validate|update|delete|list> [flags]")
+
+// globalOptions are accepted by every command.
+type globalOptions struct {
+	config  string
+	context string
+	output  string
+	timeout time.Duration
+	// insecure allows sending bearer tokens without TLS.
+	insecure bool
+}
+
+func addGlobalFlags(flags *flag.FlagSet, options *globalOptions) {
+	flags.StringVar(&options.config, "config", "", "path of the configuration file, "+configPathUsage)
+	flags.StringVar(&options.context, "context", "", "context to use, instead of the current one")
+	flags.StringVar(&options.output, "output", outputTable, "output format: table or json")
+	flags.DurationVar(&options.timeout, "timeout", defaultTimeout, "maximum duration of the command")
+	flags.BoolVar(&options.insecure, "insecure", false, "send the bearer token even if the context does not use TLS")
+}
+
+func (options *globalOptions) validate() error {
+	if options.output != outputTable && options.output != outputJSON {
+		return fmt.Errorf("%w: --output must be table or json", errUsage)
+	}
+
+	return nil
+}
+
+// command runs with a client connected to the selected context.
+type command func(ctx context.Context, passkeys client.Client, secrets *secretReader, out *printer) error
+
+var commands = map[string]func(args []string) (*globalOptions, command, error){
+	"create":   parseCreate,
+	"get":      parseGet,
+	"validate": parseValidate,
+	"update":   parseUpdate,
+	"delete":   parseDelete,
+	"list":     parseList,
+}
+
+func run(args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	parse, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown command '%s'", errUsage, args[0])
+	}
+
+	options, exec, err := parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	if err := options.validate(); err != nil {
+		return err
+	}
+
+	selected, err := loadContext(options.config, options.context)
+	if err != nil {
+		return err
+	}
+
+	passkeys, closeConn, err := selected.dial(options.insecure)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.timeout)
+	defer cancel()
+
+	return exec(ctx, passkeys, newSecretReader(os.Stdin, os.Stderr), newPrinter(options.output, os.Stdout))
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "passkeysctl:", err)
+		}
+
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// passkeyView is the JSON representation of a passkey.
+type passkeyView struct {
+	ID        string         `json:"id"`
+	Namespace string         `json:"namespace"`
+	Reward    map[string]any `json:"reward,omitempty"`
+	SingleUse bool           `json:"single_use,omitempty"`
+	ExpiresAt *time.Time     `json:"expires_at,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt *time.Time     `json:"updated_at,omitempty"`
+	Version   int64          `json:"version,omitempty"`
+}
+
+func newPasskeyView(passkey *client.Passkey) *passkeyView {
+	return &passkeyView{
+		ID:        passkey.ID,
+		Namespace: passkey.Namespace,
+		Reward:    passkey.Reward,
+		SingleUse: passkey.SingleUse,
+		ExpiresAt: passkey.ExpiresAt,
+		CreatedAt: passkey.CreatedAt,
+		UpdatedAt: passkey.UpdatedAt,
+		Version:   passkey.Version,
+	}
+}
+
+// printer writes the passkeys returned by a command, in the format selected with --output.
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+// passkey prints a single passkey. It is an object in JSON, rather than a list of one.
+func (output *printer) passkey(passkey *client.Passkey) error {
+	if output.format == outputJSON {
+		return output.json(newPasskeyView(passkey))
+	}
+
+	return output.table([]*client.Passkey{passkey})
+}
+
+func (output *printer) passkeys(passkeys []*client.Passkey) error {
+	if output.format == outputJSON {
+		return output.json(lo.Map(passkeys, func(item *client.Passkey, _ int) *passkeyView {
+			return newPasskeyView(item)
+		}))
+	}
+
+	return output.table(passkeys)
+}
+
+func (output *printer) json(value any) error {
+	encoder := json.NewEncoder(output.out)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("encode output: %w", err)
+	}
+
+	return nil
+}
+
+func (output *printer) table(passkeys []*client.Passkey) error {
+	writer := tabwriter.NewWriter(output.out, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "ID\tNAMESPACE\tVERSION\tEXPIRES AT\tCREATED AT\tUPDATED AT\tREWARD")
+
+	for _, passkey := range passkeys {
+		reward, err := json.Marshal(passkey.Reward)
+		if err != nil {
+			return fmt.Errorf("encode reward: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(
+			writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			passkey.ID,
+			passkey.Namespace,
+			lo.Ternary(passkey.Version > 0, strconv.FormatInt(passkey.Version, 10), "-"),
+			formatTime(passkey.ExpiresAt),
+			formatTime(&passkey.CreatedAt),
+			formatTime(passkey.UpdatedAt),
+			lo.Ternary(passkey.Reward == nil, "-", string(reward)),
+		)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	return nil
+}
+
+func formatTime(value *time.Time) string {
+	if value == nil {
+		return "-"
+	}
+
+	return value.Format(time.RFC3339)
+}
+
+func newPrinter(format string, out io.Writer) *printer {
+	return &printer{format: format, out: out}
+}
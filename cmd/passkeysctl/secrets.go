@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var ErrEmptySecret = errors.New("empty secret")
+
+// secretReader prompts for secrets when stdin is a terminal, without echoing them. Otherwise, it reads them from
+// stdin, one per line, in the order the command asks for them.
+type secretReader struct {
+	in     *os.File
+	lines  *bufio.Reader
+	prompt io.Writer
+}
+
+func (reader *secretReader) read(label string) (string, error) {
+	var (
+		secret string
+		err    error
+	)
+
+	if term.IsTerminal(int(reader.in.Fd())) {
+		secret, err = reader.ask(label)
+	} else {
+		secret, err = reader.readLine(label)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if secret == "" {
+		return "", fmt.Errorf("%w: %s", ErrEmptySecret, label)
+	}
+
+	return secret, nil
+}
+
+func (reader *secretReader) ask(label string) (string, error) {
+	_, _ = fmt.Fprintf(reader.prompt, "%s: ", label)
+
+	secret, err := term.ReadPassword(int(reader.in.Fd()))
+	_, _ = fmt.Fprintln(reader.prompt)
+
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", label, err)
+	}
+
+	return string(secret), nil
+}
+
+func (reader *secretReader) readLine(label string) (string, error) {
+	line, err := reader.lines.ReadString('\n')
+	// The last secret may not end with a newline.
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("read %s from stdin: %w", label, err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func newSecretReader(in *os.File, prompt io.Writer) *secretReader {
+	return &secretReader{in: in, lines: bufio.NewReader(in), prompt: prompt}
+}
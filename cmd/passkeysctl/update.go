@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+)
+
+func parseUpdate(args []string) (*globalOptions, command, error) {
+	request := new(client.UpdateRequest)
+
+	var (
+		updateMask   string
+		checkCurrent bool
+	)
+
+	flags, options := newFlagSet("update")
+	flags.StringVar(&request.Namespace, "namespace", "", "namespace of the passkey")
+	flags.StringVar(&request.ID, "id", "", "ID of the passkey")
+	flags.Func("reward", "new reward of the passkey, as a JSON object", rewardFlag(&request.Reward))
+	flags.Func("expires-in", "new lifetime of the passkey, from now", durationFlag(&request.ExpiresIn))
+	flags.StringVar(
+		&updateMask, "update-mask", "", "comma-separated fields to update: passkey, reward, expires_in (default all)",
+	)
+	flags.BoolVar(&checkCurrent, "check-current", false, "read the current secret, and only update if it matches")
+	flags.Func("expected-version", "only update if the passkey still has this version", versionFlag(
+		&request.ExpectedVersion,
+	))
+
+	if err := parseFlags(flags, args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := requireFlags(map[string]string{"namespace": request.Namespace, "id": request.ID}); err != nil {
+		return nil, nil, err
+	}
+
+	if updateMask != "" {
+		request.UpdateMask = lo.Map(strings.Split(updateMask, ","), func(item string, _ int) string {
+			return strings.TrimSpace(item)
+		})
+	}
+
+	// Every field is updated when the mask is empty, including the secret.
+	updateSecret := len(request.UpdateMask) == 0 || lo.Contains(request.UpdateMask, client.UpdateMaskPasskey)
+
+	return options, func(ctx context.Context, passkeys client.Client, secrets *secretReader, out *printer) error {
+		if checkCurrent {
+			current, err := secrets.read("Current passkey")
+			if err != nil {
+				return err
+			}
+
+			request.CurrentPasskey = &current
+		}
+
+		if updateSecret {
+			secret, err := secrets.read("New passkey")
+			if err != nil {
+				return err
+			}
+
+			request.Passkey = secret
+		}
+
+		passkey, err := passkeys.Update(ctx, request)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return out.passkey(passkey)
+	}, nil
+}
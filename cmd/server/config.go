@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/config"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+// DefaultHealthWatchInterval is how often health statuses are sent to watching clients.
+const DefaultHealthWatchInterval = time.Minute
+
+// Every value of the configuration can also be set from the environment, as listed in config/app.yaml. Flags take
+// precedence.
+var (
+	printConfig     = flag.Bool("print-config", false, "print the configuration with secrets redacted, then exit")
+	storageFlag     = flag.String("storage", "", "where passkeys are kept: database, or memory for local development")
+	configOverrides overridesFlag
+)
+
+func init() {
+	flag.Var(&configOverrides, "set", "override a configuration value, such as server.port=8080 (repeatable)")
+}
+
+type overridesFlag []string
+
+func (overrides *overridesFlag) String() string {
+	return strings.Join(*overrides, ", ")
+}
+
+func (overrides *overridesFlag) Set(value string) error {
+	*overrides = append(*overrides, value)
+
+	return nil
+}
+
+// loadConfig applies the parsed flags to the configuration, and validates it. It returns false when the process must
+// exit without starting the server.
+func loadConfig() (bool, error) {
+	if *storageFlag != "" {
+		config.App.Storage = *storageFlag
+	}
+
+	for _, override := range configOverrides {
+		if err := config.App.Override(override); err != nil {
+			return false, fmt.Errorf("apply flags: %w", err)
+		}
+	}
+
+	if err := config.App.Validate(); err != nil {
+		return false, fmt.Errorf("validate config: %w", err)
+	}
+
+	if *printConfig {
+		output, err := yaml.Marshal(config.App.Redacted())
+		if err != nil {
+			return false, fmt.Errorf("encode config: %w", err)
+		}
+
+		_, _ = os.Stdout.Write(output)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// configurePool applies the connection pool settings. Zero values keep the defaults of database/sql.
+func configurePool(database *bun.DB) {
+	pool := config.App.Postgres
+
+	if pool.MaxOpenConns > 0 {
+		database.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+
+	if pool.MaxIdleConns > 0 {
+		database.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+
+	if pool.ConnMaxLifetime > 0 {
+		database.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+
+	if pool.ConnMaxIdleTime > 0 {
+		database.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+}
+
+// configureHash replaces the default hash parameters with the ones set in the configuration.
+func configureHash() {
+	hash := config.App.Hash
+
+	lib.DefaultGenerateParams = &lib.GenerateParams{
+		SaltLength:  lo.CoalesceOrEmpty(hash.SaltLength, lib.DefaultGenerateParams.SaltLength),
+		Iterations:  lo.CoalesceOrEmpty(hash.Iterations, lib.DefaultGenerateParams.Iterations),
+		Memory:      lo.CoalesceOrEmpty(hash.Memory, lib.DefaultGenerateParams.Memory),
+		Parallelism: lo.CoalesceOrEmpty(hash.Parallelism, lib.DefaultGenerateParams.Parallelism),
+		KeyLength:   lo.CoalesceOrEmpty(hash.KeyLength, lib.DefaultGenerateParams.KeyLength),
+	}
+}
+
+// configureRedaction masks the configured keys and the database password in logs.
+func configureRedaction() {
+	config.Logger.Redactor.AddKeys(config.App.Redaction.Keys...)
+
+	dsn, err := url.Parse(config.App.Postgres.DSN)
+	if err != nil || dsn.User == nil {
+		// Passwords of key/value DSNs are masked by the "password" key.
+		return
+	}
+
+	if password, hasPassword := dsn.User.Password(); hasPassword {
+		// The DSN is used for the whole life of the process.
+		_ = config.Logger.Redactor.Track(password)
+	}
+}
@@ -1,28 +1,37 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/uptrace/bun"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/samber/lo"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
 
-	"github.com/a-novel/golib/database"
 	anovelgrpc "github.com/a-novel/golib/grpc"
 	"github.com/a-novel/golib/loggers"
 	"github.com/a-novel/golib/loggers/adapters"
 	"github.com/a-novel/golib/loggers/formatters"
 
 	"github.com/a-novel/uservice-passkeys/config"
-	"github.com/a-novel/uservice-passkeys/migrations"
-	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/gateway"
 	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
+	"github.com/a-novel/uservice-passkeys/pkg/workers"
 )
 
 var rpcServices = []grpc.ServiceDesc{
@@ -31,85 +40,304 @@ var rpcServices = []grpc.ServiceDesc{
 	passkeysv1grpc.DeleteService_ServiceDesc,
 	passkeysv1grpc.GetService_ServiceDesc,
 	passkeysv1grpc.UpdateService_ServiceDesc,
+	namespacesv1.CreateService_ServiceDesc,
+	namespacesv1.DeleteService_ServiceDesc,
+	namespacesv1.GetService_ServiceDesc,
+	namespacesv1.ListService_ServiceDesc,
+	namespacesv1.UpdateService_ServiceDesc,
+	revocationsv1.RevokeService_ServiceDesc,
+	revocationsv1.RestoreService_ServiceDesc,
+	listingsv1.ListService_ServiceDesc,
 }
 
-func getDepsCheck(database *bun.DB) *anovelgrpc.DepsCheck {
+// registeredServices lists the services of rpcServices that are served. Some of them need Postgres.
+func registeredServices(server *grpc.Server) []grpc.ServiceDesc {
+	served := server.GetServiceInfo()
+
+	return lo.Filter(rpcServices, func(service grpc.ServiceDesc, _ int) bool {
+		_, ok := served[service.ServiceName]
+
+		return ok
+	})
+}
+
+// DefaultShutdownTimeout is how long in-flight requests are given to complete on shutdown.
+const DefaultShutdownTimeout = 30 * time.Second
+
+var (
+	ErrShuttingDown    = errors.New("server is shutting down")
+	ErrShutdownTimeout = errors.New("shutdown timeout reached, pending requests were canceled")
+)
+
+// getDepsCheck reports every service as NOT_SERVING once draining is set, so load balancers stop sending requests
+// to this instance while it shuts down.
+func getDepsCheck(pingStorage func() error, draining *atomic.Bool) *anovelgrpc.DepsCheck {
 	return &anovelgrpc.DepsCheck{
 		Dependencies: anovelgrpc.DepCheckCallbacks{
-			"postgres": database.Ping,
+			"storage": pingStorage,
+			"server": func() error {
+				if draining.Load() {
+					return ErrShuttingDown
+				}
+
+				return nil
+			},
 		},
 		Services: anovelgrpc.DepCheckServices{
-			"create": {"postgres"},
-			"delete": {"postgres"},
-			"get":    {"postgres"},
-			"update": {"postgres"},
+			"create": {"storage", "server"},
+			"delete": {"storage", "server"},
+			"get":    {"storage", "server"},
+			"update": {"storage", "server"},
+
+			"namespaces.create": {"storage", "server"},
+			"namespaces.delete": {"storage", "server"},
+			"namespaces.get":    {"storage", "server"},
+			"namespaces.list":   {"storage", "server"},
+			"namespaces.update": {"storage", "server"},
+
+			"revocations.restore": {"storage", "server"},
+			"revocations.revoke":  {"storage", "server"},
+
+			"listings.list": {"storage", "server"},
 		},
 	}
 }
 
-func main() {
-	logger := config.Logger.Formatter
+// startWorkers runs the background workers, until the context is canceled. The returned channel is closed once every
+// worker has stopped. The purge worker is nil when passkeys are kept in memory.
+func startWorkers(ctx context.Context, purgePasskeysWorker workers.PurgePasskeys) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		if purgePasskeysWorker != nil && !config.App.Purge.Disabled {
+			purgePasskeysWorker.Run(ctx)
+		}
+	}()
+
+	return done
+}
+
+// serverSecurity is shared by the gRPC server and the gateway, so both authenticate callers the same way.
+type serverSecurity struct {
+	// TLSConfig is nil when the servers run in plaintext.
+	TLSConfig    *tls.Config
+	Interceptors []grpc.UnaryServerInterceptor
+}
+
+func (security *serverSecurity) grpcOptions() []grpc.ServerOption {
+	options := []grpc.ServerOption{tracingServerOption(), grpc.ChainUnaryInterceptor(security.Interceptors...)}
+	if security.TLSConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(security.TLSConfig)))
+	}
+
+	return options
+}
+
+// getServerSecurity authenticates callers with mTLS when a certificate is configured, or with a bearer token when
+// keys are configured. Callers are then restricted to the tenant of their credentials, rather than the tenant sent in
+// the metadata. The configuration prevents both from being enabled at once.
+func getServerSecurity(
+	ctx context.Context, storageInterceptors []grpc.UnaryServerInterceptor,
+) (*serverSecurity, error) {
+	tlsEnabled := config.App.Server.TLS.CertFile != ""
+	jwtEnabled := config.App.Server.JWT.JWKSFile != "" || config.App.Server.JWT.JWKSURL != ""
 
-	loader := formatters.NewLoader(
-		fmt.Sprintf("Acquiring database connection at %s...", config.App.Postgres.DSN),
-		spinner.Meter,
+	var (
+		security *serverSecurity
+		err      error
 	)
-	logger.Log(loader, loggers.LogLevelInfo)
 
-	postgresDB, closePostgresDB, err := database.OpenDB(config.App.Postgres.DSN)
+	switch {
+	case tlsEnabled:
+		security, err = getTLSServerSecurity()
+	case jwtEnabled:
+		security, err = getJWTServerSecurity(ctx)
+	default:
+		security = &serverSecurity{
+			Interceptors: []grpc.UnaryServerInterceptor{handlers.NewTenantInterceptor(handlers.TenantFromMetadata)},
+		}
+	}
+
 	if err != nil {
-		logger.Log(formatters.NewError(err, "open database conn"), loggers.LogLevelFatal)
+		return nil, err
 	}
-	defer closePostgresDB()
 
-	logger.Log(
-		loader.SetDescription("Database connection successfully acquired.").SetCompleted(),
-		loggers.LogLevelInfo,
+	// Redaction wraps every other interceptor, and the ones of the storage, such as idempotency, run once the tenant
+	// is known.
+	security.Interceptors = append(
+		[]grpc.UnaryServerInterceptor{handlers.NewRedactionInterceptor(config.Logger.Redactor)},
+		append(security.Interceptors, storageInterceptors...)...,
 	)
 
-	if err := database.Migrate(postgresDB, migrations.SQLMigrations, logger); err != nil {
-		logger.Log(formatters.NewError(err, "migrate database"), loggers.LogLevelFatal)
+	return security, nil
+}
+
+func getTLSServerSecurity() (*serverSecurity, error) {
+	tlsConfig := config.App.Server.TLS
+
+	serverTLSConfig, err := handlers.NewServerTLSConfig(tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("load TLS config: %w", err)
+	}
+
+	permissions, err := handlers.LoadPermissions(tlsConfig.PermissionsFile)
+	if err != nil {
+		return nil, fmt.Errorf("load permissions: %w", err)
+	}
+
+	return &serverSecurity{
+		TLSConfig: serverTLSConfig,
+		Interceptors: []grpc.UnaryServerInterceptor{
+			handlers.NewAuthorizationInterceptor(permissions),
+			handlers.NewTenantInterceptor(handlers.TenantFromCertificate(permissions)),
+		},
+	}, nil
+}
+
+// getJWTServerSecurity keeps refreshing the keys of the JWKS URL until the context is canceled.
+func getJWTServerSecurity(ctx context.Context) (*serverSecurity, error) {
+	jwtConfig := config.App.Server.JWT
+
+	keys, err := handlers.NewJWKSKeyfunc(ctx, jwtConfig.JWKSFile, jwtConfig.JWKSURL)
+	if err != nil {
+		return nil, fmt.Errorf("load JWKS: %w", err)
+	}
+
+	var parserOptions []jwt.ParserOption
+
+	if jwtConfig.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(jwtConfig.Issuer))
 	}
 
-	loader = formatters.NewLoader("Setup services...", spinner.Meter)
+	if jwtConfig.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(jwtConfig.Audience))
+	}
+
+	return &serverSecurity{
+		Interceptors: []grpc.UnaryServerInterceptor{
+			handlers.NewJWTInterceptor(keys, parserOptions...),
+			handlers.NewTenantInterceptor(handlers.TenantFromClaims),
+		},
+	}, nil
+}
+
+func main() {
+	flag.Parse()
+	serve()
+}
+
+// serve runs the server with the flags already parsed, until it receives a signal.
+func serve() {
+	logger := config.Logger.Formatter
+
+	start, err := loadConfig()
+	if err != nil {
+		logger.Log(formatters.NewError(err, "load config"), loggers.LogLevelFatal)
+	}
+
+	if !start {
+		return
+	}
+
+	configureHash()
+	configureRedaction()
+	configureMetrics()
+
+	stopTracing := startTracing(logger)
+	defer stopTracing()
+
+	store, closeStorage, err := openStorage(logger)
+	if err != nil {
+		logger.Log(formatters.NewError(err, "open storage"), loggers.LogLevelFatal)
+	}
+	defer closeStorage()
+
+	loader := formatters.NewLoader("Setup services...", spinner.Meter)
 	logger.Log(loader, loggers.LogLevelInfo)
 
 	grpcReporter := adapters.NewGRPC(logger)
 
-	createPasskeyDAO := dao.NewCreatePasskey(postgresDB)
-	deletePasskeyDAO := dao.NewDeletePasskey(postgresDB)
-	getPasskeyDAO := dao.NewGetPasskey(postgresDB)
-	updatePasskeyDAO := dao.NewUpdatePasskey(postgresDB)
+	resolveNamespacePolicyService := services.NewResolveNamespacePolicy(
+		store.getNamespace, config.App.Namespaces.RequireRegistered,
+	)
 
-	createPasskeyService := services.NewCreatePasskey(createPasskeyDAO)
-	deletePasskeyService := services.NewDeletePasskey(deletePasskeyDAO)
-	getPasskeyService := services.NewGetPasskey(getPasskeyDAO)
-	updatePasskeyService := services.NewUpdatePasskey(updatePasskeyDAO)
+	createPasskeyService := services.NewCreatePasskey(store.createPasskey, resolveNamespacePolicyService)
+	deletePasskeyService := services.NewDeletePasskey(store.deletePasskey)
+	getPasskeyService := services.NewGetPasskey(store.getPasskey)
+	updatePasskeyService := services.NewUpdatePasskey(store.updatePasskey, resolveNamespacePolicyService)
 
 	createPasskeyHandler := handlers.NewCreatePasskey(createPasskeyService, grpcReporter)
 	deletePasskeyHandler := handlers.NewDeletePasskey(deletePasskeyService, grpcReporter)
 	getPasskeyHandler := handlers.NewGetPasskey(getPasskeyService, grpcReporter)
 	updatePasskeyHandler := handlers.NewUpdatePasskey(updatePasskeyService, grpcReporter)
 
+	var postgresServices *databaseServices
+	if postgresDB := store.postgres(); postgresDB != nil {
+		postgresServices = newDatabaseServices(postgresDB, grpcReporter, logger)
+	}
+
 	logger.Log(loader.SetDescription("Services successfully setup.").SetCompleted(), loggers.LogLevelInfo)
 
-	listener, server, err := anovelgrpc.StartServer(config.App.Server.Port)
+	workersCTX, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+
+	workersDone := startWorkers(workersCTX, postgresServices.purgeWorker())
+
+	security, err := getServerSecurity(workersCTX, postgresServices.interceptors())
 	if err != nil {
-		logger.Log(formatters.NewError(err, "start server"), loggers.LogLevelFatal)
+		logger.Log(formatters.NewError(err, "configure server"), loggers.LogLevelFatal)
 	}
-	defer anovelgrpc.CloseServer(listener, server)
 
-	reflection.Register(server)
-	healthpb.RegisterHealthServer(server, anovelgrpc.NewHealthServer(getDepsCheck(postgresDB), time.Minute))
+	// The server is created manually, so interceptors and credentials can be registered.
+	server := grpc.NewServer(security.grpcOptions()...)
+
+	var draining atomic.Bool
+
+	if !config.App.Server.DisableReflection {
+		reflection.Register(server)
+	}
+
+	healthpb.RegisterHealthServer(server, anovelgrpc.NewHealthServer(
+		getDepsCheck(store.ping, &draining),
+		lo.CoalesceOrEmpty(config.App.Server.HealthWatchInterval, DefaultHealthWatchInterval),
+	))
 	passkeysv1grpc.RegisterCreateServiceServer(server, createPasskeyHandler)
 	passkeysv1grpc.RegisterDeleteServiceServer(server, deletePasskeyHandler)
 	passkeysv1grpc.RegisterGetServiceServer(server, getPasskeyHandler)
 	passkeysv1grpc.RegisterUpdateServiceServer(server, updatePasskeyHandler)
+	postgresServices.register(server)
 
-	report := formatters.NewDiscoverGRPC(rpcServices, config.App.Server.Port)
+	routes := gateway.PasskeyRoutes(
+		createPasskeyHandler, getPasskeyHandler, updatePasskeyHandler, deletePasskeyHandler,
+	)
+	routes = append(routes, postgresServices.routes()...)
+
+	running, err := listen(server, security, routes, newMetricsHandler(store.database))
+	if err != nil {
+		logger.Log(formatters.NewError(err, "start server"), loggers.LogLevelFatal)
+	}
+
+	report := formatters.NewDiscoverGRPC(registeredServices(server), config.App.Server.Port)
 	logger.Log(report, loggers.LogLevelInfo)
 
-	if err := server.Serve(listener); err != nil {
+	if err := running.serveUntilSignal(); err != nil {
 		logger.Log(formatters.NewError(err, "serve"), loggers.LogLevelFatal)
 	}
+
+	loader = formatters.NewLoader("Shutting down, draining in-flight requests...", spinner.Meter)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	draining.Store(true)
+
+	if !running.gracefulStop(lo.CoalesceOrEmpty(config.App.Server.ShutdownTimeout, DefaultShutdownTimeout)) {
+		logger.Log(formatters.NewError(ErrShutdownTimeout, "drain requests"), loggers.LogLevelWarning)
+	}
+
+	cancelWorkers()
+	<-workersDone
+
+	// The database is closed by the deferred call, once every request and worker is done with it.
+	logger.Log(loader.SetDescription("Server successfully shut down.").SetCompleted(), loggers.LogLevelInfo)
 }
@@ -5,10 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
@@ -16,10 +18,14 @@ import (
 
 	anovelgrpc "github.com/a-novel/golib/grpc"
 	"github.com/a-novel/golib/testutils"
+
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
 )
 
 func init() {
-	go main()
+	// Test binaries parse their own flags, so the server runs with the default ones.
+	go serve()
 }
 
 var servicesToTest = []string{
@@ -27,6 +33,14 @@ var servicesToTest = []string{
 	"delete",
 	"get",
 	"update",
+	"namespaces.create",
+	"namespaces.delete",
+	"namespaces.get",
+	"namespaces.list",
+	"namespaces.update",
+	"revocations.restore",
+	"revocations.revoke",
+	"listings.list",
 }
 
 func TestIntegrationHealth(t *testing.T) {
@@ -157,3 +171,310 @@ func TestIntegrationCRUD(t *testing.T) {
 	require.Error(t, err)
 	testutils.RequireGRPCCodesEqual(t, err, codes.NotFound)
 }
+
+func TestIntegrationNamespaces(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+
+	// Create the RPC client.
+	pool := anovelgrpc.NewConnPool()
+	conn, err := pool.Open("0.0.0.0", 8080, anovelgrpc.ProtocolHTTP)
+	require.NoError(t, err)
+
+	testutils.WaitConn(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	createNamespaceClient := namespacesv1.NewCreateServiceClient(conn)
+	deleteNamespaceClient := namespacesv1.NewDeleteServiceClient(conn)
+	getNamespaceClient := namespacesv1.NewGetServiceClient(conn)
+	listNamespacesClient := namespacesv1.NewListServiceClient(conn)
+	updateNamespaceClient := namespacesv1.NewUpdateServiceClient(conn)
+	createPasskeyClient := passkeysv1grpc.NewCreateServiceClient(conn)
+	getPasskeyClient := passkeysv1grpc.NewGetServiceClient(conn)
+	deletePasskeyClient := passkeysv1grpc.NewDeleteServiceClient(conn)
+
+	// Create namespace
+	createData, err := createNamespaceClient.Exec(ctx, &namespacesv1.CreateServiceExecRequest{
+		Name: "integration-namespace",
+		Policy: &namespacesv1.Policy{
+			MaxTtl:           durationpb.New(time.Hour),
+			StrengthRules:    &namespacesv1.StrengthRules{MinLength: 8, RequireDigit: true},
+			SingleUseDefault: true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "integration-namespace", createData.GetNamespace().GetName())
+	require.Equal(t, "argon2id", createData.GetNamespace().GetPolicy().GetHashAlgorithm())
+
+	// Create namespace again
+	_, err = createNamespaceClient.Exec(ctx, &namespacesv1.CreateServiceExecRequest{
+		Name:   "integration-namespace",
+		Policy: &namespacesv1.Policy{},
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.AlreadyExists)
+
+	// Get namespace
+	getData, err := getNamespaceClient.Exec(ctx, &namespacesv1.GetServiceExecRequest{Name: "integration-namespace"})
+	require.NoError(t, err)
+	require.True(t, getData.GetNamespace().GetPolicy().GetSingleUseDefault())
+
+	// List namespaces
+	listData, err := listNamespacesClient.Exec(ctx, &namespacesv1.ListServiceExecRequest{Limit: 100})
+	require.NoError(t, err)
+	require.NotEmpty(t, listData.GetNamespaces())
+
+	// Create passkey (weak secret)
+	weakCTX := metadata.NewOutgoingContext(ctx, metadata.Pairs("password", "weak"))
+	_, err = createPasskeyClient.Exec(weakCTX, &passkeysv1.CreateServiceExecRequest{Namespace: "integration-namespace"})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.InvalidArgument)
+
+	// Create passkey (ttl too long)
+	passkeyCTX := metadata.NewOutgoingContext(ctx, metadata.Pairs("password", "my-secret-password-1"))
+	_, err = createPasskeyClient.Exec(passkeyCTX, &passkeysv1.CreateServiceExecRequest{
+		Namespace: "integration-namespace",
+		ExpiresIn: durationpb.New(2 * time.Hour),
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.InvalidArgument)
+
+	// Create passkey
+	passkeyData, err := createPasskeyClient.Exec(passkeyCTX, &passkeysv1.CreateServiceExecRequest{
+		Namespace: "integration-namespace",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, passkeyData.GetExpiresAt())
+
+	// Delete non-empty namespace
+	_, err = deleteNamespaceClient.Exec(ctx, &namespacesv1.DeleteServiceExecRequest{Name: "integration-namespace"})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.FailedPrecondition)
+
+	// Redeem single-use passkey
+	_, err = getPasskeyClient.Exec(passkeyCTX, &passkeysv1.GetServiceExecRequest{
+		Id:        passkeyData.GetId(),
+		Namespace: "integration-namespace",
+		Validate:  true,
+	})
+	require.NoError(t, err)
+
+	_, err = getPasskeyClient.Exec(passkeyCTX, &passkeysv1.GetServiceExecRequest{
+		Id:        passkeyData.GetId(),
+		Namespace: "integration-namespace",
+		Validate:  true,
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.NotFound)
+
+	_, err = deletePasskeyClient.Exec(ctx, &passkeysv1.DeleteServiceExecRequest{
+		Id:        passkeyData.GetId(),
+		Namespace: "integration-namespace",
+	})
+	require.NoError(t, err)
+
+	// Update namespace
+	updateData, err := updateNamespaceClient.Exec(ctx, &namespacesv1.UpdateServiceExecRequest{
+		Name:   "integration-namespace",
+		Policy: &namespacesv1.Policy{},
+	})
+	require.NoError(t, err)
+	require.False(t, updateData.GetNamespace().GetPolicy().GetSingleUseDefault())
+	require.NotNil(t, updateData.GetNamespace().GetUpdatedAt())
+
+	// Delete namespace
+	_, err = deleteNamespaceClient.Exec(ctx, &namespacesv1.DeleteServiceExecRequest{Name: "integration-namespace"})
+	require.NoError(t, err)
+
+	_, err = getNamespaceClient.Exec(ctx, &namespacesv1.GetServiceExecRequest{Name: "integration-namespace"})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.NotFound)
+}
+
+func TestIntegrationRevocation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+
+	// Create the RPC client.
+	pool := anovelgrpc.NewConnPool()
+	conn, err := pool.Open("0.0.0.0", 8080, anovelgrpc.ProtocolHTTP)
+	require.NoError(t, err)
+
+	testutils.WaitConn(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	createPasskeyClient := passkeysv1grpc.NewCreateServiceClient(conn)
+	getPasskeyClient := passkeysv1grpc.NewGetServiceClient(conn)
+	revokePasskeyClient := revocationsv1.NewRevokeServiceClient(conn)
+	restorePasskeyClient := revocationsv1.NewRestoreServiceClient(conn)
+
+	passkeyCTX := metadata.NewOutgoingContext(ctx, metadata.Pairs("password", "my-secret-password"))
+	createData, err := createPasskeyClient.Exec(passkeyCTX, &passkeysv1.CreateServiceExecRequest{
+		Namespace: "revocation-namespace",
+	})
+	require.NoError(t, err)
+
+	// Revoke passkey
+	revokeData, err := revokePasskeyClient.Exec(ctx, &revocationsv1.RevokeServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "revocation-namespace",
+		RevokedBy: "integration-test",
+		Reason:    "leaked",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "leaked", revokeData.GetPasskey().GetRevocationReason())
+
+	// Validate revoked passkey
+	_, err = getPasskeyClient.Exec(passkeyCTX, &passkeysv1.GetServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "revocation-namespace",
+		Validate:  true,
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.FailedPrecondition)
+
+	// Restore passkey
+	_, err = restorePasskeyClient.Exec(ctx, &revocationsv1.RestoreServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "revocation-namespace",
+	})
+	require.NoError(t, err)
+
+	_, err = getPasskeyClient.Exec(passkeyCTX, &passkeysv1.GetServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "revocation-namespace",
+		Validate:  true,
+	})
+	require.NoError(t, err)
+
+	// Restore active passkey
+	_, err = restorePasskeyClient.Exec(ctx, &revocationsv1.RestoreServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "revocation-namespace",
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.FailedPrecondition)
+}
+
+func TestIntegrationIdempotency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+
+	// Create the RPC client.
+	pool := anovelgrpc.NewConnPool()
+	conn, err := pool.Open("0.0.0.0", 8080, anovelgrpc.ProtocolHTTP)
+	require.NoError(t, err)
+
+	testutils.WaitConn(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	createPasskeyClient := passkeysv1grpc.NewCreateServiceClient(conn)
+
+	// Keys outlive the test, so use a new one on each run.
+	passkeyCTX := metadata.NewOutgoingContext(ctx, metadata.Pairs(
+		"password", "my-secret-password",
+		"idempotency-key", uuid.NewString(),
+	))
+
+	createData, err := createPasskeyClient.Exec(passkeyCTX, &passkeysv1.CreateServiceExecRequest{
+		Namespace: "idempotency-namespace",
+	})
+	require.NoError(t, err)
+
+	// Replay
+	replayData, err := createPasskeyClient.Exec(passkeyCTX, &passkeysv1.CreateServiceExecRequest{
+		Namespace: "idempotency-namespace",
+	})
+	require.NoError(t, err)
+	require.Equal(t, createData.GetId(), replayData.GetId())
+
+	// Reuse the key with a different payload
+	_, err = createPasskeyClient.Exec(passkeyCTX, &passkeysv1.CreateServiceExecRequest{
+		Namespace: "other-idempotency-namespace",
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.InvalidArgument)
+}
+
+func TestIntegrationTenants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+
+	// Create the RPC client.
+	pool := anovelgrpc.NewConnPool()
+	conn, err := pool.Open("0.0.0.0", 8080, anovelgrpc.ProtocolHTTP)
+	require.NoError(t, err)
+
+	testutils.WaitConn(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	createPasskeyClient := passkeysv1grpc.NewCreateServiceClient(conn)
+	getPasskeyClient := passkeysv1grpc.NewGetServiceClient(conn)
+	deletePasskeyClient := passkeysv1grpc.NewDeleteServiceClient(conn)
+	revokePasskeyClient := revocationsv1.NewRevokeServiceClient(conn)
+
+	tenantACTX := metadata.NewOutgoingContext(ctx, metadata.Pairs(
+		"tenant", "integration-tenant-a",
+		"password", "my-secret-password",
+	))
+	tenantBCTX := metadata.NewOutgoingContext(ctx, metadata.Pairs(
+		"tenant", "integration-tenant-b",
+		"password", "my-secret-password",
+	))
+
+	createData, err := createPasskeyClient.Exec(tenantACTX, &passkeysv1.CreateServiceExecRequest{
+		Namespace: "tenants-namespace",
+	})
+	require.NoError(t, err)
+
+	// Read from another tenant
+	_, err = getPasskeyClient.Exec(tenantBCTX, &passkeysv1.GetServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "tenants-namespace",
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.NotFound)
+
+	// Write from another tenant
+	_, err = revokePasskeyClient.Exec(tenantBCTX, &revocationsv1.RevokeServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "tenants-namespace",
+		RevokedBy: "integration-test",
+		Reason:    "leaked",
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.NotFound)
+
+	_, err = deletePasskeyClient.Exec(tenantBCTX, &passkeysv1.DeleteServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "tenants-namespace",
+	})
+	require.Error(t, err)
+	testutils.RequireGRPCCodesEqual(t, err, codes.NotFound)
+
+	// The passkey is untouched for its own tenant.
+	_, err = getPasskeyClient.Exec(tenantACTX, &passkeysv1.GetServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "tenants-namespace",
+		Validate:  true,
+	})
+	require.NoError(t, err)
+
+	_, err = deletePasskeyClient.Exec(tenantACTX, &passkeysv1.DeleteServiceExecRequest{
+		Id:        createData.GetId(),
+		Namespace: "tenants-namespace",
+	})
+	require.NoError(t, err)
+}
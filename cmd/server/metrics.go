@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+
+	"github.com/a-novel/uservice-passkeys/config"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+// MetricsPath serves the Prometheus metrics.
+const MetricsPath = "/metrics"
+
+// configureMetrics sets the namespaces reported in the labels of the metrics.
+func configureMetrics() {
+	metrics.SetNamespaces(config.App.Metrics.Namespaces)
+}
+
+// databaseNames label the metrics of the connection pool.
+var databaseNames = map[dialect.Name]string{
+	dialect.PG:     "postgres",
+	dialect.SQLite: "sqlite",
+}
+
+// newMetricsHandler serves the metrics of the service, along with the ones of the Go runtime, the process and the
+// connection pool. The database is nil when passkeys are kept in memory.
+func newMetricsHandler(database *bun.DB) http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.Collectors()...)
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	if database != nil {
+		registry.MustRegister(collectors.NewDBStatsCollector(database.DB, databaseNames[database.Dialect().Name()]))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(http.MethodGet+" "+MetricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return mux
+}
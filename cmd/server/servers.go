@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/a-novel/uservice-passkeys/config"
+	"github.com/a-novel/uservice-passkeys/pkg/gateway"
+)
+
+// HTTPReadHeaderTimeout bounds the time clients have to send the headers of a request to the HTTP servers.
+const HTTPReadHeaderTimeout = 10 * time.Second
+
+// httpServer runs alongside the gRPC server, such as the gateway or the metrics endpoint.
+type httpServer struct {
+	name     string
+	server   *http.Server
+	listener net.Listener
+}
+
+// servers runs the gRPC server, and the HTTP servers that are enabled.
+type servers struct {
+	grpc         *grpc.Server
+	grpcListener net.Listener
+
+	http []*httpServer
+}
+
+// listen opens the ports of the servers. The gateway goes through the same interceptors and TLS configuration as the
+// gRPC server. It also serves the services registered on the gRPC server over the Connect and gRPC-Web protocols, so
+// they must be registered beforehand. The metrics handler is served in plaintext.
+func listen(
+	server *grpc.Server, security *serverSecurity, routes []gateway.Route, metricsHandler http.Handler,
+) (*servers, error) {
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.App.Server.Port))
+	if err != nil {
+		return nil, fmt.Errorf("listen gRPC: %w", err)
+	}
+
+	running := &servers{grpc: server, grpcListener: grpcListener}
+
+	if config.App.Server.Gateway.Port != 0 {
+		handler, err := newGatewayHandler(server, security, routes)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := running.listenHTTP("gateway", config.App.Server.Gateway.Port, handler, security.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.App.Metrics.Port != 0 {
+		if err := running.listenHTTP("metrics", config.App.Metrics.Port, metricsHandler, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return running, nil
+}
+
+func newGatewayHandler(
+	server *grpc.Server, security *serverSecurity, routes []gateway.Route,
+) (http.Handler, error) {
+	handler, err := gateway.NewHandler(
+		routes,
+		gateway.ChainUnaryInterceptors(security.Interceptors...),
+		gateway.OpenAPIInfo{Title: "Passkeys", Version: "v1"},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create gateway: %w", err)
+	}
+
+	handler, err = gateway.NewRPCHandler(server, gateway.PasskeyServices, handler)
+	if err != nil {
+		return nil, fmt.Errorf("create RPC gateway: %w", err)
+	}
+
+	handler = traceGateway(handler)
+
+	return gateway.NewCORSHandler(handler, gateway.CORS{
+		AllowedOrigins: config.App.Server.Gateway.CORS.AllowedOrigins,
+		MaxAge:         config.App.Server.Gateway.CORS.MaxAge,
+	}), nil
+}
+
+// listenHTTP opens the port of an HTTP server. It serves over TLS when tlsConfig is set.
+func (running *servers) listenHTTP(name string, port int, handler http.Handler, tlsConfig *tls.Config) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", name, err)
+	}
+
+	running.http = append(running.http, &httpServer{
+		name: name,
+		server: &http.Server{
+			Handler:           handler,
+			TLSConfig:         tlsConfig,
+			ReadHeaderTimeout: HTTPReadHeaderTimeout,
+		},
+		listener: listener,
+	})
+
+	return nil
+}
+
+// serveUntilSignal serves requests until the process receives SIGINT or SIGTERM.
+func (running *servers) serveUntilSignal() error {
+	signalCTX, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	serveErr := make(chan error, 1+len(running.http))
+
+	go func() {
+		serveErr <- running.grpc.Serve(running.grpcListener)
+	}()
+
+	for _, server := range running.http {
+		go func() {
+			serveErr <- server.serve()
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("serve: %w", err)
+	case <-signalCTX.Done():
+		return nil
+	}
+}
+
+func (server *httpServer) serve() error {
+	var err error
+
+	if server.server.TLSConfig != nil {
+		// Certificates are already loaded in the TLS configuration.
+		err = server.server.ServeTLS(server.listener, "", "")
+	} else {
+		err = server.server.Serve(server.listener)
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", server.name, err)
+}
+
+// gracefulStop waits for in-flight requests to complete, and cancels the remaining ones once the timeout expires. It
+// returns false if requests had to be canceled.
+func (running *servers) gracefulStop(timeout time.Duration) bool {
+	var (
+		stopping sync.WaitGroup
+		canceled atomic.Bool
+	)
+
+	stopping.Add(1 + len(running.http))
+
+	go func() {
+		defer stopping.Done()
+
+		if !gracefulStopGRPC(running.grpc, timeout) {
+			canceled.Store(true)
+		}
+	}()
+
+	for _, server := range running.http {
+		go func() {
+			defer stopping.Done()
+
+			if !gracefulStopHTTP(server.server, timeout) {
+				canceled.Store(true)
+			}
+		}()
+	}
+
+	stopping.Wait()
+
+	return !canceled.Load()
+}
+
+func gracefulStopGRPC(server *grpc.Server, timeout time.Duration) bool {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return true
+	case <-time.After(timeout):
+		server.Stop()
+		<-stopped
+
+		return false
+	}
+}
+
+func gracefulStopHTTP(server *http.Server, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		_ = server.Close()
+
+		return false
+	}
+
+	return true
+}
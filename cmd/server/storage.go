@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"google.golang.org/grpc"
+
+	"github.com/a-novel/golib/database"
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/adapters"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/config"
+	"github.com/a-novel/uservice-passkeys/migrations"
+	sqlitemigrations "github.com/a-novel/uservice-passkeys/migrations/sqlite"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/memory"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/sqlite"
+	"github.com/a-novel/uservice-passkeys/pkg/gateway"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	"github.com/a-novel/uservice-passkeys/pkg/workers"
+)
+
+// storage holds the DAOs of the passkeys services, which can run over any storage.
+type storage struct {
+	// database is nil when passkeys are kept in memory.
+	database *bun.DB
+
+	createPasskey dao.CreatePasskey
+	deletePasskey dao.DeletePasskey
+	getPasskey    dao.GetPasskey
+	updatePasskey dao.UpdatePasskey
+	getNamespace  dao.GetNamespace
+}
+
+func (storage *storage) ping() error {
+	if storage.database == nil {
+		return nil
+	}
+
+	return storage.database.Ping() //nolint:wrapcheck
+}
+
+// postgres returns the database if it is Postgres, or nil. Other services need it.
+func (storage *storage) postgres() *bun.DB {
+	if storage.database == nil || storage.database.Dialect().Name() != dialect.PG {
+		return nil
+	}
+
+	return storage.database
+}
+
+// openStorage connects to the database and migrates it, unless passkeys are kept in memory. The database is picked
+// from the scheme of the DSN. The returned function releases the storage.
+func openStorage(logger formatters.Formatter) (*storage, func(), error) {
+	if config.App.Storage == config.StorageMemory {
+		return openMemory(logger), func() {}, nil
+	}
+
+	if path, ok := config.App.SQLitePath(); ok {
+		return openSQLite(path, logger)
+	}
+
+	return openPostgres(logger)
+}
+
+func openMemory(logger formatters.Formatter) *storage {
+	logger.Log(
+		formatters.NewBase("Passkeys are kept in memory, and will be lost on shutdown."),
+		loggers.LogLevelWarning,
+	)
+
+	store := memory.NewStore(time.Now)
+
+	return &storage{
+		createPasskey: memory.NewCreatePasskey(store),
+		deletePasskey: memory.NewDeletePasskey(store),
+		getPasskey:    memory.NewGetPasskey(store),
+		updatePasskey: memory.NewUpdatePasskey(store),
+		getNamespace:  memory.NewGetNamespace(),
+	}
+}
+
+// openSQLite serves the passkeys services only, like the memory storage, but keeps passkeys in the file at path.
+func openSQLite(path string, logger formatters.Formatter) (*storage, func(), error) {
+	loader := formatters.NewLoader(fmt.Sprintf("Opening SQLite database at %s...", path), spinner.Meter)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	sqliteDB, closeSQLiteDB, err := sqlite.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open database: %w", err)
+	}
+
+	traceDatabase(sqliteDB)
+
+	logger.Log(loader.SetDescription("SQLite database successfully opened.").SetCompleted(), loggers.LogLevelInfo)
+
+	if err := database.Migrate(sqliteDB, sqlitemigrations.SQLMigrations, logger); err != nil {
+		closeSQLiteDB()
+
+		return nil, nil, fmt.Errorf("migrate database: %w", err)
+	}
+
+	return &storage{
+		database:      sqliteDB,
+		createPasskey: sqlite.NewCreatePasskey(sqliteDB),
+		deletePasskey: sqlite.NewDeletePasskey(sqliteDB),
+		getPasskey:    sqlite.NewGetPasskey(sqliteDB, time.Now),
+		updatePasskey: sqlite.NewUpdatePasskey(sqliteDB),
+		// Namespaces cannot be registered, so passkeys use the default policy.
+		getNamespace: memory.NewGetNamespace(),
+	}, closeSQLiteDB, nil
+}
+
+func openPostgres(logger formatters.Formatter) (*storage, func(), error) {
+	loader := formatters.NewLoader(
+		fmt.Sprintf("Acquiring database connection at %s...", config.App.Redacted().Postgres.DSN),
+		spinner.Meter,
+	)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	postgresDB, closePostgresDB, err := database.OpenDB(config.App.Postgres.DSN)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open database conn: %w", err)
+	}
+
+	configurePool(postgresDB)
+	traceDatabase(postgresDB)
+
+	logger.Log(
+		loader.SetDescription("Database connection successfully acquired.").SetCompleted(),
+		loggers.LogLevelInfo,
+	)
+
+	if err := database.Migrate(postgresDB, migrations.SQLMigrations, logger); err != nil {
+		closePostgresDB()
+
+		return nil, nil, fmt.Errorf("migrate database: %w", err)
+	}
+
+	return &storage{
+		database:      postgresDB,
+		createPasskey: dao.NewCreatePasskey(postgresDB),
+		deletePasskey: dao.NewDeletePasskey(postgresDB),
+		getPasskey:    dao.NewGetPasskey(postgresDB),
+		updatePasskey: dao.NewUpdatePasskey(postgresDB),
+		getNamespace:  dao.NewGetNamespace(postgresDB),
+	}, closePostgresDB, nil
+}
+
+// databaseServices need Postgres. They are not available with the other storages, in which case the value is nil.
+type databaseServices struct {
+	createNamespace handlers.CreateNamespace
+	deleteNamespace handlers.DeleteNamespace
+	getNamespace    handlers.GetNamespace
+	listNamespaces  handlers.ListNamespaces
+	updateNamespace handlers.UpdateNamespace
+	revokePasskey   handlers.RevokePasskey
+	restorePasskey  handlers.RestorePasskey
+	listPasskeys    handlers.ListPasskeys
+
+	idempotencyInterceptor grpc.UnaryServerInterceptor
+	purgePasskeysWorker    workers.PurgePasskeys
+}
+
+func newDatabaseServices(
+	postgresDB *bun.DB, grpcReporter adapters.GRPC, logger formatters.Formatter,
+) *databaseServices {
+	revokePasskeyDAO := dao.NewRevokePasskey(postgresDB)
+	restorePasskeyDAO := dao.NewRestorePasskey(postgresDB)
+	listPasskeysDAO := dao.NewListPasskeys(postgresDB)
+
+	createNamespaceDAO := dao.NewCreateNamespace(postgresDB)
+	deleteNamespaceDAO := dao.NewDeleteNamespace(postgresDB)
+	getNamespaceDAO := dao.NewGetNamespace(postgresDB)
+	listNamespacesDAO := dao.NewListNamespaces(postgresDB)
+	updateNamespaceDAO := dao.NewUpdateNamespace(postgresDB)
+
+	lockPurgeDAO := dao.NewLockPurge(postgresDB)
+	purgePasskeysDAO := dao.NewPurgePasskeys(postgresDB)
+	purgeIdempotencyKeysDAO := dao.NewPurgeIdempotencyKeys(postgresDB)
+
+	revokePasskeyService := services.NewRevokePasskey(revokePasskeyDAO)
+	restorePasskeyService := services.NewRestorePasskey(
+		restorePasskeyDAO, lo.CoalesceOrEmpty(config.App.Revocation.RestoreWindow, services.DefaultRestoreWindow),
+	)
+	listPasskeysService := services.NewListPasskeys(listPasskeysDAO)
+
+	createNamespaceService := services.NewCreateNamespace(createNamespaceDAO)
+	deleteNamespaceService := services.NewDeleteNamespace(deleteNamespaceDAO)
+	getNamespaceService := services.NewGetNamespace(getNamespaceDAO)
+	listNamespacesService := services.NewListNamespaces(listNamespacesDAO)
+	updateNamespaceService := services.NewUpdateNamespace(updateNamespaceDAO)
+
+	return &databaseServices{
+		createNamespace: handlers.NewCreateNamespace(createNamespaceService, grpcReporter),
+		deleteNamespace: handlers.NewDeleteNamespace(deleteNamespaceService, grpcReporter),
+		getNamespace:    handlers.NewGetNamespace(getNamespaceService, grpcReporter),
+		listNamespaces:  handlers.NewListNamespaces(listNamespacesService, grpcReporter),
+		updateNamespace: handlers.NewUpdateNamespace(updateNamespaceService, grpcReporter),
+		revokePasskey:   handlers.NewRevokePasskey(revokePasskeyService, grpcReporter),
+		restorePasskey:  handlers.NewRestorePasskey(restorePasskeyService, grpcReporter),
+		listPasskeys:    handlers.NewListPasskeys(listPasskeysService, grpcReporter),
+
+		idempotencyInterceptor: newIdempotencyInterceptor(postgresDB, logger),
+		purgePasskeysWorker: workers.NewPurgePasskeys(
+			lockPurgeDAO, purgePasskeysDAO, purgeIdempotencyKeysDAO, workers.PurgePasskeysConfig{
+				Interval:  config.App.Purge.Interval,
+				Retention: config.App.Purge.Retention,
+				BatchSize: config.App.Purge.BatchSize,
+			}, logger,
+		),
+	}
+}
+
+// newIdempotencyInterceptor replays the responses of requests sent with an idempotency key. It is disabled when no
+// fingerprint key is configured, in which case idempotency keys are ignored.
+func newIdempotencyInterceptor(postgresDB *bun.DB, logger formatters.Formatter) grpc.UnaryServerInterceptor {
+	if config.App.Idempotency.FingerprintKey == "" {
+		logger.Log(
+			formatters.NewBase("No idempotency fingerprint key is set: idempotency keys are ignored."),
+			loggers.LogLevelWarning,
+		)
+
+		return nil
+	}
+
+	claimIdempotencyKeyService := services.NewClaimIdempotencyKey(
+		dao.NewClaimIdempotencyKey(postgresDB),
+		lo.CoalesceOrEmpty(config.App.Idempotency.TTL, services.DefaultIdempotencyTTL),
+	)
+	completeIdempotencyKeyService := services.NewCompleteIdempotencyKey(dao.NewCompleteIdempotencyKey(postgresDB))
+	releaseIdempotencyKeyService := services.NewReleaseIdempotencyKey(dao.NewReleaseIdempotencyKey(postgresDB))
+
+	return handlers.NewIdempotencyInterceptor(
+		claimIdempotencyKeyService, completeIdempotencyKeyService, releaseIdempotencyKeyService,
+		[]byte(config.App.Idempotency.FingerprintKey),
+	)
+}
+
+func (postgres *databaseServices) register(server *grpc.Server) {
+	if postgres == nil {
+		return
+	}
+
+	namespacesv1.RegisterCreateServiceServer(server, postgres.createNamespace)
+	namespacesv1.RegisterDeleteServiceServer(server, postgres.deleteNamespace)
+	namespacesv1.RegisterGetServiceServer(server, postgres.getNamespace)
+	namespacesv1.RegisterListServiceServer(server, postgres.listNamespaces)
+	namespacesv1.RegisterUpdateServiceServer(server, postgres.updateNamespace)
+	revocationsv1.RegisterRevokeServiceServer(server, postgres.revokePasskey)
+	revocationsv1.RegisterRestoreServiceServer(server, postgres.restorePasskey)
+	listingsv1.RegisterListServiceServer(server, postgres.listPasskeys)
+}
+
+// routes returns the gateway routes of the services that rely on the database.
+func (postgres *databaseServices) routes() []gateway.Route {
+	if postgres == nil {
+		return nil
+	}
+
+	return gateway.ListPasskeyRoutes(postgres.listPasskeys)
+}
+
+// interceptors returns the interceptors that rely on the database, such as idempotency.
+func (postgres *databaseServices) interceptors() []grpc.UnaryServerInterceptor {
+	if postgres == nil || postgres.idempotencyInterceptor == nil {
+		return nil
+	}
+
+	return []grpc.UnaryServerInterceptor{postgres.idempotencyInterceptor}
+}
+
+func (postgres *databaseServices) purgeWorker() workers.PurgePasskeys {
+	if postgres == nil {
+		return nil
+	}
+
+	return postgres.purgePasskeysWorker
+}
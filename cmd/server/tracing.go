@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/extra/bunotel"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/grpc"
+
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/config"
+)
+
+// DefaultServiceName identifies the spans of the service, unless OTEL_SERVICE_NAME is set.
+const DefaultServiceName = "uservice-passkeys"
+
+// DefaultTracingSampleRatio records every trace started by the service.
+const DefaultTracingSampleRatio = 1.0
+
+// TracingShutdownTimeout bounds the time spent exporting the remaining spans on shutdown.
+const TracingShutdownTimeout = 5 * time.Second
+
+// configureTracing registers the exporter of the configuration. It returns a function that flushes the remaining
+// spans. Incoming trace context is propagated even when tracing is disabled, so traces are not broken by the service.
+func configureTracing(ctx context.Context) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	if config.App.Tracing.Exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newSpanExporter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create exporter: %w", err)
+	}
+
+	serviceResource, err := resource.New(
+		ctx,
+		resource.WithAttributes(semconv.ServiceName(DefaultServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create resource: %w", err)
+	}
+
+	ratio := lo.CoalesceOrEmpty(config.App.Tracing.SampleRatio, DefaultTracingSampleRatio)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource),
+		// Traces started by callers follow their sampling decision.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// startTracing configures tracing, and returns a function that flushes the remaining spans on shutdown.
+func startTracing(logger formatters.Formatter) func() {
+	shutdown, err := configureTracing(context.Background())
+	if err != nil {
+		logger.Log(formatters.NewError(err, "configure tracing"), loggers.LogLevelFatal)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), TracingShutdownTimeout)
+		defer cancel()
+
+		if err := shutdown(ctx); err != nil {
+			logger.Log(formatters.NewError(err, "flush spans"), loggers.LogLevelWarning)
+		}
+	}
+}
+
+func newSpanExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if config.App.Tracing.Exporter == "stdout" {
+		return stdouttrace.New(stdouttrace.WithPrettyPrint()) //nolint:wrapcheck
+	}
+
+	var options []otlptracegrpc.Option
+	if config.App.Tracing.Endpoint != "" {
+		options = append(options, otlptracegrpc.WithEndpointURL(config.App.Tracing.Endpoint))
+	}
+
+	return otlptracegrpc.New(ctx, options...) //nolint:wrapcheck
+}
+
+// tracingServerOption creates a span for every gRPC call, as the parent of the spans of the handlers. Health checks
+// are too frequent to be worth tracing.
+func tracingServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler(
+		otelgrpc.WithFilter(filters.Not(filters.HealthCheck())),
+	))
+}
+
+// traceGateway creates a span for every request of the gateway, and reads the trace context of its headers.
+func traceGateway(handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(handler, "gateway")
+}
+
+// traceDatabase creates a span for every query. Queries are recorded without their arguments, so secrets and
+// hashes stay out of the spans.
+func traceDatabase(database *bun.DB) {
+	database.AddQueryHook(bunotel.NewQueryHook(bunotel.WithFormattedQueries(false)))
+}
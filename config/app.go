@@ -2,6 +2,8 @@ package config
 
 import (
 	_ "embed"
+	"strings"
+	"time"
 
 	"github.com/a-novel/golib/deploy"
 )
@@ -9,13 +11,127 @@ import (
 //go:embed app.yaml
 var appFile []byte
 
+// Storages where passkeys can be kept.
+const (
+	StorageDatabase = "database"
+	StorageMemory   = "memory"
+)
+
+// MinFingerprintKeyLength is the minimum length of the idempotency fingerprint key.
+const MinFingerprintKeyLength = 32
+
+// SQLiteScheme starts the DSNs of SQLite databases, followed by the path of the file. Other DSNs connect to Postgres.
+const SQLiteScheme = "sqlite://"
+
+// AppType is the configuration of the server. Empty values use the defaults of the package that consumes them, and
+// are documented in app.yaml.
 type AppType struct {
 	Server struct {
-		Port int `yaml:"port"`
+		Port int `validate:"required,min=1,max=65535" yaml:"port"`
+		// ShutdownTimeout is how long in-flight requests are given to complete on shutdown.
+		ShutdownTimeout time.Duration `validate:"min=0" yaml:"shutdown_timeout"`
+		// HealthWatchInterval is how often health statuses are sent to watching clients.
+		HealthWatchInterval time.Duration `validate:"min=0" yaml:"health_watch_interval"`
+		// DisableReflection hides the schema of the services from clients.
+		DisableReflection bool `yaml:"disable_reflection"`
+		Gateway           struct {
+			// Port serves the HTTP/JSON gateway. The gateway is disabled when it is not set.
+			Port int `validate:"omitempty,min=1,max=65535" yaml:"port"`
+			// CORS allows browsers to call the gateway from other origins.
+			CORS struct {
+				AllowedOrigins []string      `yaml:"allowed_origins"`
+				MaxAge         time.Duration `validate:"min=0"       yaml:"max_age"`
+			} `yaml:"cors"`
+		} `yaml:"gateway"`
+		TLS struct {
+			// Every file must be set to enable TLS.
+			CertFile        string `yaml:"cert_file"`
+			KeyFile         string `yaml:"key_file"`
+			ClientCAFile    string `yaml:"client_ca_file"`
+			PermissionsFile string `yaml:"permissions_file"`
+		} `yaml:"tls"`
+		JWT struct {
+			JWKSFile string `yaml:"jwks_file"`
+			JWKSURL  string `validate:"omitempty,url" yaml:"jwks_url"`
+			Issuer   string `yaml:"issuer"`
+			Audience string `yaml:"audience"`
+		} `yaml:"jwt"`
 	} `yaml:"server"`
+	// Storage defaults to a database, Postgres or SQLite depending on the DSN. The memory storage is meant for local
+	// development. Only Postgres serves every service: the others only serve the passkeys ones, and reject the
+	// settings of the other services.
+	Storage  string `validate:"omitempty,oneof=database memory" yaml:"storage"`
 	Postgres struct {
-		DSN string `yaml:"dsn"`
+		// DSN is required by the database storage. It can point to a SQLite file, in which case the connection pool
+		// settings are ignored.
+		DSN string `secret:"true" yaml:"dsn"`
+		// Connection pool. Zero values keep the defaults of database/sql.
+		MaxOpenConns    int           `validate:"min=0" yaml:"max_open_conns"`
+		MaxIdleConns    int           `validate:"min=0" yaml:"max_idle_conns"`
+		ConnMaxLifetime time.Duration `validate:"min=0" yaml:"conn_max_lifetime"`
+		ConnMaxIdleTime time.Duration `validate:"min=0" yaml:"conn_max_idle_time"`
 	} `yaml:"postgres"`
+	// Hash overrides the default Argon2id parameters, used by namespaces without their own.
+	Hash struct {
+		SaltLength  uint   `validate:"omitempty,min=8,max=1024"       yaml:"salt_length"`
+		Iterations  uint32 `validate:"omitempty,min=1,max=100"        yaml:"iterations"`
+		Memory      uint32 `validate:"omitempty,min=1024,max=4194304" yaml:"memory"`
+		Parallelism uint8  `validate:"omitempty,min=1"                yaml:"parallelism"`
+		KeyLength   uint32 `validate:"omitempty,min=16,max=1024"      yaml:"key_length"`
+	} `yaml:"hash"`
+	Namespaces struct {
+		RequireRegistered bool `yaml:"require_registered"`
+	} `yaml:"namespaces"`
+	Revocation struct {
+		RestoreWindow time.Duration `validate:"min=0" yaml:"restore_window"`
+	} `yaml:"revocation"`
+	Idempotency struct {
+		TTL time.Duration `validate:"min=0" yaml:"ttl"`
+		// FingerprintKey keys the hashes of the requests sent with an idempotency key, and must be the same on every
+		// replica. Idempotency keys are ignored when it is not set.
+		FingerprintKey string `secret:"true" yaml:"fingerprint_key"`
+	} `yaml:"idempotency"`
+	Redaction struct {
+		// Keys are masked in logs and error messages, in addition to lib.DefaultSensitiveKeys.
+		Keys []string `yaml:"keys"`
+	} `yaml:"redaction"`
+	Metrics struct {
+		// Port serves the Prometheus metrics at /metrics. Metrics are not served when it is not set.
+		Port int `validate:"omitempty,min=1,max=65535" yaml:"port"`
+		// Namespaces are reported in the namespace label of the metrics. Other namespaces share the "other" label, so
+		// the number of series stays bounded.
+		Namespaces []string `yaml:"namespaces"`
+	} `yaml:"metrics"`
+	// Tracing exports OpenTelemetry spans. It is disabled when no exporter is set.
+	Tracing struct {
+		Exporter string `validate:"omitempty,oneof=otlp stdout" yaml:"exporter"`
+		// Endpoint of the OTLP collector. The standard OTEL_EXPORTER_OTLP_* variables apply when it is empty.
+		Endpoint    string  `validate:"omitempty,url" yaml:"endpoint"`
+		SampleRatio float64 `validate:"min=0,max=1"   yaml:"sample_ratio"`
+	} `yaml:"tracing"`
+	Purge struct {
+		// Disabled stops this instance from purging passkeys, for example when another deployment takes care of it.
+		Disabled  bool          `yaml:"disabled"`
+		Interval  time.Duration `validate:"min=0" yaml:"interval"`
+		Retention time.Duration `validate:"min=0" yaml:"retention"`
+		BatchSize int           `validate:"min=0" yaml:"batch_size"`
+	} `yaml:"purge"`
+}
+
+// SQLitePath returns the file of the SQLite database that keeps passkeys, if the DSN points to one.
+func (app *AppType) SQLitePath() (string, bool) {
+	if app.Storage == StorageMemory {
+		return "", false
+	}
+
+	return strings.CutPrefix(app.Postgres.DSN, SQLiteScheme)
+}
+
+// UsesPostgres tells whether passkeys are kept in Postgres, the only storage that serves every service.
+func (app *AppType) UsesPostgres() bool {
+	_, sqlite := app.SQLitePath()
+
+	return app.Storage != StorageMemory && !sqlite
 }
 
 var App = deploy.LoadConfig[AppType](
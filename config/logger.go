@@ -9,6 +9,8 @@ import (
 	"github.com/a-novel/golib/deploy"
 	"github.com/a-novel/golib/loggers"
 	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 )
 
 //go:embed logger-dev.yaml
@@ -22,6 +24,8 @@ type LoggerType struct {
 	Type    string `yaml:"type"`
 
 	Formatter formatters.Formatter
+	// Redactor masks secrets before they reach the logs.
+	Redactor *lib.Redactor
 }
 
 var Logger = deploy.LoadConfig[LoggerType](
@@ -30,12 +34,14 @@ var Logger = deploy.LoadConfig[LoggerType](
 )
 
 func init() {
+	Logger.Redactor = lib.NewRedactor()
+
 	switch Logger.Type {
 	case "console":
-		logger := loggers.NewSTDOut()
+		logger := lib.RedactConsole(loggers.NewSTDOut(), Logger.Redactor)
 		Logger.Formatter = formatters.NewConsoleFormatter(logger, !Logger.Dynamic)
 	case "json":
-		logger := loggers.NewZeroLog(zerolog.New(os.Stdout))
+		logger := lib.RedactJSON(loggers.NewZeroLog(zerolog.New(os.Stdout)), Logger.Redactor)
 		Logger.Formatter = formatters.NewJSONFormatter(logger)
 	default:
 		panic("unknown logger type " + Logger.Type)
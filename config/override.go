@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// RedactedValue replaces secrets in the output of Redacted.
+const RedactedValue = "REDACTED"
+
+var ErrInvalidOverride = errors.New("invalid override")
+
+// Override sets a single value from its YAML path, such as "server.port=8080". Values are decoded as YAML scalars, so
+// durations are written like "1h".
+func (app *AppType) Override(assignment string) error {
+	path, rawValue, found := strings.Cut(assignment, "=")
+	if !found || path == "" {
+		return fmt.Errorf("%w: '%s': expected path=value", ErrInvalidOverride, assignment)
+	}
+
+	var value any
+	if err := yaml.Unmarshal([]byte(rawValue), &value); err != nil {
+		// Keep values that are not valid YAML as plain strings.
+		value = rawValue
+	}
+
+	keys := strings.Split(path, ".")
+	for index := len(keys) - 1; index >= 0; index-- {
+		value = map[string]any{keys[index]: value}
+	}
+
+	document, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%w: '%s': %w", ErrInvalidOverride, assignment, err)
+	}
+
+	// Strict mode rejects paths that do not exist, so typos are not silently ignored.
+	if err := yaml.UnmarshalWithOptions(document, app, yaml.Strict()); err != nil {
+		return fmt.Errorf("%w: '%s': %w", ErrInvalidOverride, assignment, err)
+	}
+
+	return nil
+}
+
+// Redacted returns a copy of the configuration, where values tagged as secret are hidden. The password of URLs is
+// redacted, so the rest of the URL can still be checked.
+func (app *AppType) Redacted() *AppType {
+	output := *app
+	redactSecrets(reflect.ValueOf(&output).Elem())
+
+	return &output
+}
+
+func redactSecrets(value reflect.Value) {
+	for fieldIndex := range value.NumField() {
+		field := value.Field(fieldIndex)
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			redactSecrets(field)
+		case field.Kind() == reflect.String && value.Type().Field(fieldIndex).Tag.Get("secret") == "true":
+			field.SetString(redactSecret(field.String()))
+		}
+	}
+}
+
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(secret)
+	if err != nil || parsed.User == nil {
+		return RedactedValue
+	}
+
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = url.UserPassword(parsed.User.Username(), RedactedValue)
+	}
+
+	return parsed.String()
+}
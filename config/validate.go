@@ -0,0 +1,211 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrInvalidConfig      = errors.New("invalid config")
+	ErrInvalidConfigValue = errors.New("invalid value")
+	ErrIncompleteTLS      = errors.New("cert_file, key_file, client_ca_file and permissions_file must be set together")
+	ErrConflictingAuth    = errors.New("TLS and JWT authentication cannot be enabled together")
+	ErrConflictingJWKS    = errors.New("jwks_file and jwks_url cannot be set together")
+	ErrRetentionTooShort  = errors.New("purge retention must be longer than the revocation restore window")
+	ErrConflictingPorts   = errors.New("two servers cannot listen on the same port")
+	ErrUnsupportedStorage = errors.New("only supported with Postgres")
+)
+
+var appValidate = newAppValidate()
+
+// newAppValidate reports fields using their YAML path, so errors match the configuration file.
+func newAppValidate() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	return validate
+}
+
+// describeFieldError turns a validation failure into a message that can be read without knowing the validator.
+func describeFieldError(fieldErr validator.FieldError) error {
+	// Remove the name of the root struct.
+	_, path, _ := strings.Cut(fieldErr.Namespace(), ".")
+
+	var rule string
+
+	switch fieldErr.Tag() {
+	case "required":
+		rule = "is required"
+	case "min":
+		rule = "must be at least " + fieldErr.Param()
+	case "max":
+		rule = "must be at most " + fieldErr.Param()
+	case "url":
+		rule = "must be a valid URL"
+	case "oneof":
+		rule = "must be one of: " + strings.Join(strings.Fields(fieldErr.Param()), ", ")
+	default:
+		rule = fmt.Sprintf("must satisfy '%s=%s'", fieldErr.Tag(), fieldErr.Param())
+	}
+
+	return fmt.Errorf("%s: %w: %s (got '%v')", path, ErrInvalidConfigValue, rule, fieldErr.Value())
+}
+
+// Validate checks the configuration, and reports every invalid value at once.
+func (app *AppType) Validate() error {
+	errs, err := app.validateFields()
+	if err != nil {
+		return errors.Join(ErrInvalidConfig, err)
+	}
+
+	errs = append(errs, app.validateStorage()...)
+	errs = append(errs, app.validateAuth()...)
+	errs = append(errs, app.validatePorts()...)
+
+	// Unset values fall back to defaults, which are consistent with each other.
+	if app.Purge.Retention != 0 && app.Revocation.RestoreWindow != 0 &&
+		app.Purge.Retention < app.Revocation.RestoreWindow {
+		errs = append(errs, fmt.Errorf("purge.retention: %w", ErrRetentionTooShort))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func (app *AppType) validateFields() ([]error, error) {
+	err := appValidate.Struct(app)
+	if err == nil {
+		return nil, nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return nil, err //nolint:wrapcheck
+	}
+
+	errs := make([]error, len(fieldErrs))
+	for index, fieldErr := range fieldErrs {
+		errs[index] = describeFieldError(fieldErr)
+	}
+
+	return errs, nil
+}
+
+// validateStorage requires a database, unless passkeys are kept in memory. Postgres serves idempotent retries once a
+// fingerprint key is set, which must be long enough.
+func (app *AppType) validateStorage() []error {
+	if app.Storage != StorageMemory && app.Postgres.DSN == "" {
+		return []error{fmt.Errorf("postgres.dsn: %w: is required", ErrInvalidConfigValue)}
+	}
+
+	if path, sqlite := app.SQLitePath(); sqlite && path == "" {
+		return []error{fmt.Errorf("postgres.dsn: %w: the SQLite file is missing", ErrInvalidConfigValue)}
+	}
+
+	if !app.UsesPostgres() {
+		return app.validatePostgresOnly()
+	}
+
+	// The key is not reported, unlike other invalid values.
+	key := app.Idempotency.FingerprintKey
+	if key != "" && len(key) < MinFingerprintKeyLength {
+		return []error{fmt.Errorf(
+			"idempotency.fingerprint_key: %w: must be at least %d characters long",
+			ErrInvalidConfigValue, MinFingerprintKeyLength,
+		)}
+	}
+
+	return nil
+}
+
+// validatePostgresOnly rejects the settings of the services that only run with Postgres, so they are not silently
+// ignored by other storages.
+func (app *AppType) validatePostgresOnly() []error {
+	var errs []error
+
+	for _, setting := range []struct {
+		path string
+		set  bool
+	}{
+		{"namespaces.require_registered", app.Namespaces.RequireRegistered},
+		{"revocation.restore_window", app.Revocation.RestoreWindow != 0},
+		{"idempotency.ttl", app.Idempotency.TTL != 0},
+		{"idempotency.fingerprint_key", app.Idempotency.FingerprintKey != ""},
+		{"purge.interval", app.Purge.Interval != 0},
+		{"purge.retention", app.Purge.Retention != 0},
+		{"purge.batch_size", app.Purge.BatchSize != 0},
+	} {
+		if setting.set {
+			errs = append(errs, fmt.Errorf("%s: %w", setting.path, ErrUnsupportedStorage))
+		}
+	}
+
+	return errs
+}
+
+// validatePorts checks the optional servers do not listen on the port of another one.
+func (app *AppType) validatePorts() []error {
+	var errs []error
+
+	used := map[int]bool{app.Server.Port: true}
+
+	for _, port := range []struct {
+		path  string
+		value int
+	}{
+		{"server.gateway.port", app.Server.Gateway.Port},
+		{"metrics.port", app.Metrics.Port},
+	} {
+		if port.value == 0 {
+			continue
+		}
+
+		if used[port.value] {
+			errs = append(errs, fmt.Errorf("%s: %w", port.path, ErrConflictingPorts))
+		}
+
+		used[port.value] = true
+	}
+
+	return errs
+}
+
+func (app *AppType) validateAuth() []error {
+	var errs []error
+
+	tls := app.Server.TLS
+	tlsEnabled := tls.CertFile != ""
+
+	for _, file := range []string{tls.KeyFile, tls.ClientCAFile, tls.PermissionsFile} {
+		if (file != "") != tlsEnabled {
+			errs = append(errs, fmt.Errorf("server.tls: %w", ErrIncompleteTLS))
+
+			break
+		}
+	}
+
+	jwt := app.Server.JWT
+	if jwt.JWKSFile != "" && jwt.JWKSURL != "" {
+		errs = append(errs, fmt.Errorf("server.jwt: %w", ErrConflictingJWKS))
+	}
+
+	if tlsEnabled && (jwt.JWKSFile != "" || jwt.JWKSURL != "") {
+		errs = append(errs, fmt.Errorf("server: %w", ErrConflictingAuth))
+	}
+
+	return errs
+}
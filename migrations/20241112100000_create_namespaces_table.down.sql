@@ -0,0 +1,16 @@
+DROP VIEW IF EXISTS active_passkeys;
+
+--bun:split
+
+ALTER TABLE passkeys DROP COLUMN IF EXISTS redeemed_at;
+ALTER TABLE passkeys DROP COLUMN IF EXISTS single_use;
+
+--bun:split
+
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE passkeys.expires_at IS NULL OR passkeys.expires_at >= now();
+
+--bun:split
+
+DROP TABLE IF EXISTS namespaces;
@@ -0,0 +1,31 @@
+CREATE TABLE namespaces (
+    name TEXT PRIMARY KEY,
+
+    -- Durations are stored in nanoseconds.
+    default_ttl BIGINT,
+    max_ttl BIGINT,
+
+    hash_algorithm TEXT NOT NULL DEFAULT 'argon2id',
+    hash_params jsonb,
+    strength_rules jsonb,
+
+    single_use_default BOOLEAN NOT NULL DEFAULT FALSE,
+    max_active_passkeys INTEGER,
+
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ
+);
+
+--bun:split
+
+ALTER TABLE passkeys ADD COLUMN single_use BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE passkeys ADD COLUMN redeemed_at TIMESTAMPTZ;
+
+--bun:split
+
+-- Views expand their columns on creation, so they must be recreated to pick up new ones.
+DROP VIEW IF EXISTS active_passkeys;
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL;
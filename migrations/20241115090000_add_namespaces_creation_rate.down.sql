@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS passkeys_namespace_created_at_idx;
+
+--bun:split
+
+ALTER TABLE namespaces DROP COLUMN IF EXISTS creation_rate_window;
+ALTER TABLE namespaces DROP COLUMN IF EXISTS creation_rate_limit;
@@ -0,0 +1,8 @@
+ALTER TABLE namespaces ADD COLUMN creation_rate_limit INTEGER;
+-- Nanoseconds.
+ALTER TABLE namespaces ADD COLUMN creation_rate_window BIGINT;
+
+--bun:split
+
+-- Speeds up the creation rate quota, which counts the latest passkeys of a namespace.
+CREATE INDEX passkeys_namespace_created_at_idx ON passkeys (namespace, created_at);
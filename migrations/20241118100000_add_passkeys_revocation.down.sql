@@ -0,0 +1,14 @@
+DROP VIEW IF EXISTS active_passkeys;
+
+--bun:split
+
+ALTER TABLE passkeys DROP COLUMN IF EXISTS revocation_reason;
+ALTER TABLE passkeys DROP COLUMN IF EXISTS revoked_by;
+ALTER TABLE passkeys DROP COLUMN IF EXISTS revoked_at;
+
+--bun:split
+
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL;
@@ -0,0 +1,12 @@
+ALTER TABLE passkeys ADD COLUMN revoked_at TIMESTAMPTZ;
+ALTER TABLE passkeys ADD COLUMN revoked_by TEXT;
+ALTER TABLE passkeys ADD COLUMN revocation_reason TEXT;
+
+--bun:split
+
+DROP VIEW IF EXISTS active_passkeys;
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL
+  AND passkeys.revoked_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS passkey_history;
+
+--bun:split
+
+ALTER TABLE namespaces DROP COLUMN IF EXISTS secret_history_size;
@@ -0,0 +1,17 @@
+ALTER TABLE namespaces ADD COLUMN secret_history_size INTEGER NOT NULL DEFAULT 0;
+
+--bun:split
+
+-- Previous secrets of a passkey, used to prevent their reuse on update.
+CREATE TABLE passkey_history (
+    id BIGSERIAL PRIMARY KEY,
+
+    passkey_id UUID NOT NULL REFERENCES passkeys (id) ON DELETE CASCADE,
+    encrypted_key TEXT NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX passkey_history_passkey_id_idx ON passkey_history (passkey_id, created_at DESC);
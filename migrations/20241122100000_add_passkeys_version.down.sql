@@ -0,0 +1,13 @@
+DROP VIEW IF EXISTS active_passkeys;
+
+--bun:split
+
+ALTER TABLE passkeys DROP COLUMN IF EXISTS version;
+
+--bun:split
+
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL
+  AND passkeys.revoked_at IS NULL;
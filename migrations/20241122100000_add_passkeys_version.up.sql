@@ -0,0 +1,10 @@
+ALTER TABLE passkeys ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+--bun:split
+
+DROP VIEW IF EXISTS active_passkeys;
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL
+  AND passkeys.revoked_at IS NULL;
@@ -0,0 +1,20 @@
+-- Results of the requests sent with an idempotency key, so retries can be answered without running them again.
+CREATE TABLE idempotency_keys (
+    key TEXT NOT NULL,
+    -- Full name of the gRPC method, so the same key can be used on different services.
+    service TEXT NOT NULL,
+
+    -- Hash of the original request, used to reject a different payload sent under the same key.
+    fingerprint TEXT NOT NULL,
+    -- Serialized response of the original request. NULL while the request is still running.
+    response BYTEA,
+
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (service, key)
+);
+
+--bun:split
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,88 @@
+DROP POLICY IF EXISTS idempotency_keys_tenant_isolation ON idempotency_keys;
+ALTER TABLE idempotency_keys DISABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+DROP POLICY IF EXISTS namespaces_tenant_isolation ON namespaces;
+ALTER TABLE namespaces DISABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+DROP POLICY IF EXISTS passkey_history_tenant_isolation ON passkey_history;
+ALTER TABLE passkey_history DISABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+DROP POLICY IF EXISTS passkeys_tenant_isolation ON passkeys;
+ALTER TABLE passkeys DISABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+-- Revokes every privilege of the role in this database, including the default ones.
+DROP OWNED BY passkeys_tenant;
+
+--bun:split
+
+DROP ROLE IF EXISTS passkeys_tenant;
+
+--bun:split
+
+DROP VIEW IF EXISTS active_passkeys;
+
+--bun:split
+
+ALTER TABLE passkey_history DROP CONSTRAINT passkey_history_passkey_id_fkey;
+
+--bun:split
+
+ALTER TABLE passkeys DROP CONSTRAINT passkeys_pkey;
+
+--bun:split
+
+-- Fails if different tenants used the same passkey ID.
+ALTER TABLE passkeys ADD PRIMARY KEY (id);
+
+--bun:split
+
+ALTER TABLE passkey_history ADD CONSTRAINT passkey_history_passkey_id_fkey
+    FOREIGN KEY (passkey_id) REFERENCES passkeys (id) ON DELETE CASCADE;
+
+--bun:split
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+
+--bun:split
+
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (service, key);
+
+--bun:split
+
+ALTER TABLE namespaces DROP CONSTRAINT namespaces_pkey;
+
+--bun:split
+
+ALTER TABLE namespaces ADD PRIMARY KEY (name);
+
+--bun:split
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS tenant;
+
+--bun:split
+
+ALTER TABLE namespaces DROP COLUMN IF EXISTS tenant;
+
+--bun:split
+
+ALTER TABLE passkey_history DROP COLUMN IF EXISTS tenant;
+
+--bun:split
+
+ALTER TABLE passkeys DROP COLUMN IF EXISTS tenant;
+
+--bun:split
+
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL
+  AND passkeys.revoked_at IS NULL;
@@ -0,0 +1,127 @@
+-- Every row belongs to a tenant. It defaults to the tenant of the current transaction, so queries never have to set
+-- it explicitly. Rows created before tenants existed belong to the default, empty tenant.
+ALTER TABLE passkeys ADD COLUMN tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+--bun:split
+
+ALTER TABLE passkey_history ADD COLUMN tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+--bun:split
+
+ALTER TABLE namespaces ADD COLUMN tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+--bun:split
+
+ALTER TABLE idempotency_keys ADD COLUMN tenant TEXT NOT NULL DEFAULT COALESCE(current_setting('app.tenant', true), '');
+
+--bun:split
+
+-- Different tenants can use the same namespace names, idempotency keys and passkey IDs.
+ALTER TABLE namespaces DROP CONSTRAINT namespaces_pkey;
+
+--bun:split
+
+ALTER TABLE namespaces ADD PRIMARY KEY (tenant, name);
+
+--bun:split
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+
+--bun:split
+
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant, service, key);
+
+--bun:split
+
+-- Passkey IDs only need to be unique within a tenant. The history references its passkey in the same tenant.
+ALTER TABLE passkey_history DROP CONSTRAINT passkey_history_passkey_id_fkey;
+
+--bun:split
+
+ALTER TABLE passkeys DROP CONSTRAINT passkeys_pkey;
+
+--bun:split
+
+ALTER TABLE passkeys ADD PRIMARY KEY (tenant, id);
+
+--bun:split
+
+ALTER TABLE passkey_history ADD CONSTRAINT passkey_history_passkey_id_fkey
+    FOREIGN KEY (tenant, passkey_id) REFERENCES passkeys (tenant, id) ON DELETE CASCADE;
+
+--bun:split
+
+-- The view runs with the permissions of the caller, otherwise it would bypass the policies below. Keep this option
+-- when recreating it.
+DROP VIEW IF EXISTS active_passkeys;
+CREATE VIEW active_passkeys WITH (security_invoker = true) AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL
+  AND passkeys.revoked_at IS NULL;
+
+--bun:split
+
+-- Policies only restrict this role: superusers and table owners bypass them. The service switches to it at the start
+-- of each transaction.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'passkeys_tenant') THEN
+        CREATE ROLE passkeys_tenant NOLOGIN;
+    END IF;
+END
+$$;
+
+--bun:split
+
+GRANT passkeys_tenant TO CURRENT_USER;
+
+--bun:split
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON passkeys, passkey_history, namespaces, idempotency_keys TO passkeys_tenant;
+
+--bun:split
+
+GRANT SELECT ON active_passkeys TO passkeys_tenant;
+
+--bun:split
+
+GRANT USAGE ON SEQUENCE passkey_history_id_seq TO passkeys_tenant;
+
+--bun:split
+
+-- Tables created by later migrations are available to the role as well.
+ALTER DEFAULT PRIVILEGES GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO passkeys_tenant;
+
+--bun:split
+
+ALTER DEFAULT PRIVILEGES GRANT USAGE ON SEQUENCES TO passkeys_tenant;
+
+--bun:split
+
+-- An unset tenant matches no row.
+ALTER TABLE passkeys ENABLE ROW LEVEL SECURITY;
+CREATE POLICY passkeys_tenant_isolation ON passkeys
+    USING (tenant = current_setting('app.tenant', true))
+    WITH CHECK (tenant = current_setting('app.tenant', true));
+
+--bun:split
+
+ALTER TABLE passkey_history ENABLE ROW LEVEL SECURITY;
+CREATE POLICY passkey_history_tenant_isolation ON passkey_history
+    USING (tenant = current_setting('app.tenant', true))
+    WITH CHECK (tenant = current_setting('app.tenant', true));
+
+--bun:split
+
+ALTER TABLE namespaces ENABLE ROW LEVEL SECURITY;
+CREATE POLICY namespaces_tenant_isolation ON namespaces
+    USING (tenant = current_setting('app.tenant', true))
+    WITH CHECK (tenant = current_setting('app.tenant', true));
+
+--bun:split
+
+ALTER TABLE idempotency_keys ENABLE ROW LEVEL SECURITY;
+CREATE POLICY idempotency_keys_tenant_isolation ON idempotency_keys
+    USING (tenant = current_setting('app.tenant', true))
+    WITH CHECK (tenant = current_setting('app.tenant', true));
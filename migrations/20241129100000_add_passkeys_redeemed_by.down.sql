@@ -0,0 +1,17 @@
+DROP VIEW IF EXISTS active_passkeys;
+
+--bun:split
+
+ALTER TABLE passkeys DROP COLUMN IF EXISTS redeemed_by;
+
+--bun:split
+
+CREATE VIEW active_passkeys WITH (security_invoker = true) AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR passkeys.expires_at >= now())
+  AND passkeys.redeemed_at IS NULL
+  AND passkeys.revoked_at IS NULL;
+
+--bun:split
+
+GRANT SELECT ON active_passkeys TO passkeys_tenant;
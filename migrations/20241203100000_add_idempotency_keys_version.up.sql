@@ -0,0 +1,2 @@
+-- Version header sent with the original response, so replays carry it too. NULL when the method does not send one.
+ALTER TABLE idempotency_keys ADD COLUMN version BIGINT;
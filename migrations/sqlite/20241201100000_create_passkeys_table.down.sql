@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS passkey_history;
+
+--bun:split
+
+DROP VIEW IF EXISTS active_passkeys;
+
+--bun:split
+
+DROP TABLE IF EXISTS passkeys;
@@ -0,0 +1,62 @@
+-- Port of the Postgres passkeys table. UUIDs are generated by the service, and times are stored as UTC text, which
+-- the date functions of SQLite understand. Tenants are set by the DAOs, as SQLite has no row-level security. Like in
+-- Postgres, IDs are unique within a tenant.
+--
+-- SQLite has no DEFAULT keyword in inserts, so empty values are written as NULL. ON CONFLICT REPLACE turns those into
+-- the default value, like Postgres does.
+CREATE TABLE passkeys (
+    id TEXT NOT NULL,
+
+    namespace TEXT NOT NULL,
+    tenant TEXT NOT NULL ON CONFLICT REPLACE DEFAULT '',
+    encrypted_key TEXT NOT NULL,
+    reward TEXT,
+
+    single_use BOOLEAN NOT NULL DEFAULT FALSE,
+    redeemed_at TIMESTAMP,
+    redeemed_by TEXT,
+
+    revoked_at TIMESTAMP,
+    revoked_by TEXT,
+    revocation_reason TEXT,
+
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP,
+
+    version INTEGER NOT NULL ON CONFLICT REPLACE DEFAULT 1,
+
+    PRIMARY KEY (tenant, id)
+);
+
+--bun:split
+
+CREATE INDEX passkeys_namespace_created_at_idx ON passkeys (tenant, namespace, created_at);
+
+--bun:split
+
+-- The DAOs apply the same conditions with the time of the service, so it can be frozen in tests.
+CREATE VIEW active_passkeys AS
+SELECT * FROM passkeys
+WHERE (passkeys.expires_at IS NULL OR julianday(passkeys.expires_at) >= julianday('now'))
+  AND passkeys.redeemed_at IS NULL
+  AND passkeys.revoked_at IS NULL;
+
+--bun:split
+
+-- Previous secrets of a passkey, used to prevent their reuse on update.
+CREATE TABLE passkey_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+
+    passkey_id TEXT NOT NULL,
+    tenant TEXT NOT NULL ON CONFLICT REPLACE DEFAULT '',
+    encrypted_key TEXT NOT NULL,
+
+    created_at TIMESTAMP NOT NULL,
+
+    FOREIGN KEY (tenant, passkey_id) REFERENCES passkeys (tenant, id) ON DELETE CASCADE
+);
+
+--bun:split
+
+CREATE INDEX passkey_history_passkey_id_idx ON passkey_history (tenant, passkey_id, created_at DESC);
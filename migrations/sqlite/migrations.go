@@ -0,0 +1,10 @@
+// Package sqlite holds the migrations of the SQLite storage. They create the tables of the passkeys services only, at
+// their latest schema: the other services need Postgres.
+package sqlite
+
+import (
+	"embed"
+)
+
+//go:embed *.sql
+var SQLMigrations embed.FS
@@ -0,0 +1,185 @@
+// Package client calls the passkeys service over gRPC. It sends the secrets and options of each request as the
+// metadata the service expects, retries the calls that are safe to retry, and converts the status codes of the service
+// back to sentinel errors.
+//
+// The package does not depend on the storage of the service, so it can be imported by any consumer.
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+)
+
+// Metadata read by the service, see the Extract functions of the handlers package.
+const (
+	passkeyHeader         = "password"
+	currentPasskeyHeader  = "current-password"
+	passkeyIDHeader       = "passkey-id"
+	updateMaskHeader      = "update-mask"
+	expectedVersionHeader = "expected-version"
+	idempotencyKeyHeader  = "idempotency-key"
+	tenantHeader          = "tenant"
+	versionHeader         = "version"
+)
+
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 2 * time.Second
+)
+
+type Config struct {
+	// MaxAttempts is the number of times a call that is safe to retry is sent, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It doubles on each retry, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Tenant is sent with every request, when set. The service only trusts it when it resolves tenants from the
+	// request metadata.
+	Tenant string
+}
+
+// Passkey is returned by every method of the Client. Its secret never leaves the service.
+type Passkey struct {
+	ID        string
+	Namespace string
+	Reward    map[string]any
+	// SingleUse is only reported by List.
+	SingleUse bool
+
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+
+	// Version of the passkey, to send as the ExpectedVersion of an update or deletion. It is 0 if the service did not
+	// return one.
+	Version int64
+}
+
+// Client of the passkeys service.
+//
+// Get and List are always retried when the service is unavailable. Create and Validate are only retried when the
+// request carries an idempotency key, as the service then replays the first response instead of creating or redeeming
+// the passkey again. Update and Delete are never retried.
+type Client interface {
+	Create(ctx context.Context, request *CreateRequest) (*Passkey, error)
+	// Get returns the passkey without checking its secret, so it never redeems single-use passkeys.
+	Get(ctx context.Context, request *GetRequest) (*Passkey, error)
+	// Validate returns the passkey if the secret matches, and redeems it if it is single-use.
+	Validate(ctx context.Context, request *ValidateRequest) (*Passkey, error)
+	Update(ctx context.Context, request *UpdateRequest) (*Passkey, error)
+	Delete(ctx context.Context, request *DeleteRequest) (*Passkey, error)
+	// List returns the active passkeys, sorted by namespace, then ID. It requires the service to run with Postgres.
+	List(ctx context.Context, request *ListRequest) ([]*Passkey, error)
+}
+
+type clientImpl struct {
+	create passkeysv1grpc.CreateServiceClient
+	get    passkeysv1grpc.GetServiceClient
+	update passkeysv1grpc.UpdateServiceClient
+	delete passkeysv1grpc.DeleteServiceClient
+	list   listingsv1.ListServiceClient
+
+	config Config
+}
+
+// outgoing attaches the metadata of a request to ctx, skipping the empty values.
+func (client *clientImpl) outgoing(ctx context.Context, pairs ...string) context.Context {
+	pairs = append(pairs, tenantHeader, client.config.Tenant)
+
+	var filtered []string
+
+	for i := 0; i < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			filtered = append(filtered, pairs[i], pairs[i+1])
+		}
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, filtered...)
+}
+
+// call sends the request once, or up to MaxAttempts times if retry is true and the service is unavailable. It returns
+// the version sent by the service in the response headers.
+func (client *clientImpl) call(
+	ctx context.Context, retry bool, exec func(ctx context.Context, opts ...googlegrpc.CallOption) error,
+) (int64, error) {
+	attempts := lo.Ternary(retry, client.config.MaxAttempts, 1)
+	backoff := client.config.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		var header metadata.MD
+
+		err := exec(ctx, googlegrpc.Header(&header))
+		if err == nil {
+			return parseVersion(header), nil
+		}
+
+		if attempt >= attempts || status.Code(err) != codes.Unavailable {
+			return 0, err
+		}
+
+		if !sleep(ctx, backoff) {
+			return 0, fmt.Errorf("wait before retry: %w", ctx.Err())
+		}
+
+		backoff = min(2*backoff, client.config.MaxBackoff)
+	}
+}
+
+// sleep waits for a random delay up to backoff, so clients that failed together do not retry together. It returns
+// false if ctx is done first.
+func sleep(ctx context.Context, backoff time.Duration) bool {
+	timer := time.NewTimer(rand.N(backoff) + 1) //nolint:gosec
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func parseVersion(header metadata.MD) int64 {
+	values := header.Get(versionHeader)
+	if len(values) == 0 {
+		return 0
+	}
+
+	version, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+// NewClient returns a client that sends its requests through conn. The connection is owned by the caller, who must
+// close it.
+func NewClient(conn googlegrpc.ClientConnInterface, config Config) Client {
+	config.MaxAttempts = lo.CoalesceOrEmpty(config.MaxAttempts, DefaultMaxAttempts)
+	config.InitialBackoff = lo.CoalesceOrEmpty(config.InitialBackoff, DefaultInitialBackoff)
+	config.MaxBackoff = lo.CoalesceOrEmpty(config.MaxBackoff, DefaultMaxBackoff)
+
+	return &clientImpl{
+		create: passkeysv1grpc.NewCreateServiceClient(conn),
+		get:    passkeysv1grpc.NewGetServiceClient(conn),
+		update: passkeysv1grpc.NewUpdateServiceClient(conn),
+		delete: passkeysv1grpc.NewDeleteServiceClient(conn),
+		list:   listingsv1.NewListServiceClient(conn),
+		config: config,
+	}
+}
@@ -0,0 +1,843 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+
+	"github.com/a-novel/uservice-passkeys/pkg/client"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	handlersmocks "github.com/a-novel/uservice-passkeys/pkg/handlers/mocks"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+)
+
+// clientMetadata lists the metadata the client may send, so the ones set by gRPC itself are ignored.
+var clientMetadata = []string{
+	"password", "current-password", "passkey-id", "update-mask", "expected-version", "idempotency-key", "tenant",
+}
+
+type testServer struct {
+	create *handlersmocks.MockCreatePasskey
+	get    *handlersmocks.MockGetPasskey
+	update *handlersmocks.MockUpdatePasskey
+	delete *handlersmocks.MockDeletePasskey
+	list   *handlersmocks.MockListPasskeys
+}
+
+func newTestClient(t *testing.T, tenant string) (client.Client, *testServer) {
+	t.Helper()
+
+	mocks := &testServer{
+		create: handlersmocks.NewMockCreatePasskey(t),
+		get:    handlersmocks.NewMockGetPasskey(t),
+		update: handlersmocks.NewMockUpdatePasskey(t),
+		delete: handlersmocks.NewMockDeletePasskey(t),
+		list:   handlersmocks.NewMockListPasskeys(t),
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := googlegrpc.NewServer()
+	passkeysv1grpc.RegisterCreateServiceServer(server, mocks.create)
+	passkeysv1grpc.RegisterGetServiceServer(server, mocks.get)
+	passkeysv1grpc.RegisterUpdateServiceServer(server, mocks.update)
+	passkeysv1grpc.RegisterDeleteServiceServer(server, mocks.delete)
+	listingsv1.RegisterListServiceServer(server, mocks.list)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := googlegrpc.NewClient(
+		"passthrough:///bufnet",
+		googlegrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		googlegrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return client.NewClient(conn, client.Config{InitialBackoff: time.Millisecond, Tenant: tenant}), mocks
+}
+
+// serve returns a handler that fails with errs, one per call, then sends the version and returns res.
+func serve[Req any, Res any](
+	errs []error, version int64, res Res, received *map[string]string,
+) func(ctx context.Context, _ Req) (Res, error) {
+	var calls int
+
+	return func(ctx context.Context, _ Req) (Res, error) {
+		incoming, _ := metadata.FromIncomingContext(ctx)
+
+		*received = make(map[string]string)
+
+		for _, key := range clientMetadata {
+			if values := incoming.Get(key); len(values) > 0 {
+				(*received)[key] = values[0]
+			}
+		}
+
+		calls++
+		if calls <= len(errs) {
+			var zero Res
+
+			return zero, errs[calls-1]
+		}
+
+		handlers.SendVersion(ctx, version)
+
+		return res, nil
+	}
+}
+
+// invalidPasskeyError is the status sent by the handlers for a wrong passkey.
+func invalidPasskeyError(t *testing.T) error {
+	t.Helper()
+
+	output, err := status.New(codes.PermissionDenied, "invalid passkey").WithDetails(&errdetails.ErrorInfo{
+		Reason: handlers.ReasonInvalidPasskey,
+		Domain: handlers.ErrorDomain,
+	})
+	require.NoError(t, err)
+
+	return output.Err()
+}
+
+func TestCreate(t *testing.T) {
+	reward, err := structpb.NewStruct(map[string]interface{}{"type": "reward"})
+	require.NoError(t, err)
+
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	testCases := []struct {
+		name string
+
+		tenant  string
+		request *client.CreateRequest
+
+		serverErrs []error
+
+		expectCalls    int
+		expectRequest  *passkeysv1.CreateServiceExecRequest
+		expectMetadata map[string]string
+		expect         *client.Passkey
+		expectErr      error
+	}{
+		{
+			name: "OK",
+
+			tenant: "tenant",
+			request: &client.CreateRequest{
+				ID:        "id",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+				Reward:    map[string]any{"type": "reward"},
+				ExpiresIn: lo.ToPtr(time.Hour),
+			},
+
+			expectCalls: 1,
+			expectRequest: &passkeysv1.CreateServiceExecRequest{
+				Namespace: "namespace",
+				Reward:    reward,
+				ExpiresIn: durationpb.New(time.Hour),
+			},
+			expectMetadata: map[string]string{"passkey-id": "id", "password": "passkey", "tenant": "tenant"},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				Reward:    map[string]any{"type": "reward"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:   1,
+			},
+		},
+		{
+			name: "Retry/IdempotencyKey",
+
+			request: &client.CreateRequest{
+				Namespace:      "namespace",
+				Passkey:        "passkey",
+				IdempotencyKey: "key",
+			},
+
+			serverErrs: []error{unavailable, unavailable},
+
+			expectCalls:    3,
+			expectRequest:  &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"},
+			expectMetadata: map[string]string{"password": "passkey", "idempotency-key": "key"},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				Reward:    map[string]any{"type": "reward"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:   1,
+			},
+		},
+		{
+			name: "Retry/MaxAttempts",
+
+			request: &client.CreateRequest{
+				Namespace:      "namespace",
+				Passkey:        "passkey",
+				IdempotencyKey: "key",
+			},
+
+			serverErrs: []error{unavailable, unavailable, unavailable},
+
+			expectCalls:    3,
+			expectRequest:  &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"},
+			expectMetadata: map[string]string{"password": "passkey", "idempotency-key": "key"},
+			expectErr:      client.ErrUnavailable,
+		},
+		{
+			name: "NoRetry/NoIdempotencyKey",
+
+			request: &client.CreateRequest{Namespace: "namespace", Passkey: "passkey"},
+
+			serverErrs: []error{unavailable},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrUnavailable,
+		},
+		{
+			name: "NamespaceNotRegistered",
+
+			request: &client.CreateRequest{Namespace: "namespace", Passkey: "passkey"},
+
+			serverErrs: []error{status.Error(codes.FailedPrecondition, "namespace not registered")},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrNamespaceNotRegistered,
+		},
+		{
+			name: "QuotaExceeded",
+
+			request: &client.CreateRequest{Namespace: "namespace", Passkey: "passkey"},
+
+			serverErrs: []error{status.Error(codes.ResourceExhausted, "quota exceeded")},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrQuotaExceeded,
+		},
+		{
+			name: "AlreadyExists",
+
+			request: &client.CreateRequest{ID: "id", Namespace: "namespace", Passkey: "passkey"},
+
+			serverErrs: []error{status.Error(codes.AlreadyExists, "passkey already exists")},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"},
+			expectMetadata: map[string]string{"passkey-id": "id", "password": "passkey"},
+			expectErr:      client.ErrPasskeyAlreadyExists,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			passkeysClient, server := newTestClient(t, testCase.tenant)
+
+			var received map[string]string
+
+			server.create.
+				On("Exec", mock.Anything, mock.MatchedBy(func(request *passkeysv1.CreateServiceExecRequest) bool {
+					return request.GetNamespace() == testCase.expectRequest.GetNamespace() &&
+						request.GetReward().String() == testCase.expectRequest.GetReward().String() &&
+						request.GetExpiresIn().AsDuration() == testCase.expectRequest.GetExpiresIn().AsDuration()
+				})).
+				Return(serve[*passkeysv1.CreateServiceExecRequest](
+					testCase.serverErrs, 1, &passkeysv1.CreateServiceExecResponse{
+						Id:        "id",
+						Namespace: "namespace",
+						Reward:    reward,
+						ExpiresAt: timestamppb.New(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+						CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					}, &received,
+				)).
+				Times(testCase.expectCalls)
+
+			res, err := passkeysClient.Create(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, res)
+			require.Equal(t, testCase.expectMetadata, received)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	testCases := []struct {
+		name string
+
+		call func(passkeysClient client.Client) (*client.Passkey, error)
+
+		serverErrs []error
+
+		expectCalls    int
+		expectRequest  *passkeysv1.GetServiceExecRequest
+		expectMetadata map[string]string
+		expect         *client.Passkey
+		expectErr      error
+	}{
+		{
+			name: "Get",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Get(context.Background(), &client.GetRequest{ID: "id", Namespace: "namespace"})
+			},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace"},
+			expectMetadata: map[string]string{},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Get/Retry",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Get(context.Background(), &client.GetRequest{ID: "id", Namespace: "namespace"})
+			},
+
+			serverErrs: []error{unavailable},
+
+			expectCalls:    2,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace"},
+			expectMetadata: map[string]string{},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Get/NotFound",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Get(context.Background(), &client.GetRequest{ID: "id", Namespace: "namespace"})
+			},
+
+			serverErrs: []error{status.Error(codes.NotFound, "passkey not found")},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace"},
+			expectMetadata: map[string]string{},
+			expectErr:      client.ErrPasskeyNotFound,
+		},
+		{
+			name: "Validate",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Validate(context.Background(), &client.ValidateRequest{
+					ID: "id", Namespace: "namespace", Passkey: "passkey",
+				})
+			},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace", Validate: true},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Validate/NoRetry",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Validate(context.Background(), &client.ValidateRequest{
+					ID: "id", Namespace: "namespace", Passkey: "passkey",
+				})
+			},
+
+			serverErrs: []error{unavailable},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace", Validate: true},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrUnavailable,
+		},
+		{
+			name: "Validate/Retry/IdempotencyKey",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Validate(context.Background(), &client.ValidateRequest{
+					ID: "id", Namespace: "namespace", Passkey: "passkey", IdempotencyKey: "key",
+				})
+			},
+
+			serverErrs: []error{unavailable},
+
+			expectCalls:    2,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace", Validate: true},
+			expectMetadata: map[string]string{"password": "passkey", "idempotency-key": "key"},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Validate/InvalidPasskey",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Validate(context.Background(), &client.ValidateRequest{
+					ID: "id", Namespace: "namespace", Passkey: "passkey",
+				})
+			},
+
+			serverErrs: []error{invalidPasskeyError(t)},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace", Validate: true},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrInvalidPasskey,
+		},
+		{
+			name: "Validate/PermissionDenied",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Validate(context.Background(), &client.ValidateRequest{
+					ID: "id", Namespace: "namespace", Passkey: "passkey",
+				})
+			},
+
+			serverErrs: []error{status.Error(codes.PermissionDenied, "operation get is not allowed")},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace", Validate: true},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrPermissionDenied,
+		},
+		{
+			name: "Validate/Revoked",
+
+			call: func(passkeysClient client.Client) (*client.Passkey, error) {
+				return passkeysClient.Validate(context.Background(), &client.ValidateRequest{
+					ID: "id", Namespace: "namespace", Passkey: "passkey",
+				})
+			},
+
+			serverErrs: []error{status.Error(codes.FailedPrecondition, "passkey revoked")},
+
+			expectCalls:    1,
+			expectRequest:  &passkeysv1.GetServiceExecRequest{Id: "id", Namespace: "namespace", Validate: true},
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrPasskeyRevoked,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			passkeysClient, server := newTestClient(t, "")
+
+			var received map[string]string
+
+			server.get.
+				On("Exec", mock.Anything, mock.MatchedBy(func(request *passkeysv1.GetServiceExecRequest) bool {
+					return request.GetId() == testCase.expectRequest.GetId() &&
+						request.GetNamespace() == testCase.expectRequest.GetNamespace() &&
+						request.GetValidate() == testCase.expectRequest.GetValidate()
+				})).
+				Return(serve[*passkeysv1.GetServiceExecRequest](
+					testCase.serverErrs, 2, &passkeysv1.GetServiceExecResponse{
+						Id:        "id",
+						Namespace: "namespace",
+						CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+						UpdatedAt: timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+					}, &received,
+				)).
+				Times(testCase.expectCalls)
+
+			res, err := testCase.call(passkeysClient)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, res)
+			require.Equal(t, testCase.expectMetadata, received)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *client.UpdateRequest
+
+		serverErr error
+
+		expectCalls    int
+		expectMetadata map[string]string
+		expect         *client.Passkey
+		expectErr      error
+	}{
+		{
+			name: "OK",
+
+			request: &client.UpdateRequest{
+				ID:              "id",
+				Namespace:       "namespace",
+				Passkey:         "new-passkey",
+				UpdateMask:      []string{client.UpdateMaskPasskey, client.UpdateMaskReward},
+				CurrentPasskey:  lo.ToPtr("passkey"),
+				ExpectedVersion: lo.ToPtr[int64](2),
+			},
+
+			expectCalls: 1,
+			expectMetadata: map[string]string{
+				"password":         "new-passkey",
+				"update-mask":      "passkey,reward",
+				"current-password": "passkey",
+				"expected-version": "2",
+			},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   3,
+			},
+		},
+		{
+			name: "NoRetry",
+
+			request: &client.UpdateRequest{ID: "id", Namespace: "namespace", Passkey: "passkey"},
+
+			serverErr: status.Error(codes.Unavailable, "unavailable"),
+
+			expectCalls:    1,
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrUnavailable,
+		},
+		{
+			name: "VersionMismatch",
+
+			request: &client.UpdateRequest{
+				ID: "id", Namespace: "namespace", Passkey: "passkey", ExpectedVersion: lo.ToPtr[int64](1),
+			},
+
+			serverErr: status.Error(codes.Aborted, "version mismatch"),
+
+			expectCalls:    1,
+			expectMetadata: map[string]string{"password": "passkey", "expected-version": "1"},
+			expectErr:      client.ErrVersionMismatch,
+		},
+		{
+			name: "InvalidPasskey",
+
+			request: &client.UpdateRequest{
+				ID: "id", Namespace: "namespace", Passkey: "passkey", CurrentPasskey: lo.ToPtr("wrong"),
+			},
+
+			serverErr: invalidPasskeyError(t),
+
+			expectCalls:    1,
+			expectMetadata: map[string]string{"password": "passkey", "current-password": "wrong"},
+			expectErr:      client.ErrInvalidPasskey,
+		},
+		{
+			name: "PermissionDenied",
+
+			request: &client.UpdateRequest{
+				ID: "id", Namespace: "namespace", Passkey: "passkey",
+			},
+
+			serverErr: status.Error(codes.PermissionDenied, "operation update is not allowed"),
+
+			expectCalls:    1,
+			expectMetadata: map[string]string{"password": "passkey"},
+			expectErr:      client.ErrPermissionDenied,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			passkeysClient, server := newTestClient(t, "")
+
+			var received map[string]string
+
+			server.update.
+				On("Exec", mock.Anything, mock.MatchedBy(func(request *passkeysv1.UpdateServiceExecRequest) bool {
+					return request.GetId() == "id" && request.GetNamespace() == "namespace"
+				})).
+				Return(serve[*passkeysv1.UpdateServiceExecRequest](
+					lo.Compact([]error{testCase.serverErr}), 3, &passkeysv1.UpdateServiceExecResponse{
+						Id:        "id",
+						Namespace: "namespace",
+						CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+						UpdatedAt: timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+					}, &received,
+				)).
+				Times(testCase.expectCalls)
+
+			res, err := passkeysClient.Update(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, res)
+			require.Equal(t, testCase.expectMetadata, received)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *client.DeleteRequest
+
+		serverErr error
+
+		expectValidate bool
+		expectMetadata map[string]string
+		expect         *client.Passkey
+		expectErr      error
+		expectCode     codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &client.DeleteRequest{ID: "id", Namespace: "namespace"},
+
+			expectMetadata: map[string]string{},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:   4,
+			},
+		},
+		{
+			name: "Validate",
+
+			request: &client.DeleteRequest{
+				ID: "id", Namespace: "namespace", Passkey: "passkey", ExpectedVersion: lo.ToPtr[int64](4),
+			},
+
+			expectValidate: true,
+			expectMetadata: map[string]string{"password": "passkey", "expected-version": "4"},
+			expect: &client.Passkey{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:   4,
+			},
+		},
+		{
+			name: "NotFound",
+
+			request: &client.DeleteRequest{ID: "id", Namespace: "namespace"},
+
+			serverErr: status.Error(codes.NotFound, "passkey not found"),
+
+			expectMetadata: map[string]string{},
+			expectErr:      client.ErrPasskeyNotFound,
+			expectCode:     codes.NotFound,
+		},
+		{
+			name: "VersionMismatch",
+
+			request: &client.DeleteRequest{ID: "id", Namespace: "namespace", ExpectedVersion: lo.ToPtr[int64](1)},
+
+			serverErr: status.Error(codes.Aborted, "version mismatch"),
+
+			expectMetadata: map[string]string{"expected-version": "1"},
+			expectErr:      client.ErrVersionMismatch,
+			expectCode:     codes.Aborted,
+		},
+		{
+			name: "Internal",
+
+			request: &client.DeleteRequest{ID: "id", Namespace: "namespace"},
+
+			serverErr: status.Error(codes.Internal, "uh oh"),
+
+			expectMetadata: map[string]string{},
+			expectCode:     codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			passkeysClient, server := newTestClient(t, "")
+
+			var received map[string]string
+
+			server.delete.
+				On("Exec", mock.Anything, mock.MatchedBy(func(request *passkeysv1.DeleteServiceExecRequest) bool {
+					return request.GetId() == "id" && request.GetNamespace() == "namespace" &&
+						request.GetValidate() == testCase.expectValidate
+				})).
+				Return(serve[*passkeysv1.DeleteServiceExecRequest](
+					lo.Compact([]error{testCase.serverErr}), 4, &passkeysv1.DeleteServiceExecResponse{
+						Id:        "id",
+						Namespace: "namespace",
+						CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					}, &received,
+				)).
+				Once()
+
+			res, err := passkeysClient.Delete(context.Background(), testCase.request)
+
+			if testCase.expectErr != nil {
+				require.ErrorIs(t, err, testCase.expectErr)
+			}
+
+			require.Equal(t, testCase.expectCode, status.Code(err))
+			require.Equal(t, testCase.expect, res)
+			require.Equal(t, testCase.expectMetadata, received)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *client.ListRequest
+
+		serverErrs []error
+
+		expectCalls int
+		expect      []*client.Passkey
+		expectErr   error
+	}{
+		{
+			name: "OK",
+
+			request: &client.ListRequest{
+				Namespace: "namespace", Limit: 10, AfterNamespace: "namespace", AfterID: "previous",
+			},
+
+			expectCalls: 1,
+			expect: []*client.Passkey{
+				{
+					ID:        "id",
+					Namespace: "namespace",
+					SingleUse: true,
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					Version:   5,
+				},
+			},
+		},
+		{
+			name: "Retry",
+
+			request: &client.ListRequest{
+				Namespace: "namespace", Limit: 10, AfterNamespace: "namespace", AfterID: "previous",
+			},
+
+			serverErrs: []error{status.Error(codes.Unavailable, "unavailable")},
+
+			expectCalls: 2,
+			expect: []*client.Passkey{
+				{
+					ID:        "id",
+					Namespace: "namespace",
+					SingleUse: true,
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					Version:   5,
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &client.ListRequest{
+				Namespace: "namespace", Limit: 10, AfterNamespace: "namespace", AfterID: "previous",
+			},
+
+			serverErrs: []error{status.Error(codes.InvalidArgument, "invalid request")},
+
+			expectCalls: 1,
+			expectErr:   client.ErrInvalidRequest,
+		},
+		{
+			name: "Unsupported",
+
+			request: &client.ListRequest{
+				Namespace: "namespace", Limit: 10, AfterNamespace: "namespace", AfterID: "previous",
+			},
+
+			serverErrs: []error{status.Error(codes.Unimplemented, "unknown service")},
+
+			expectCalls: 1,
+			expectErr:   client.ErrUnsupported,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			passkeysClient, server := newTestClient(t, "")
+
+			var received map[string]string
+
+			server.list.
+				On("Exec", mock.Anything, mock.MatchedBy(func(request *listingsv1.ListServiceExecRequest) bool {
+					return request.GetNamespace() == "namespace" && request.GetLimit() == 10 &&
+						request.GetAfterNamespace() == "namespace" && request.GetAfterId() == "previous"
+				})).
+				Return(serve[*listingsv1.ListServiceExecRequest](
+					testCase.serverErrs, 0, &listingsv1.ListServiceExecResponse{
+						Passkeys: []*listingsv1.Passkey{
+							{
+								Id:        "id",
+								Namespace: "namespace",
+								SingleUse: true,
+								CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+								Version:   5,
+							},
+						},
+					}, &received,
+				)).
+				Times(testCase.expectCalls)
+
+			res, err := passkeysClient.List(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, res)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The conversions below mirror the ones of golib/grpc, which would otherwise pull database drivers into every
+// consumer of the client.
+
+// passkeyResponse is implemented by the responses of every service.
+type passkeyResponse interface {
+	GetId() string
+	GetNamespace() string
+	GetReward() *structpb.Struct
+	GetExpiresAt() *timestamppb.Timestamp
+	GetCreatedAt() *timestamppb.Timestamp
+}
+
+func newPasskey(res passkeyResponse, version int64) *Passkey {
+	passkey := &Passkey{
+		ID:        res.GetId(),
+		Namespace: res.GetNamespace(),
+		ExpiresAt: timeOptional(res.GetExpiresAt()),
+		CreatedAt: res.GetCreatedAt().AsTime(),
+		Version:   version,
+	}
+
+	if reward := res.GetReward(); reward != nil {
+		passkey.Reward = reward.AsMap()
+	}
+
+	// Passkeys are never updated when created, so the response of the create service has no such field.
+	if updated, ok := res.(interface{ GetUpdatedAt() *timestamppb.Timestamp }); ok {
+		passkey.UpdatedAt = timeOptional(updated.GetUpdatedAt())
+	}
+
+	return passkey
+}
+
+func timeOptional(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	return lo.ToPtr(timestamp.AsTime())
+}
+
+func durationOptional(duration *time.Duration) *durationpb.Duration {
+	if duration == nil {
+		return nil
+	}
+
+	return durationpb.New(*duration)
+}
+
+func rewardOptional(reward map[string]any) (*structpb.Struct, error) {
+	if reward == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	res, err := structpb.NewStruct(reward)
+	if err != nil {
+		return nil, fmt.Errorf("%w: convert reward: %w", ErrInvalidRequest, err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	googlegrpc "google.golang.org/grpc"
+
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+)
+
+type CreateRequest struct {
+	// ID of the new passkey. The service generates one when empty.
+	ID        string
+	Namespace string
+	// Passkey is the secret of the new passkey. It must satisfy the policy of the namespace, if any.
+	Passkey   string
+	Reward    map[string]any
+	ExpiresIn *time.Duration
+
+	// IdempotencyKey lets the call be retried safely: the service returns the passkey created by the first request
+	// with the same key.
+	IdempotencyKey string
+}
+
+func (client *clientImpl) Create(ctx context.Context, request *CreateRequest) (*Passkey, error) {
+	reward, err := rewardOptional(request.Reward)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = client.outgoing(ctx,
+		passkeyIDHeader, request.ID,
+		passkeyHeader, request.Passkey,
+		idempotencyKeyHeader, request.IdempotencyKey,
+	)
+
+	var res *passkeysv1.CreateServiceExecResponse
+
+	version, err := client.call(ctx, request.IdempotencyKey != "",
+		func(ctx context.Context, opts ...googlegrpc.CallOption) (err error) {
+			res, err = client.create.Exec(ctx, &passkeysv1.CreateServiceExecRequest{
+				Namespace: request.Namespace,
+				Reward:    reward,
+				ExpiresIn: durationOptional(request.ExpiresIn),
+			}, opts...)
+
+			return err //nolint:wrapcheck
+		},
+	)
+	if err != nil {
+		return nil, handleError(err, createErrorCodes)
+	}
+
+	return newPasskey(res, version), nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+
+	googlegrpc "google.golang.org/grpc"
+
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+)
+
+type DeleteRequest struct {
+	ID        string
+	Namespace string
+	// Passkey, when set, must match the secret of the passkey for it to be deleted.
+	Passkey string
+	// ExpectedVersion, when set, must match the version of the passkey for it to be deleted.
+	ExpectedVersion *int64
+}
+
+func (client *clientImpl) Delete(ctx context.Context, request *DeleteRequest) (*Passkey, error) {
+	ctx = client.outgoing(ctx,
+		passkeyHeader, request.Passkey,
+		expectedVersionHeader, formatVersion(request.ExpectedVersion),
+	)
+
+	var res *passkeysv1.DeleteServiceExecResponse
+
+	version, err := client.call(ctx, false, func(ctx context.Context, opts ...googlegrpc.CallOption) (err error) {
+		res, err = client.delete.Exec(ctx, &passkeysv1.DeleteServiceExecRequest{
+			Id:        request.ID,
+			Namespace: request.Namespace,
+			Validate:  request.Passkey != "",
+		}, opts...)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, handleError(err, deleteErrorCodes)
+	}
+
+	return newPasskey(res, version), nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The errors of the service, as told apart by their status code. Errors returned by the Client wrap both the sentinel
+// and the original status, so status.FromError still works on them.
+var (
+	ErrInvalidRequest         = errors.New("invalid request")
+	ErrPasskeyNotFound        = errors.New("passkey not found")
+	ErrPasskeyAlreadyExists   = errors.New("passkey already exists")
+	ErrInvalidPasskey         = errors.New("invalid passkey")
+	ErrPasskeyRevoked         = errors.New("passkey revoked")
+	ErrNamespaceNotRegistered = errors.New("namespace not registered")
+	ErrQuotaExceeded          = errors.New("namespace quota exceeded")
+	ErrVersionMismatch        = errors.New("passkey was modified since the expected version")
+	ErrRequestInProgress      = errors.New("a request with the same idempotency key is in progress")
+	ErrUnauthenticated        = errors.New("unauthenticated")
+	ErrPermissionDenied       = errors.New("permission denied")
+	ErrUnavailable            = errors.New("service unavailable")
+	// ErrUnsupported is returned by methods the service does not serve, such as List without Postgres.
+	ErrUnsupported = errors.New("not supported by the service")
+)
+
+// errorCodes maps the status codes that mean the same thing for every method.
+var errorCodes = map[codes.Code]error{
+	codes.InvalidArgument:   ErrInvalidRequest,
+	codes.NotFound:          ErrPasskeyNotFound,
+	codes.AlreadyExists:     ErrPasskeyAlreadyExists,
+	codes.PermissionDenied:  ErrPermissionDenied,
+	codes.ResourceExhausted: ErrQuotaExceeded,
+	codes.Unauthenticated:   ErrUnauthenticated,
+	codes.Unavailable:       ErrUnavailable,
+	codes.Unimplemented:     ErrUnsupported,
+}
+
+// Some codes depend on the method: reads return FailedPrecondition for revoked passkeys, and writes for namespaces
+// that must be registered first. Aborted reports a version mismatch, except for the methods that accept an
+// idempotency key, where it means the first request with the key did not complete yet.
+var (
+	createErrorCodes = lo.Assign(errorCodes, map[codes.Code]error{
+		codes.FailedPrecondition: ErrNamespaceNotRegistered,
+		codes.Aborted:            ErrRequestInProgress,
+	})
+	getErrorCodes = lo.Assign(errorCodes, map[codes.Code]error{
+		codes.FailedPrecondition: ErrPasskeyRevoked,
+		codes.Aborted:            ErrRequestInProgress,
+	})
+	updateErrorCodes = lo.Assign(errorCodes, map[codes.Code]error{
+		codes.FailedPrecondition: ErrNamespaceNotRegistered,
+		codes.Aborted:            ErrVersionMismatch,
+	})
+	deleteErrorCodes = lo.Assign(errorCodes, map[codes.Code]error{
+		codes.Aborted: ErrVersionMismatch,
+	})
+)
+
+// invalidPasskeyReason is the reason of the ErrorInfo detail sent along PermissionDenied when the passkey is wrong,
+// rather than the caller not being allowed to call the method.
+const invalidPasskeyReason = "INVALID_PASSKEY"
+
+// isInvalidPasskey tells whether a PermissionDenied status reports a wrong passkey.
+func isInvalidPasskey(err error) bool {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == invalidPasskeyReason {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleError wraps err with the sentinel matching its status code, if any.
+func handleError(err error, sentinels map[codes.Code]error) error {
+	if err == nil {
+		return nil
+	}
+
+	sentinel, ok := sentinels[status.Code(err)]
+	if !ok {
+		return err
+	}
+
+	if errors.Is(sentinel, ErrPermissionDenied) && isInvalidPasskey(err) {
+		sentinel = ErrInvalidPasskey
+	}
+
+	return fmt.Errorf("%w: %w", sentinel, err)
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+
+	googlegrpc "google.golang.org/grpc"
+
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+)
+
+type GetRequest struct {
+	ID        string
+	Namespace string
+}
+
+type ValidateRequest struct {
+	ID        string
+	Namespace string
+	Passkey   string
+
+	// IdempotencyKey lets the call be retried safely: the service returns the response of the first request with the
+	// same key, rather than rejecting a single-use passkey it already redeemed.
+	IdempotencyKey string
+}
+
+func (client *clientImpl) Get(ctx context.Context, request *GetRequest) (*Passkey, error) {
+	return client.getPasskey(client.outgoing(ctx), true, &passkeysv1.GetServiceExecRequest{
+		Id:        request.ID,
+		Namespace: request.Namespace,
+	})
+}
+
+func (client *clientImpl) Validate(ctx context.Context, request *ValidateRequest) (*Passkey, error) {
+	ctx = client.outgoing(ctx,
+		passkeyHeader, request.Passkey,
+		idempotencyKeyHeader, request.IdempotencyKey,
+	)
+
+	return client.getPasskey(ctx, request.IdempotencyKey != "", &passkeysv1.GetServiceExecRequest{
+		Id:        request.ID,
+		Namespace: request.Namespace,
+		Validate:  true,
+	})
+}
+
+func (client *clientImpl) getPasskey(
+	ctx context.Context, retry bool, request *passkeysv1.GetServiceExecRequest,
+) (*Passkey, error) {
+	var res *passkeysv1.GetServiceExecResponse
+
+	version, err := client.call(ctx, retry, func(ctx context.Context, opts ...googlegrpc.CallOption) (err error) {
+		res, err = client.get.Exec(ctx, request, opts...)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, handleError(err, getErrorCodes)
+	}
+
+	return newPasskey(res, version), nil
+}
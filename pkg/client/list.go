@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	googlegrpc "google.golang.org/grpc"
+
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+)
+
+type ListRequest struct {
+	// Namespace restricts the results to a single namespace. Every namespace is listed when empty.
+	Namespace string
+	Limit     int
+	// AfterNamespace and AfterID resume the listing after the given passkey, usually the last one of the previous
+	// page. Both must be set together.
+	AfterNamespace string
+	AfterID        string
+}
+
+func (client *clientImpl) List(ctx context.Context, request *ListRequest) ([]*Passkey, error) {
+	var res *listingsv1.ListServiceExecResponse
+
+	ctx = client.outgoing(ctx)
+
+	_, err := client.call(ctx, true, func(ctx context.Context, opts ...googlegrpc.CallOption) (err error) {
+		res, err = client.list.Exec(ctx, &listingsv1.ListServiceExecRequest{
+			Namespace:      request.Namespace,
+			Limit:          int32(request.Limit),
+			AfterNamespace: request.AfterNamespace,
+			AfterId:        request.AfterID,
+		}, opts...)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, handleError(err, errorCodes)
+	}
+
+	return lo.Map(res.GetPasskeys(), func(item *listingsv1.Passkey, _ int) *Passkey {
+		passkey := newPasskey(item, item.GetVersion())
+		passkey.SingleUse = item.GetSingleUse()
+
+		return passkey
+	}), nil
+}
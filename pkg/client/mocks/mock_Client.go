@@ -0,0 +1,392 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package clientmocks
+
+import (
+	context "context"
+
+	client "github.com/a-novel/uservice-passkeys/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockClient is an autogenerated mock type for the Client type
+type MockClient struct {
+	mock.Mock
+}
+
+type MockClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClient) EXPECT() *MockClient_Expecter {
+	return &MockClient_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *MockClient) Create(ctx context.Context, request *client.CreateRequest) (*client.Passkey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *client.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.CreateRequest) (*client.Passkey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.CreateRequest) *client.Passkey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *client.CreateRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockClient_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *client.CreateRequest
+func (_e *MockClient_Expecter) Create(ctx interface{}, request interface{}) *MockClient_Create_Call {
+	return &MockClient_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *MockClient_Create_Call) Run(run func(ctx context.Context, request *client.CreateRequest)) *MockClient_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.CreateRequest))
+	})
+	return _c
+}
+
+func (_c *MockClient_Create_Call) Return(_a0 *client.Passkey, _a1 error) *MockClient_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_Create_Call) RunAndReturn(run func(context.Context, *client.CreateRequest) (*client.Passkey, error)) *MockClient_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, request
+func (_m *MockClient) Delete(ctx context.Context, request *client.DeleteRequest) (*client.Passkey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *client.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.DeleteRequest) (*client.Passkey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.DeleteRequest) *client.Passkey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *client.DeleteRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockClient_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *client.DeleteRequest
+func (_e *MockClient_Expecter) Delete(ctx interface{}, request interface{}) *MockClient_Delete_Call {
+	return &MockClient_Delete_Call{Call: _e.mock.On("Delete", ctx, request)}
+}
+
+func (_c *MockClient_Delete_Call) Run(run func(ctx context.Context, request *client.DeleteRequest)) *MockClient_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.DeleteRequest))
+	})
+	return _c
+}
+
+func (_c *MockClient_Delete_Call) Return(_a0 *client.Passkey, _a1 error) *MockClient_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_Delete_Call) RunAndReturn(run func(context.Context, *client.DeleteRequest) (*client.Passkey, error)) *MockClient_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, request
+func (_m *MockClient) Get(ctx context.Context, request *client.GetRequest) (*client.Passkey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *client.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.GetRequest) (*client.Passkey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.GetRequest) *client.Passkey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *client.GetRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockClient_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *client.GetRequest
+func (_e *MockClient_Expecter) Get(ctx interface{}, request interface{}) *MockClient_Get_Call {
+	return &MockClient_Get_Call{Call: _e.mock.On("Get", ctx, request)}
+}
+
+func (_c *MockClient_Get_Call) Run(run func(ctx context.Context, request *client.GetRequest)) *MockClient_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.GetRequest))
+	})
+	return _c
+}
+
+func (_c *MockClient_Get_Call) Return(_a0 *client.Passkey, _a1 error) *MockClient_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_Get_Call) RunAndReturn(run func(context.Context, *client.GetRequest) (*client.Passkey, error)) *MockClient_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *MockClient) List(ctx context.Context, request *client.ListRequest) ([]*client.Passkey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*client.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.ListRequest) ([]*client.Passkey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.ListRequest) []*client.Passkey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*client.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *client.ListRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockClient_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *client.ListRequest
+func (_e *MockClient_Expecter) List(ctx interface{}, request interface{}) *MockClient_List_Call {
+	return &MockClient_List_Call{Call: _e.mock.On("List", ctx, request)}
+}
+
+func (_c *MockClient_List_Call) Run(run func(ctx context.Context, request *client.ListRequest)) *MockClient_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.ListRequest))
+	})
+	return _c
+}
+
+func (_c *MockClient_List_Call) Return(_a0 []*client.Passkey, _a1 error) *MockClient_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_List_Call) RunAndReturn(run func(context.Context, *client.ListRequest) ([]*client.Passkey, error)) *MockClient_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, request
+func (_m *MockClient) Update(ctx context.Context, request *client.UpdateRequest) (*client.Passkey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *client.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.UpdateRequest) (*client.Passkey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.UpdateRequest) *client.Passkey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *client.UpdateRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockClient_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *client.UpdateRequest
+func (_e *MockClient_Expecter) Update(ctx interface{}, request interface{}) *MockClient_Update_Call {
+	return &MockClient_Update_Call{Call: _e.mock.On("Update", ctx, request)}
+}
+
+func (_c *MockClient_Update_Call) Run(run func(ctx context.Context, request *client.UpdateRequest)) *MockClient_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.UpdateRequest))
+	})
+	return _c
+}
+
+func (_c *MockClient_Update_Call) Return(_a0 *client.Passkey, _a1 error) *MockClient_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_Update_Call) RunAndReturn(run func(context.Context, *client.UpdateRequest) (*client.Passkey, error)) *MockClient_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function with given fields: ctx, request
+func (_m *MockClient) Validate(ctx context.Context, request *client.ValidateRequest) (*client.Passkey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 *client.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.ValidateRequest) (*client.Passkey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.ValidateRequest) *client.Passkey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *client.ValidateRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockClient_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *client.ValidateRequest
+func (_e *MockClient_Expecter) Validate(ctx interface{}, request interface{}) *MockClient_Validate_Call {
+	return &MockClient_Validate_Call{Call: _e.mock.On("Validate", ctx, request)}
+}
+
+func (_c *MockClient_Validate_Call) Run(run func(ctx context.Context, request *client.ValidateRequest)) *MockClient_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.ValidateRequest))
+	})
+	return _c
+}
+
+func (_c *MockClient_Validate_Call) Return(_a0 *client.Passkey, _a1 error) *MockClient_Validate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_Validate_Call) RunAndReturn(run func(context.Context, *client.ValidateRequest) (*client.Passkey, error)) *MockClient_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClient creates a new instance of MockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClient {
+	mock := &MockClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,267 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package clientmocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MockpasskeyResponse is an autogenerated mock type for the passkeyResponse type
+type MockpasskeyResponse struct {
+	mock.Mock
+}
+
+type MockpasskeyResponse_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockpasskeyResponse) EXPECT() *MockpasskeyResponse_Expecter {
+	return &MockpasskeyResponse_Expecter{mock: &_m.Mock}
+}
+
+// GetCreatedAt provides a mock function with given fields:
+func (_m *MockpasskeyResponse) GetCreatedAt() *timestamppb.Timestamp {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCreatedAt")
+	}
+
+	var r0 *timestamppb.Timestamp
+	if rf, ok := ret.Get(0).(func() *timestamppb.Timestamp); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*timestamppb.Timestamp)
+		}
+	}
+
+	return r0
+}
+
+// MockpasskeyResponse_GetCreatedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCreatedAt'
+type MockpasskeyResponse_GetCreatedAt_Call struct {
+	*mock.Call
+}
+
+// GetCreatedAt is a helper method to define mock.On call
+func (_e *MockpasskeyResponse_Expecter) GetCreatedAt() *MockpasskeyResponse_GetCreatedAt_Call {
+	return &MockpasskeyResponse_GetCreatedAt_Call{Call: _e.mock.On("GetCreatedAt")}
+}
+
+func (_c *MockpasskeyResponse_GetCreatedAt_Call) Run(run func()) *MockpasskeyResponse_GetCreatedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetCreatedAt_Call) Return(_a0 *timestamppb.Timestamp) *MockpasskeyResponse_GetCreatedAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetCreatedAt_Call) RunAndReturn(run func() *timestamppb.Timestamp) *MockpasskeyResponse_GetCreatedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiresAt provides a mock function with given fields:
+func (_m *MockpasskeyResponse) GetExpiresAt() *timestamppb.Timestamp {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiresAt")
+	}
+
+	var r0 *timestamppb.Timestamp
+	if rf, ok := ret.Get(0).(func() *timestamppb.Timestamp); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*timestamppb.Timestamp)
+		}
+	}
+
+	return r0
+}
+
+// MockpasskeyResponse_GetExpiresAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiresAt'
+type MockpasskeyResponse_GetExpiresAt_Call struct {
+	*mock.Call
+}
+
+// GetExpiresAt is a helper method to define mock.On call
+func (_e *MockpasskeyResponse_Expecter) GetExpiresAt() *MockpasskeyResponse_GetExpiresAt_Call {
+	return &MockpasskeyResponse_GetExpiresAt_Call{Call: _e.mock.On("GetExpiresAt")}
+}
+
+func (_c *MockpasskeyResponse_GetExpiresAt_Call) Run(run func()) *MockpasskeyResponse_GetExpiresAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetExpiresAt_Call) Return(_a0 *timestamppb.Timestamp) *MockpasskeyResponse_GetExpiresAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetExpiresAt_Call) RunAndReturn(run func() *timestamppb.Timestamp) *MockpasskeyResponse_GetExpiresAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetId provides a mock function with given fields:
+func (_m *MockpasskeyResponse) GetId() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockpasskeyResponse_GetId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetId'
+type MockpasskeyResponse_GetId_Call struct {
+	*mock.Call
+}
+
+// GetId is a helper method to define mock.On call
+func (_e *MockpasskeyResponse_Expecter) GetId() *MockpasskeyResponse_GetId_Call {
+	return &MockpasskeyResponse_GetId_Call{Call: _e.mock.On("GetId")}
+}
+
+func (_c *MockpasskeyResponse_GetId_Call) Run(run func()) *MockpasskeyResponse_GetId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetId_Call) Return(_a0 string) *MockpasskeyResponse_GetId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetId_Call) RunAndReturn(run func() string) *MockpasskeyResponse_GetId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNamespace provides a mock function with given fields:
+func (_m *MockpasskeyResponse) GetNamespace() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespace")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockpasskeyResponse_GetNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNamespace'
+type MockpasskeyResponse_GetNamespace_Call struct {
+	*mock.Call
+}
+
+// GetNamespace is a helper method to define mock.On call
+func (_e *MockpasskeyResponse_Expecter) GetNamespace() *MockpasskeyResponse_GetNamespace_Call {
+	return &MockpasskeyResponse_GetNamespace_Call{Call: _e.mock.On("GetNamespace")}
+}
+
+func (_c *MockpasskeyResponse_GetNamespace_Call) Run(run func()) *MockpasskeyResponse_GetNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetNamespace_Call) Return(_a0 string) *MockpasskeyResponse_GetNamespace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetNamespace_Call) RunAndReturn(run func() string) *MockpasskeyResponse_GetNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReward provides a mock function with given fields:
+func (_m *MockpasskeyResponse) GetReward() *structpb.Struct {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReward")
+	}
+
+	var r0 *structpb.Struct
+	if rf, ok := ret.Get(0).(func() *structpb.Struct); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*structpb.Struct)
+		}
+	}
+
+	return r0
+}
+
+// MockpasskeyResponse_GetReward_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReward'
+type MockpasskeyResponse_GetReward_Call struct {
+	*mock.Call
+}
+
+// GetReward is a helper method to define mock.On call
+func (_e *MockpasskeyResponse_Expecter) GetReward() *MockpasskeyResponse_GetReward_Call {
+	return &MockpasskeyResponse_GetReward_Call{Call: _e.mock.On("GetReward")}
+}
+
+func (_c *MockpasskeyResponse_GetReward_Call) Run(run func()) *MockpasskeyResponse_GetReward_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetReward_Call) Return(_a0 *structpb.Struct) *MockpasskeyResponse_GetReward_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockpasskeyResponse_GetReward_Call) RunAndReturn(run func() *structpb.Struct) *MockpasskeyResponse_GetReward_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockpasskeyResponse creates a new instance of MockpasskeyResponse. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockpasskeyResponse(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockpasskeyResponse {
+	mock := &MockpasskeyResponse{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+)
+
+// The fields of a passkey that an update can target.
+const (
+	UpdateMaskPasskey   = "passkey"
+	UpdateMaskReward    = "reward"
+	UpdateMaskExpiresIn = "expires_in"
+)
+
+type UpdateRequest struct {
+	ID        string
+	Namespace string
+	Passkey   string
+	Reward    map[string]any
+	ExpiresIn *time.Duration
+
+	// UpdateMask lists the fields to update, among the UpdateMask constants. Every field is updated when empty, so a
+	// nil Reward or ExpiresIn clears the current value.
+	UpdateMask []string
+	// CurrentPasskey, when set, must match the current secret of the passkey for the update to apply.
+	CurrentPasskey *string
+	// ExpectedVersion, when set, must match the version of the passkey for the update to apply.
+	ExpectedVersion *int64
+}
+
+func (client *clientImpl) Update(ctx context.Context, request *UpdateRequest) (*Passkey, error) {
+	reward, err := rewardOptional(request.Reward)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = client.outgoing(ctx,
+		passkeyHeader, request.Passkey,
+		updateMaskHeader, strings.Join(request.UpdateMask, ","),
+		expectedVersionHeader, formatVersion(request.ExpectedVersion),
+	)
+
+	// The service tells an empty current secret apart from a missing one.
+	if request.CurrentPasskey != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, currentPasskeyHeader, *request.CurrentPasskey)
+	}
+
+	var res *passkeysv1.UpdateServiceExecResponse
+
+	version, err := client.call(ctx, false, func(ctx context.Context, opts ...googlegrpc.CallOption) (err error) {
+		res, err = client.update.Exec(ctx, &passkeysv1.UpdateServiceExecRequest{
+			Id:        request.ID,
+			Namespace: request.Namespace,
+			Reward:    reward,
+			ExpiresIn: durationOptional(request.ExpiresIn),
+		}, opts...)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, handleError(err, updateErrorCodes)
+	}
+
+	return newPasskey(res, version), nil
+}
+
+func formatVersion(version *int64) string {
+	if version == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*version, 10)
+}
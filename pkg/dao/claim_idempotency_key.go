@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type ClaimIdempotencyKeyRequest struct {
+	Key         string
+	Service     string
+	Fingerprint string
+	TTL         time.Duration
+}
+
+// ClaimIdempotencyKey reserves an idempotency key for a new request. If the key was already used, and has not expired
+// yet, the stored entry is returned instead: callers can tell them apart from its response, which is only set once
+// the original request completed.
+type ClaimIdempotencyKey interface {
+	Exec(ctx context.Context, now time.Time, request *ClaimIdempotencyKeyRequest) (*entities.IdempotencyKey, error)
+}
+
+type claimIdempotencyKeyImpl struct {
+	database bun.IDB
+}
+
+func (dao *claimIdempotencyKeyImpl) Exec(
+	ctx context.Context, now time.Time, request *ClaimIdempotencyKeyRequest,
+) (*entities.IdempotencyKey, error) {
+	model := &entities.IdempotencyKey{
+		Key:         request.Key,
+		Service:     request.Service,
+		Fingerprint: request.Fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(request.TTL),
+	}
+
+	var output *entities.IdempotencyKey
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		rows, err := tx.NewInsert().
+			Model(model).
+			// Expired keys are taken over by the new request.
+			On("CONFLICT (tenant, service, key) DO UPDATE").
+			Set("fingerprint = EXCLUDED.fingerprint").
+			Set("response = NULL").
+			Set("version = NULL").
+			Set("created_at = EXCLUDED.created_at").
+			Set("expires_at = EXCLUDED.expires_at").
+			Where("?TableAlias.expires_at <= EXCLUDED.created_at").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("claim key: %w", err)
+		}
+
+		affected, err := rows.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if affected > 0 {
+			output = model
+			return nil
+		}
+
+		output, err = dao.getExisting(ctx, tx, request)
+
+		return err
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return output, nil
+}
+
+func (dao *claimIdempotencyKeyImpl) getExisting(
+	ctx context.Context, tx bun.Tx, request *ClaimIdempotencyKeyRequest,
+) (*entities.IdempotencyKey, error) {
+	existing := &entities.IdempotencyKey{Key: request.Key, Service: request.Service}
+
+	err := tx.NewSelect().Model(existing).WherePK().Scan(ctx)
+	// The key was released by the original request in the meantime.
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrIdempotencyKeyPending
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get existing key: %w", err)
+	}
+
+	if existing.Fingerprint != request.Fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	if existing.Response == nil {
+		return nil, ErrIdempotencyKeyPending
+	}
+
+	return existing, nil
+}
+
+func NewClaimIdempotencyKey(database bun.IDB) ClaimIdempotencyKey {
+	return &claimIdempotencyKeyImpl{database: database}
+}
@@ -0,0 +1,179 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestClaimIdempotencyKey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.IdempotencyKey{
+			Key:         "completed",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			Response:    []byte("response"),
+			Version:     lo.ToPtr(int64(2)),
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.IdempotencyKey{
+			Key:         "pending",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.IdempotencyKey{
+			Key:         "expired",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			Response:    []byte("response"),
+			Version:     lo.ToPtr(int64(2)),
+			CreatedAt:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now     time.Time
+		request *dao.ClaimIdempotencyKeyRequest
+
+		expect    *entities.IdempotencyKey
+		expectErr error
+	}{
+		{
+			name: "Claim",
+
+			now: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			request: &dao.ClaimIdempotencyKeyRequest{
+				Key:         "new",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+				TTL:         time.Hour,
+			},
+
+			expect: &entities.IdempotencyKey{
+				Key:         "new",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+				CreatedAt:   time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+				ExpiresAt:   time.Date(2021, 1, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Claim/OtherService",
+
+			now: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			request: &dao.ClaimIdempotencyKeyRequest{
+				Key:         "completed",
+				Service:     "other-service",
+				Fingerprint: "other-fingerprint",
+				TTL:         time.Hour,
+			},
+
+			expect: &entities.IdempotencyKey{
+				Key:         "completed",
+				Service:     "other-service",
+				Fingerprint: "other-fingerprint",
+				CreatedAt:   time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+				ExpiresAt:   time.Date(2021, 1, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Claim/Expired",
+
+			now: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			request: &dao.ClaimIdempotencyKeyRequest{
+				Key:         "expired",
+				Service:     "service",
+				Fingerprint: "other-fingerprint",
+				TTL:         time.Hour,
+			},
+
+			expect: &entities.IdempotencyKey{
+				Key:         "expired",
+				Service:     "service",
+				Fingerprint: "other-fingerprint",
+				CreatedAt:   time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+				ExpiresAt:   time.Date(2021, 1, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Replay",
+
+			now: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			request: &dao.ClaimIdempotencyKeyRequest{
+				Key:         "completed",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+				TTL:         time.Hour,
+			},
+
+			expect: &entities.IdempotencyKey{
+				Key:         "completed",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+				Response:    []byte("response"),
+				Version:     lo.ToPtr(int64(2)),
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Reused",
+
+			now: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			request: &dao.ClaimIdempotencyKeyRequest{
+				Key:         "completed",
+				Service:     "service",
+				Fingerprint: "other-fingerprint",
+				TTL:         time.Hour,
+			},
+
+			expectErr: dao.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "Pending",
+
+			now: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			request: &dao.ClaimIdempotencyKeyRequest{
+				Key:         "pending",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+				TTL:         time.Hour,
+			},
+
+			expectErr: dao.ErrIdempotencyKeyPending,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			claimIdempotencyKeyDAO := dao.NewClaimIdempotencyKey(transaction)
+
+			result, err := claimIdempotencyKeyDAO.Exec(context.Background(), testCase.now, testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, result)
+		})
+	}
+}
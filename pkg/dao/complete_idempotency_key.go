@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type CompleteIdempotencyKeyRequest struct {
+	Key      string
+	Service  string
+	Response []byte
+	// Version is the version header sent with the response, if any.
+	Version *int64
+}
+
+// CompleteIdempotencyKey stores the response of the request that claimed an idempotency key, so it can be replayed.
+type CompleteIdempotencyKey interface {
+	Exec(ctx context.Context, request *CompleteIdempotencyKeyRequest) error
+}
+
+type completeIdempotencyKeyImpl struct {
+	database bun.IDB
+}
+
+func (dao *completeIdempotencyKeyImpl) Exec(ctx context.Context, request *CompleteIdempotencyKeyRequest) error {
+	model := &entities.IdempotencyKey{
+		Key:      request.Key,
+		Service:  request.Service,
+		Response: request.Response,
+		Version:  request.Version,
+	}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(model).
+			Column("response", "version").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return nil
+}
+
+func NewCompleteIdempotencyKey(database bun.IDB) CompleteIdempotencyKey {
+	return &completeIdempotencyKeyImpl{database: database}
+}
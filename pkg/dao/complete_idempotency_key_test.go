@@ -0,0 +1,57 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestCompleteIdempotencyKey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.IdempotencyKey{
+			Key:         "pending",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	completeIdempotencyKeyDAO := dao.NewCompleteIdempotencyKey(transaction)
+
+	err = completeIdempotencyKeyDAO.Exec(context.Background(), &dao.CompleteIdempotencyKeyRequest{
+		Key:      "pending",
+		Service:  "service",
+		Response: []byte("response"),
+		Version:  lo.ToPtr(int64(2)),
+	})
+	require.NoError(t, err)
+
+	stored := &entities.IdempotencyKey{Key: "pending", Service: "service"}
+	require.NoError(t, transaction.NewSelect().Model(stored).WherePK().Scan(context.Background()))
+	require.Equal(t, &entities.IdempotencyKey{
+		Key:         "pending",
+		Service:     "service",
+		Fingerprint: "fingerprint",
+		Response:    []byte("response"),
+		Version:     lo.ToPtr(int64(2)),
+		CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, stored)
+}
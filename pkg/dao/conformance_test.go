@@ -0,0 +1,43 @@
+package dao_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/daotest"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestPasskeysConformance(t *testing.T) {
+	database, closer, err := anoveldb.OpenTestDB(nil)
+	require.NoError(t, err)
+	defer closer()
+
+	formatter := formatters.NewConsoleFormatter(loggers.NewSTDOut(), true)
+	require.NoError(t, anoveldb.Migrate(database, migrations.SQLMigrations, formatter))
+
+	daotest.RunPasskeys(t, func(t *testing.T, now time.Time, fixtures []*entities.Passkey) *daotest.PasskeyDAOs {
+		t.Helper()
+
+		transaction := anoveldb.BeginTestTX(database, fixtures)
+		t.Cleanup(func() { anoveldb.RollbackTestTX(transaction) })
+
+		// The frozen time is rolled back with the transaction.
+		require.NoError(t, anoveldb.FreezeTime(transaction, now))
+
+		return &daotest.PasskeyDAOs{
+			Create: dao.NewCreatePasskey(transaction),
+			Get:    dao.NewGetPasskey(transaction),
+			Update: dao.NewUpdatePasskey(transaction),
+			Delete: dao.NewDeletePasskey(transaction),
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type CreateNamespaceRequest struct {
+	Name   string
+	Policy entities.NamespacePolicy
+}
+
+type CreateNamespace interface {
+	Exec(ctx context.Context, now time.Time, request *CreateNamespaceRequest) (*entities.Namespace, error)
+}
+
+type createNamespaceImpl struct {
+	database bun.IDB
+}
+
+func (dao *createNamespaceImpl) Exec(
+	ctx context.Context, now time.Time, request *CreateNamespaceRequest,
+) (*entities.Namespace, error) {
+	model := &entities.Namespace{
+		Name:            request.Name,
+		NamespacePolicy: request.Policy,
+		CreatedAt:       now,
+	}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+			if isUniqueViolation(err) {
+				return ErrNamespaceAlreadyExists
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+func NewCreateNamespace(database bun.IDB) CreateNamespace {
+	return &createNamespaceImpl{database: database}
+}
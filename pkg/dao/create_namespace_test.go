@@ -0,0 +1,127 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestCreateNamespace(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Namespace{
+			Name:            "existing",
+			NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now     time.Time
+		request *dao.CreateNamespaceRequest
+
+		expect    *entities.Namespace
+		expectErr error
+	}{
+		{
+			name: "Create",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateNamespaceRequest{
+				Name: "namespace",
+				Policy: entities.NamespacePolicy{
+					DefaultTTL:    lo.ToPtr(time.Hour),
+					MaxTTL:        lo.ToPtr(24 * time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					HashParams: &entities.HashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:     &entities.StrengthRules{MinLength: 8, RequireDigit: true},
+					SingleUseDefault:  true,
+					MaxActivePasskeys: lo.ToPtr(10),
+				},
+			},
+
+			expect: &entities.Namespace{
+				Name: "namespace",
+				NamespacePolicy: entities.NamespacePolicy{
+					DefaultTTL:    lo.ToPtr(time.Hour),
+					MaxTTL:        lo.ToPtr(24 * time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					HashParams: &entities.HashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:     &entities.StrengthRules{MinLength: 8, RequireDigit: true},
+					SingleUseDefault:  true,
+					MaxActivePasskeys: lo.ToPtr(10),
+				},
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/Minimal",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			},
+
+			expect: &entities.Namespace{
+				Name:            "namespace",
+				NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+				CreatedAt:       time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "AlreadyExists",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateNamespaceRequest{
+				Name:   "existing",
+				Policy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			},
+
+			expectErr: dao.ErrNamespaceAlreadyExists,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			createNamespaceDAO := dao.NewCreateNamespace(transaction)
+
+			result, err := createNamespaceDAO.Exec(context.Background(), testCase.now, testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, result)
+		})
+	}
+}
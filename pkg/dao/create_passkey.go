@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/uptrace/bun"
 
 	"github.com/a-novel/uservice-passkeys/pkg/entities"
@@ -17,6 +18,17 @@ type CreatePasskeyRequest struct {
 	Passkey   string
 	Reward    map[string]interface{}
 	ExpiresAt *time.Time
+	SingleUse bool
+
+	// HashParams defaults to lib.DefaultGenerateParams when empty.
+	HashParams *lib.GenerateParams
+	// MaxActivePasskeys rejects the creation once the namespace holds that many active passkeys. No limit applies
+	// when empty.
+	MaxActivePasskeys *int
+	// CreationRateLimit rejects the creation once that many passkeys were created in the namespace over the last
+	// CreationRateWindow. No limit applies when either is empty.
+	CreationRateLimit  *int
+	CreationRateWindow *time.Duration
 }
 
 type CreatePasskey interface {
@@ -30,7 +42,9 @@ type createPasskeyImpl struct {
 func (dao *createPasskeyImpl) Exec(
 	ctx context.Context, passkeyID uuid.UUID, now time.Time, request *CreatePasskeyRequest,
 ) (*entities.Passkey, error) {
-	encrypted, err := lib.GenerateFromPassword(request.Passkey, lib.DefaultGenerateParams)
+	encrypted, err := GenerateHash(
+		ctx, request.Passkey, lo.CoalesceOrEmpty(request.HashParams, lib.DefaultGenerateParams),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("encrypt passkey: %w", err)
 	}
@@ -40,13 +54,34 @@ func (dao *createPasskeyImpl) Exec(
 		Namespace:    request.Namespace,
 		EncryptedKey: encrypted,
 		Reward:       request.Reward,
+		SingleUse:    request.SingleUse,
 		ExpiresAt:    request.ExpiresAt,
 		CreatedAt:    now,
 	}
 
-	_, err = dao.database.NewInsert().Model(model).Returning("*").Exec(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("exec query: %w", err)
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		quotas := &PasskeyQuotas{
+			MaxActivePasskeys:  request.MaxActivePasskeys,
+			CreationRateLimit:  request.CreationRateLimit,
+			CreationRateWindow: request.CreationRateWindow,
+		}
+
+		if err := checkQuotas(ctx, tx, request.Namespace, quotas, now, []*entities.Passkey{model}); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+			if isUniqueViolation(err) {
+				return ErrPasskeyAlreadyExists
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
 	}
 
 	return model, nil
@@ -21,6 +21,8 @@ func TestCreatePasskey(t *testing.T) {
 	testCases := []struct {
 		name string
 
+		fixtures []interface{}
+
 		id      uuid.UUID
 		now     time.Time
 		request *dao.CreatePasskeyRequest
@@ -66,6 +68,181 @@ func TestCreatePasskey(t *testing.T) {
 				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
 			},
 		},
+		{
+			name: "Create/SingleUse",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+				SingleUse: true,
+				HashParams: &lib.GenerateParams{
+					SaltLength:  16,
+					Iterations:  1,
+					Memory:      16 * 1024,
+					Parallelism: 1,
+					KeyLength:   16,
+				},
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				SingleUse: true,
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/Quota",
+
+			fixtures: []interface{}{
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted",
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				// Other namespace.
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Namespace:    "namespace-2",
+					EncryptedKey: "encrypted",
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				// Expired.
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted",
+					ExpiresAt:    lo.ToPtr(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:    time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreatePasskeyRequest{
+				Namespace:         "namespace",
+				Passkey:           "passkey",
+				MaxActivePasskeys: lo.ToPtr(2),
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/QuotaExceeded",
+
+			fixtures: []interface{}{
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted",
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreatePasskeyRequest{
+				Namespace:         "namespace",
+				Passkey:           "passkey",
+				MaxActivePasskeys: lo.ToPtr(1),
+			},
+
+			expectErr: dao.ErrQuotaExceeded,
+		},
+		{
+			name: "Create/CreationRate",
+
+			fixtures: []interface{}{
+				// Outside the window.
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted",
+					CreatedAt:    time.Date(2021, 1, 31, 23, 0, 0, 0, time.UTC),
+				},
+				// Other namespace.
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Namespace:    "namespace-2",
+					EncryptedKey: "encrypted",
+					CreatedAt:    time.Date(2021, 1, 31, 23, 59, 0, 0, time.UTC),
+				},
+			},
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreatePasskeyRequest{
+				Namespace:          "namespace",
+				Passkey:            "passkey",
+				CreationRateLimit:  lo.ToPtr(1),
+				CreationRateWindow: lo.ToPtr(time.Minute * 10),
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/AlreadyExists",
+
+			fixtures: []interface{}{
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "other-namespace",
+					EncryptedKey: "encrypted",
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			expectErr: dao.ErrPasskeyAlreadyExists,
+		},
+		{
+			name: "Create/CreationRateExceeded",
+
+			fixtures: []interface{}{
+				// Expired passkeys still count toward the creation rate.
+				&entities.Passkey{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted",
+					ExpiresAt:    lo.ToPtr(time.Date(2021, 1, 31, 23, 56, 0, 0, time.UTC)),
+					CreatedAt:    time.Date(2021, 1, 31, 23, 55, 0, 0, time.UTC),
+				},
+			},
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreatePasskeyRequest{
+				Namespace:          "namespace",
+				Passkey:            "passkey",
+				CreationRateLimit:  lo.ToPtr(1),
+				CreationRateWindow: lo.ToPtr(time.Minute * 10),
+			},
+
+			expectErr: dao.ErrQuotaExceeded,
+		},
 	}
 
 	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
@@ -74,7 +251,7 @@ func TestCreatePasskey(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			transaction := anoveldb.BeginTestTX[interface{}](database, nil)
+			transaction := anoveldb.BeginTestTX(database, testCase.fixtures)
 			defer anoveldb.RollbackTestTX(transaction)
 
 			createPasskeyDAO := dao.NewCreatePasskey(transaction)
@@ -91,6 +268,8 @@ func TestCreatePasskey(t *testing.T) {
 				require.Equal(t, testCase.expect.Reward, result.Reward)
 				require.Equal(t, testCase.expect.ExpiresAt, result.ExpiresAt)
 				require.Equal(t, testCase.expect.CreatedAt, result.CreatedAt)
+				require.Equal(t, testCase.expect.SingleUse, result.SingleUse)
+				require.Equal(t, int64(1), result.Version)
 
 				matching, err := lib.ComparePasswordAndHash(testCase.request.Passkey, result.EncryptedKey)
 				require.NoError(t, err)
@@ -0,0 +1,847 @@
+// Package daotest holds the conformance suites the implementations of the DAOs must pass, so every storage behaves
+// like the Postgres one.
+package daotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+// OtherTenant owns some of the fixtures, to check tenants are isolated.
+const OtherTenant = "other-tenant"
+
+// PasskeyDAOs are the DAOs under test. They share the same storage.
+type PasskeyDAOs struct {
+	Create dao.CreatePasskey
+	Get    dao.GetPasskey
+	Update dao.UpdatePasskey
+	Delete dao.DeletePasskey
+}
+
+var (
+	passkeysNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	passkeyActiveID     = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	passkeyExpiredID    = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	passkeySingleUseID  = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	passkeyRevokedID    = uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	passkeyOtherID      = uuid.MustParse("00000000-0000-0000-0000-000000000005")
+	passkeyExpiresNowID = uuid.MustParse("00000000-0000-0000-0000-000000000006")
+	passkeyUnknownID    = uuid.MustParse("00000000-0000-0000-0000-000000000099")
+)
+
+const (
+	passkeysNamespace = "namespace"
+	password1         = "password1"
+	password2         = "password2"
+	password3         = "password3"
+)
+
+// passkeysSuite runs every test over the same fixtures, hashed once.
+type passkeysSuite struct {
+	newDAOs  func(t *testing.T, now time.Time, fixtures []*entities.Passkey) *PasskeyDAOs
+	fixtures []*entities.Passkey
+}
+
+// RunPasskeys checks the passkey DAOs returned by newDAOs behave like the Postgres ones. Each test gets its own
+// storage: newDAOs must return DAOs over a storage that only holds the fixtures, in the tenant they are set to, with
+// its time frozen at now. Changes must not outlive the test.
+func RunPasskeys(t *testing.T, newDAOs func(t *testing.T, now time.Time, fixtures []*entities.Passkey) *PasskeyDAOs) {
+	t.Helper()
+
+	suite := &passkeysSuite{newDAOs: newDAOs, fixtures: newPasskeyFixtures(t)}
+
+	t.Run("Create", suite.testCreate)
+	t.Run("Get", suite.testGet)
+	t.Run("Get/Redeem", suite.testRedeem)
+	t.Run("Update", suite.testUpdate)
+	t.Run("Update/History", suite.testHistory)
+	t.Run("Delete", suite.testDelete)
+}
+
+func newPasskeyFixtures(t *testing.T) []*entities.Passkey {
+	t.Helper()
+
+	encrypted1, err := lib.GenerateFromPassword(password1, lib.DefaultGenerateParams)
+	require.NoError(t, err)
+
+	return []*entities.Passkey{
+		{
+			ID:           passkeyActiveID,
+			Namespace:    passkeysNamespace,
+			EncryptedKey: encrypted1,
+			Reward:       map[string]interface{}{"key": "value"},
+			ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           passkeyExpiredID,
+			Namespace:    passkeysNamespace,
+			EncryptedKey: encrypted1,
+			ExpiresAt:    lo.ToPtr(time.Date(2020, 12, 31, 18, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           passkeySingleUseID,
+			Namespace:    passkeysNamespace,
+			EncryptedKey: encrypted1,
+			SingleUse:    true,
+			CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:               passkeyRevokedID,
+			Namespace:        passkeysNamespace,
+			EncryptedKey:     encrypted1,
+			RevokedAt:        lo.ToPtr(time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)),
+			RevokedBy:        lo.ToPtr("admin"),
+			RevocationReason: lo.ToPtr("leaked"),
+			CreatedAt:        time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           passkeyOtherID,
+			Namespace:    passkeysNamespace,
+			Tenant:       OtherTenant,
+			EncryptedKey: encrypted1,
+			CreatedAt:    time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC),
+		},
+		// Passkeys are still valid at the instant they expire.
+		{
+			ID:           passkeyExpiresNowID,
+			Namespace:    passkeysNamespace,
+			EncryptedKey: encrypted1,
+			ExpiresAt:    lo.ToPtr(passkeysNow),
+			CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+// fixture returns a copy of a fixture, as the storage returns it before any change.
+func (suite *passkeysSuite) fixture(id uuid.UUID) *entities.Passkey {
+	for _, fixture := range suite.fixtures {
+		if fixture.ID == id {
+			clone := *fixture
+			clone.Version = 1
+
+			return &clone
+		}
+	}
+
+	return nil
+}
+
+func tenantContext(tenant string) context.Context {
+	return dao.WithTenant(context.Background(), tenant)
+}
+
+func (suite *passkeysSuite) testCreate(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		tenant  string
+		id      uuid.UUID
+		request *dao.CreatePasskeyRequest
+
+		expect    *entities.Passkey
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			id: passkeyUnknownID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+				Reward:    map[string]interface{}{"key": "value"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				SingleUse: true,
+			},
+
+			expect: &entities.Passkey{
+				ID:        passkeyUnknownID,
+				Namespace: passkeysNamespace,
+				Reward:    map[string]interface{}{"key": "value"},
+				SingleUse: true,
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: passkeysNow,
+				Version:   1,
+			},
+		},
+		{
+			name: "OK/Tenant",
+
+			tenant: OtherTenant,
+			id:     passkeyUnknownID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+			},
+
+			expect: &entities.Passkey{
+				ID:        passkeyUnknownID,
+				Namespace: passkeysNamespace,
+				Tenant:    OtherTenant,
+				CreatedAt: passkeysNow,
+				Version:   1,
+			},
+		},
+		{
+			name: "AlreadyExists",
+
+			id: passkeyActiveID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+			},
+
+			expectErr: dao.ErrPasskeyAlreadyExists,
+		},
+		{
+			// IDs are unique across namespaces.
+			name: "AlreadyExists/OtherNamespace",
+
+			id: passkeyActiveID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace: "namespace-2",
+				Passkey:   password2,
+			},
+
+			expectErr: dao.ErrPasskeyAlreadyExists,
+		},
+		{
+			// IDs are only unique within a tenant.
+			name: "OK/OtherTenantID",
+
+			id: passkeyOtherID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+			},
+
+			expect: &entities.Passkey{
+				ID:        passkeyOtherID,
+				Namespace: passkeysNamespace,
+				CreatedAt: passkeysNow,
+				Version:   1,
+			},
+		},
+		{
+			// Expired, redeemed, revoked passkeys, and the passkeys of other tenants are not counted.
+			name: "MaxActivePasskeys",
+
+			id: passkeyUnknownID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace:         passkeysNamespace,
+				Passkey:           password2,
+				MaxActivePasskeys: lo.ToPtr(4),
+			},
+
+			expect: &entities.Passkey{
+				ID:        passkeyUnknownID,
+				Namespace: passkeysNamespace,
+				CreatedAt: passkeysNow,
+				Version:   1,
+			},
+		},
+		{
+			name: "MaxActivePasskeys/Exceeded",
+
+			id: passkeyUnknownID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace:         passkeysNamespace,
+				Passkey:           password2,
+				MaxActivePasskeys: lo.ToPtr(3),
+			},
+
+			expectErr: dao.ErrQuotaExceeded,
+		},
+		{
+			// Expired passkeys still count toward the rate, but not the passkeys of other tenants.
+			name: "CreationRate",
+
+			id: passkeyUnknownID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace:          passkeysNamespace,
+				Passkey:            password2,
+				CreationRateLimit:  lo.ToPtr(3),
+				CreationRateWindow: lo.ToPtr(24 * time.Hour),
+			},
+
+			expect: &entities.Passkey{
+				ID:        passkeyUnknownID,
+				Namespace: passkeysNamespace,
+				CreatedAt: passkeysNow,
+				Version:   1,
+			},
+		},
+		{
+			name: "CreationRate/Exceeded",
+
+			id: passkeyUnknownID,
+			request: &dao.CreatePasskeyRequest{
+				Namespace:          passkeysNamespace,
+				Passkey:            password2,
+				CreationRateLimit:  lo.ToPtr(2),
+				CreationRateWindow: lo.ToPtr(24 * time.Hour),
+			},
+
+			expectErr: dao.ErrQuotaExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			daos := suite.newDAOs(t, passkeysNow, suite.fixtures)
+			ctx := tenantContext(testCase.tenant)
+
+			res, err := daos.Create.Exec(ctx, testCase.id, passkeysNow, testCase.request)
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expect == nil {
+				require.Nil(t, res)
+
+				return
+			}
+
+			requirePasskey(t, testCase.expect, testCase.request.Passkey, res)
+
+			// The passkey can be read back.
+			stored, err := daos.Get.Exec(ctx, &dao.GetPasskeyRequest{
+				ID:        testCase.id,
+				Namespace: testCase.request.Namespace,
+				RawKey:    &testCase.request.Passkey,
+			})
+			require.NoError(t, err)
+			require.Equal(t, res.ID, stored.ID)
+		})
+	}
+}
+
+func (suite *passkeysSuite) testGet(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		tenant  string
+		request *dao.GetPasskeyRequest
+
+		expect    *entities.Passkey
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyActiveID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeyActiveID),
+		},
+		{
+			name: "OK/WithPassword",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyActiveID, Namespace: passkeysNamespace, RawKey: lo.ToPtr(password1)},
+
+			expect: suite.fixture(passkeyActiveID),
+		},
+		{
+			name: "OK/ExpiresNow",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyExpiresNowID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeyExpiresNowID),
+		},
+		{
+			name: "OK/Tenant",
+
+			tenant:  OtherTenant,
+			request: &dao.GetPasskeyRequest{ID: passkeyOtherID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeyOtherID),
+		},
+		{
+			// Single-use passkeys are only redeemed when validated.
+			name: "OK/SingleUse",
+
+			request: &dao.GetPasskeyRequest{ID: passkeySingleUseID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeySingleUseID),
+		},
+		{
+			name: "BadPassword",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyActiveID, Namespace: passkeysNamespace, RawKey: lo.ToPtr(password2)},
+
+			expectErr: dao.ErrInvalidPasskey,
+		},
+		{
+			name: "Expired",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyExpiredID, Namespace: passkeysNamespace},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "Revoked",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyRevokedID, Namespace: passkeysNamespace, RawKey: lo.ToPtr(password1)},
+
+			expectErr: dao.ErrPasskeyRevoked,
+		},
+		{
+			name: "OtherTenant",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyOtherID, Namespace: passkeysNamespace},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "OtherNamespace",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyActiveID, Namespace: "namespace-2"},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "NotFound",
+
+			request: &dao.GetPasskeyRequest{ID: passkeyUnknownID, Namespace: passkeysNamespace},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			daos := suite.newDAOs(t, passkeysNow, suite.fixtures)
+
+			res, err := daos.Get.Exec(tenantContext(testCase.tenant), testCase.request)
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, res)
+		})
+	}
+}
+
+func (suite *passkeysSuite) testRedeem(t *testing.T) {
+	daos := suite.newDAOs(t, passkeysNow, suite.fixtures)
+	ctx := context.Background()
+
+	request := &dao.GetPasskeyRequest{
+		ID:         passkeySingleUseID,
+		Namespace:  passkeysNamespace,
+		RawKey:     lo.ToPtr(password1),
+		RedeemedBy: lo.ToPtr("user-1"),
+	}
+
+	// A bad password does not redeem the passkey.
+	_, err := daos.Get.Exec(ctx, &dao.GetPasskeyRequest{
+		ID: passkeySingleUseID, Namespace: passkeysNamespace, RawKey: lo.ToPtr(password2),
+	})
+	require.ErrorIs(t, err, dao.ErrInvalidPasskey)
+
+	expect := suite.fixture(passkeySingleUseID)
+	expect.RedeemedAt = lo.ToPtr(passkeysNow)
+	expect.RedeemedBy = lo.ToPtr("user-1")
+	expect.Version = 2
+
+	res, err := daos.Get.Exec(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, expect, res)
+
+	_, err = daos.Get.Exec(ctx, request)
+	require.ErrorIs(t, err, dao.ErrPasskeyNotFound)
+
+	// Redeemed passkeys are kept until they are purged.
+	deleted, err := daos.Delete.Exec(ctx, &dao.DeletePasskeyRequest{ID: passkeySingleUseID, Namespace: passkeysNamespace})
+	require.NoError(t, err)
+	require.Equal(t, expect, deleted)
+}
+
+func (suite *passkeysSuite) testUpdate(t *testing.T) {
+	updatedAt := passkeysNow.Add(time.Hour)
+
+	withChanges := func(id uuid.UUID, update func(passkey *entities.Passkey)) *entities.Passkey {
+		passkey := suite.fixture(id)
+		passkey.UpdatedAt = &updatedAt
+		passkey.Version++
+		update(passkey)
+
+		return passkey
+	}
+
+	testCases := []struct {
+		name string
+
+		tenant  string
+		id      uuid.UUID
+		request *dao.UpdatePasskeyRequest
+
+		expect *entities.Passkey
+		// expectSecret must match the hash of the updated passkey.
+		expectSecret string
+		expectErr    error
+	}{
+		{
+			name: "OK",
+
+			id: passkeyActiveID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+				Reward:    map[string]interface{}{"key": "other-value"},
+			},
+
+			expect: withChanges(passkeyActiveID, func(passkey *entities.Passkey) {
+				passkey.Reward = map[string]interface{}{"key": "other-value"}
+				passkey.ExpiresAt = nil
+			}),
+			expectSecret: password2,
+		},
+		{
+			name: "OK/Mask",
+
+			id: passkeyActiveID,
+			request: &dao.UpdatePasskeyRequest{
+				Mask:      []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+				Reward:    map[string]interface{}{"key": "other-value"},
+			},
+
+			expect: withChanges(passkeyActiveID, func(passkey *entities.Passkey) {
+				passkey.Reward = map[string]interface{}{"key": "other-value"}
+			}),
+			expectSecret: password1,
+		},
+		{
+			// Expired passkeys can be extended.
+			name: "OK/Expired",
+
+			id: passkeyExpiredID,
+			request: &dao.UpdatePasskeyRequest{
+				Mask:      []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldExpiresAt},
+				Namespace: passkeysNamespace,
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: withChanges(passkeyExpiredID, func(passkey *entities.Passkey) {
+				passkey.ExpiresAt = lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC))
+			}),
+			expectSecret: password1,
+		},
+		{
+			name: "OK/CurrentKey",
+
+			id: passkeyActiveID,
+			request: &dao.UpdatePasskeyRequest{
+				Mask:       []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldPasskey},
+				Namespace:  passkeysNamespace,
+				Passkey:    password2,
+				CurrentKey: lo.ToPtr(password1),
+			},
+
+			expect:       withChanges(passkeyActiveID, func(_ *entities.Passkey) {}),
+			expectSecret: password2,
+		},
+		{
+			name: "OK/ExpectedVersion",
+
+			id: passkeyActiveID,
+			request: &dao.UpdatePasskeyRequest{
+				Mask:            []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace:       passkeysNamespace,
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expect: withChanges(passkeyActiveID, func(passkey *entities.Passkey) {
+				passkey.Reward = nil
+			}),
+			expectSecret: password1,
+		},
+		{
+			name: "OK/Tenant",
+
+			tenant: OtherTenant,
+			id:     passkeyOtherID,
+			request: &dao.UpdatePasskeyRequest{
+				Mask:      []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace: passkeysNamespace,
+				Reward:    map[string]interface{}{"key": "value"},
+			},
+
+			expect: withChanges(passkeyOtherID, func(passkey *entities.Passkey) {
+				passkey.Reward = map[string]interface{}{"key": "value"}
+			}),
+			expectSecret: password1,
+		},
+		{
+			name: "CurrentKey/Invalid",
+
+			id: passkeyActiveID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:  passkeysNamespace,
+				Passkey:    password2,
+				CurrentKey: lo.ToPtr(password2),
+			},
+
+			expectErr: dao.ErrInvalidPasskey,
+		},
+		{
+			name: "ExpectedVersion/Mismatch",
+
+			id: passkeyActiveID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:       passkeysNamespace,
+				Passkey:         password2,
+				ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+
+			expectErr: dao.ErrVersionMismatch,
+		},
+		{
+			name: "ExpectedVersion/Revoked",
+
+			id: passkeyRevokedID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:       passkeysNamespace,
+				Passkey:         password2,
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expectErr: dao.ErrPasskeyRevoked,
+		},
+		{
+			name: "ExpectedVersion/NotFound",
+
+			id: passkeyUnknownID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:       passkeysNamespace,
+				Passkey:         password2,
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "Revoked",
+
+			id: passkeyRevokedID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+			},
+
+			expectErr: dao.ErrPasskeyRevoked,
+		},
+		{
+			name: "OtherTenant",
+
+			id: passkeyOtherID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "NotFound",
+
+			id: passkeyUnknownID,
+			request: &dao.UpdatePasskeyRequest{
+				Namespace: passkeysNamespace,
+				Passkey:   password2,
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			daos := suite.newDAOs(t, passkeysNow, suite.fixtures)
+			ctx := tenantContext(testCase.tenant)
+
+			res, err := daos.Update.Exec(ctx, testCase.id, updatedAt, testCase.request)
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expect == nil {
+				require.Nil(t, res)
+
+				return
+			}
+
+			requirePasskey(t, testCase.expect, testCase.expectSecret, res)
+		})
+	}
+}
+
+// testHistory rotates the secret of a passkey, which must differ from the current and the previous ones.
+func (suite *passkeysSuite) testHistory(t *testing.T) {
+	daos := suite.newDAOs(t, passkeysNow, suite.fixtures)
+	ctx := context.Background()
+
+	rotate := func(secret string) error {
+		_, err := daos.Update.Exec(ctx, passkeyActiveID, passkeysNow, &dao.UpdatePasskeyRequest{
+			Mask:        []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldPasskey},
+			Namespace:   passkeysNamespace,
+			Passkey:     secret,
+			HistorySize: 1,
+		})
+
+		return err //nolint:wrapcheck
+	}
+
+	require.ErrorIs(t, rotate(password1), dao.ErrSecretReused)
+	require.NoError(t, rotate(password2))
+	require.ErrorIs(t, rotate(password1), dao.ErrSecretReused)
+	require.NoError(t, rotate(password3))
+	// Only the latest previous secret is kept.
+	require.NoError(t, rotate(password1))
+
+	_, err := daos.Get.Exec(ctx, &dao.GetPasskeyRequest{
+		ID: passkeyActiveID, Namespace: passkeysNamespace, RawKey: lo.ToPtr(password1),
+	})
+	require.NoError(t, err)
+}
+
+func (suite *passkeysSuite) testDelete(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		tenant  string
+		request *dao.DeletePasskeyRequest
+
+		expect    *entities.Passkey
+		expectErr error
+		// expectKept is set when the passkey must be left untouched.
+		expectKept bool
+	}{
+		{
+			name: "OK",
+
+			request: &dao.DeletePasskeyRequest{ID: passkeyActiveID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeyActiveID),
+		},
+		{
+			name: "OK/WithPassword",
+
+			request: &dao.DeletePasskeyRequest{ID: passkeyActiveID, Namespace: passkeysNamespace, RawKey: lo.ToPtr(password1)},
+
+			expect: suite.fixture(passkeyActiveID),
+		},
+		{
+			name: "OK/ExpectedVersion",
+
+			request: &dao.DeletePasskeyRequest{
+				ID: passkeyActiveID, Namespace: passkeysNamespace, ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expect: suite.fixture(passkeyActiveID),
+		},
+		{
+			name: "OK/Expired",
+
+			request: &dao.DeletePasskeyRequest{ID: passkeyExpiredID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeyExpiredID),
+		},
+		{
+			name: "OK/Revoked",
+
+			request: &dao.DeletePasskeyRequest{ID: passkeyRevokedID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeyRevokedID),
+		},
+		{
+			name: "OK/Tenant",
+
+			tenant:  OtherTenant,
+			request: &dao.DeletePasskeyRequest{ID: passkeyOtherID, Namespace: passkeysNamespace},
+
+			expect: suite.fixture(passkeyOtherID),
+		},
+		{
+			name: "BadPassword",
+
+			request: &dao.DeletePasskeyRequest{ID: passkeyActiveID, Namespace: passkeysNamespace, RawKey: lo.ToPtr(password2)},
+
+			expectErr:  dao.ErrInvalidPasskey,
+			expectKept: true,
+		},
+		{
+			name: "ExpectedVersion/Mismatch",
+
+			request: &dao.DeletePasskeyRequest{
+				ID: passkeyActiveID, Namespace: passkeysNamespace, ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+
+			expectErr:  dao.ErrVersionMismatch,
+			expectKept: true,
+		},
+		{
+			name: "ExpectedVersion/NotFound",
+
+			request: &dao.DeletePasskeyRequest{
+				ID: passkeyUnknownID, Namespace: passkeysNamespace, ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			request: &dao.DeletePasskeyRequest{ID: passkeyOtherID, Namespace: passkeysNamespace},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "NotFound",
+
+			request: &dao.DeletePasskeyRequest{ID: passkeyUnknownID, Namespace: passkeysNamespace},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			daos := suite.newDAOs(t, passkeysNow, suite.fixtures)
+			ctx := tenantContext(testCase.tenant)
+
+			res, err := daos.Delete.Exec(ctx, testCase.request)
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, res)
+
+			_, err = daos.Get.Exec(ctx, &dao.GetPasskeyRequest{ID: testCase.request.ID, Namespace: testCase.request.Namespace})
+			if testCase.expectKept {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, dao.ErrPasskeyNotFound)
+			}
+		})
+	}
+}
+
+// requirePasskey compares passkeys, except for their hashes, which are salted. The hash of actual must match secret
+// instead.
+func requirePasskey(t *testing.T, expect *entities.Passkey, secret string, actual *entities.Passkey) {
+	t.Helper()
+
+	require.NotNil(t, actual)
+
+	match, err := lib.ComparePasswordAndHash(secret, actual.EncryptedKey)
+	require.NoError(t, err)
+	require.True(t, match)
+
+	expect = lo.ToPtr(*expect)
+	expect.EncryptedKey = actual.EncryptedKey
+
+	require.Equal(t, expect, actual)
+}
@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type DeleteNamespaceRequest struct {
+	Name string
+}
+
+type DeleteNamespace interface {
+	Exec(ctx context.Context, request *DeleteNamespaceRequest) (*entities.Namespace, error)
+}
+
+type deleteNamespaceImpl struct {
+	database bun.IDB
+}
+
+func (dao *deleteNamespaceImpl) Exec(
+	ctx context.Context, request *DeleteNamespaceRequest,
+) (*entities.Namespace, error) {
+	model := &entities.Namespace{Name: request.Name}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		// Passkeys would silently fall back to the default policy once their namespace is gone. Expired passkeys are
+		// checked too, as they are still visible to the storage.
+		exists, err := tx.NewSelect().
+			Table("passkeys").
+			Where("namespace = ?", request.Name).
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check passkeys: %w", err)
+		}
+
+		if exists {
+			return ErrNamespaceNotEmpty
+		}
+
+		rows, err := tx.NewDelete().
+			Model(model).
+			WherePK().
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		affected, err := rows.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if affected == 0 {
+			return ErrNamespaceNotFound
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+func NewDeleteNamespace(database bun.IDB) DeleteNamespace {
+	return &deleteNamespaceImpl{database: database}
+}
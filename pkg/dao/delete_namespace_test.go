@@ -0,0 +1,95 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestDeleteNamespace(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Namespace{
+			Name:            "namespace",
+			NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Namespace{
+			Name:            "namespace-with-passkeys",
+			NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Namespace:    "namespace-with-passkeys",
+			EncryptedKey: "encrypted",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		namespace string
+
+		expect    *entities.Namespace
+		expectErr error
+	}{
+		{
+			name: "Delete",
+
+			namespace: "namespace",
+
+			expect: &entities.Namespace{
+				Name:            "namespace",
+				NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "NotEmpty",
+
+			namespace: "namespace-with-passkeys",
+
+			expectErr: dao.ErrNamespaceNotEmpty,
+		},
+		{
+			name: "NotFound",
+
+			namespace: "namespace-2",
+
+			expectErr: dao.ErrNamespaceNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			deleteNamespaceDAO := dao.NewDeleteNamespace(transaction)
+
+			result, err := deleteNamespaceDAO.Exec(context.Background(), &dao.DeleteNamespaceRequest{Name: testCase.namespace})
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expectErr == nil {
+				require.Equal(t, testCase.expect, result)
+			} else {
+				require.Nil(t, result)
+			}
+		})
+	}
+}
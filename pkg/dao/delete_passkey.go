@@ -8,13 +8,14 @@ import (
 	"github.com/uptrace/bun"
 
 	"github.com/a-novel/uservice-passkeys/pkg/entities"
-	"github.com/a-novel/uservice-passkeys/pkg/lib"
 )
 
 type DeletePasskeyRequest struct {
 	ID        uuid.UUID
 	Namespace string
 	RawKey    *string
+	// ExpectedVersion rejects the deletion if the passkey was modified since this version, when set.
+	ExpectedVersion *int64
 }
 
 type DeletePasskey interface {
@@ -31,12 +32,17 @@ func (dao *deletePasskeyImpl) Exec(ctx context.Context, request *DeletePasskeyRe
 		Namespace: request.Namespace,
 	}
 
-	txErr := dao.database.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		rows, err := tx.NewDelete().
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewDelete().
 			Model(model).
 			WherePK().
-			Returning("*").
-			Exec(ctx)
+			Returning("*")
+
+		if request.ExpectedVersion != nil {
+			query = query.Where("version = ?", *request.ExpectedVersion)
+		}
+
+		rows, err := query.Exec(ctx)
 		if err != nil {
 			return fmt.Errorf("exec query: %w", err)
 		}
@@ -47,11 +53,11 @@ func (dao *deletePasskeyImpl) Exec(ctx context.Context, request *DeletePasskeyRe
 		}
 
 		if affected == 0 {
-			return ErrPasskeyNotFound
+			return checkVersionMismatch(ctx, tx, request.ID, request.Namespace, request.ExpectedVersion)
 		}
 
 		if request.RawKey != nil {
-			match, err := lib.ComparePasswordAndHash(*request.RawKey, model.EncryptedKey)
+			match, err := CompareHash(ctx, *request.RawKey, model.EncryptedKey)
 			if err != nil {
 				return fmt.Errorf("compare passkey: %w", err)
 			}
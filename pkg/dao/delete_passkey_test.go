@@ -71,6 +71,7 @@ func TestDeletePasskey(t *testing.T) {
 				ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
 				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				Version:      1,
 			},
 		},
 		{
@@ -87,6 +88,7 @@ func TestDeletePasskey(t *testing.T) {
 				EncryptedKey: encryptedPassword2,
 				ExpiresAt:    lo.ToPtr(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)),
 				CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      1,
 			},
 		},
 		{
@@ -99,6 +101,46 @@ func TestDeletePasskey(t *testing.T) {
 
 			expectErr: dao.ErrPasskeyNotFound,
 		},
+		{
+			name: "Delete/ExpectedVersion",
+
+			request: &dao.DeletePasskeyRequest{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace:       "namespace-2",
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expect: &entities.Passkey{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace:    "namespace-2",
+				EncryptedKey: encryptedPassword2,
+				ExpiresAt:    lo.ToPtr(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      1,
+			},
+		},
+		{
+			name: "Delete/ExpectedVersion/Mismatch",
+
+			request: &dao.DeletePasskeyRequest{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace:       "namespace-2",
+				ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+
+			expectErr: dao.ErrVersionMismatch,
+		},
+		{
+			name: "Delete/ExpectedVersion/NotFound",
+
+			request: &dao.DeletePasskeyRequest{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Namespace:       "namespace",
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
 		{
 			name: "Delete/WithPassword",
 
@@ -116,6 +158,7 @@ func TestDeletePasskey(t *testing.T) {
 				ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
 				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				Version:      1,
 			},
 		},
 		{
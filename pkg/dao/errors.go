@@ -1,8 +1,66 @@
 package dao
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+)
 
 var (
-	ErrPasskeyNotFound = errors.New("passkey not found")
-	ErrInvalidPasskey  = errors.New("invalid passkey")
+	ErrPasskeyNotFound        = errors.New("passkey not found")
+	ErrPasskeyAlreadyExists   = errors.New("passkey already exists")
+	ErrInvalidPasskey         = errors.New("invalid passkey")
+	ErrNamespaceNotFound      = errors.New("namespace not found")
+	ErrNamespaceAlreadyExists = errors.New("namespace already exists")
+	ErrNamespaceNotEmpty      = errors.New("namespace still has passkeys")
+	ErrQuotaExceeded          = errors.New("namespace quota exceeded")
+	ErrPasskeyRevoked         = errors.New("passkey revoked")
+	ErrPasskeyNotRevoked      = errors.New("passkey is not revoked")
+	ErrRestoreWindowExpired   = errors.New("passkey was revoked too long ago to be restored")
+	ErrPurgeLocked            = errors.New("another purge is running")
+	ErrSecretReused           = errors.New("secret was used recently by this passkey")
+	ErrVersionMismatch        = errors.New("passkey was modified since the expected version")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was used with a different request")
+	ErrIdempotencyKeyPending  = errors.New("request with the same idempotency key is still running")
 )
+
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const pgUniqueViolation = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pgErr pgdriver.Error
+
+	return errors.As(err, &pgErr) && pgErr.Field('C') == pgUniqueViolation
+}
+
+const (
+	QuotaActivePasskeys = "active_passkeys"
+	QuotaCreationRate   = "creation_rate"
+)
+
+// QuotaExceededError describes the namespace quota a creation hit. It matches ErrQuotaExceeded.
+type QuotaExceededError struct {
+	Namespace string
+	// Quota is one of the Quota* constants.
+	Quota string
+	Limit int
+	// Window is set for rate quotas only.
+	Window time.Duration
+}
+
+func (err *QuotaExceededError) Error() string {
+	if err.Window > 0 {
+		return fmt.Sprintf(
+			"%s: %s limit of %d per %s reached in namespace %q",
+			ErrQuotaExceeded, err.Quota, err.Limit, err.Window, err.Namespace,
+		)
+	}
+
+	return fmt.Sprintf("%s: %s limit of %d reached in namespace %q", ErrQuotaExceeded, err.Quota, err.Limit, err.Namespace)
+}
+
+func (err *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
@@ -0,0 +1,55 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type GetNamespaceRequest struct {
+	Name string
+}
+
+type GetNamespace interface {
+	Exec(ctx context.Context, request *GetNamespaceRequest) (*entities.Namespace, error)
+}
+
+type getNamespaceImpl struct {
+	database bun.IDB
+}
+
+func (dao *getNamespaceImpl) Exec(
+	ctx context.Context, request *GetNamespaceRequest,
+) (*entities.Namespace, error) {
+	model := &entities.Namespace{Name: request.Name}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Model(model).
+			WherePK().
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNamespaceNotFound
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+func NewGetNamespace(database bun.IDB) GetNamespace {
+	return &getNamespaceImpl{database: database}
+}
@@ -0,0 +1,87 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestGetNamespace(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Namespace{
+			Name: "namespace",
+			NamespacePolicy: entities.NamespacePolicy{
+				MaxTTL:        lo.ToPtr(time.Hour),
+				HashAlgorithm: entities.HashAlgorithmArgon2id,
+				StrengthRules: &entities.StrengthRules{MinLength: 8},
+			},
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		namespace string
+
+		expect    *entities.Namespace
+		expectErr error
+	}{
+		{
+			name: "Get",
+
+			namespace: "namespace",
+
+			expect: &entities.Namespace{
+				Name: "namespace",
+				NamespacePolicy: entities.NamespacePolicy{
+					MaxTTL:        lo.ToPtr(time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					StrengthRules: &entities.StrengthRules{MinLength: 8},
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "NotFound",
+
+			namespace: "namespace-2",
+
+			expectErr: dao.ErrNamespaceNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(nil)
+	require.NoError(t, err)
+	defer closer()
+
+	formatter := formatters.NewConsoleFormatter(loggers.NewSTDOut(), true)
+	require.NoError(t, anoveldb.Migrate(database, migrations.SQLMigrations, formatter))
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			getNamespaceDAO := dao.NewGetNamespace(transaction)
+
+			result, err := getNamespaceDAO.Exec(context.Background(), &dao.GetNamespaceRequest{Name: testCase.namespace})
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, result)
+		})
+	}
+}
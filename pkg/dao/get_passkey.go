@@ -10,13 +10,16 @@ import (
 	"github.com/uptrace/bun"
 
 	"github.com/a-novel/uservice-passkeys/pkg/entities"
-	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
 )
 
 type GetPasskeyRequest struct {
 	ID        uuid.UUID
 	Namespace string
-	RawKey    *string
+	// RawKey validates the passkey when set. Validating a single-use passkey redeems it.
+	RawKey *string
+	// RedeemedBy is recorded when the request redeems a single-use passkey.
+	RedeemedBy *string
 }
 
 type GetPasskey interface {
@@ -28,35 +31,133 @@ type getPasskeyImpl struct {
 }
 
 func (dao *getPasskeyImpl) Exec(ctx context.Context, request *GetPasskeyRequest) (*entities.Passkey, error) {
+	for {
+		model, err := dao.getActive(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		if request.RawKey == nil {
+			return model, nil
+		}
+
+		// Secrets are compared without holding any lock, as hashing is slow.
+		match, err := CompareHash(ctx, *request.RawKey, model.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("compare passkey: %w", err)
+		}
+
+		if !match {
+			return nil, ErrInvalidPasskey
+		}
+
+		if !model.SingleUse {
+			return model, nil
+		}
+
+		redeemed, err := dao.redeem(ctx, model, request)
+		if err != nil {
+			return nil, err
+		}
+
+		if redeemed {
+			// Only count redemptions once they are committed.
+			recordRedemption(model, request)
+
+			return model, nil
+		}
+
+		// The passkey was modified while its secret was compared, and may have been redeemed by another request.
+	}
+}
+
+// getActive returns the passkey if it is active, and tells revoked passkeys apart from the ones that do not exist
+// otherwise.
+func (dao *getPasskeyImpl) getActive(ctx context.Context, request *GetPasskeyRequest) (*entities.Passkey, error) {
 	model := &entities.Passkey{
 		ID:        request.ID,
 		Namespace: request.Namespace,
 	}
 
-	err := dao.database.NewSelect().
-		Model(model).
-		WherePK().
-		Scan(ctx)
-	if err != nil {
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(model).WherePK().Scan(ctx)
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrPasskeyNotFound
+			return dao.checkRevoked(ctx, tx, request)
+		}
+
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
 		}
 
-		return nil, fmt.Errorf("exec query: %w", err)
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
 	}
 
-	if request.RawKey != nil {
-		match, err := lib.ComparePasswordAndHash(*request.RawKey, model.EncryptedKey)
+	return model, nil
+}
+
+// redeem marks a single-use passkey as redeemed, unless it was modified since it was read. The conditional update
+// guarantees a passkey is only redeemed once, without locking it while its secret is compared.
+func (dao *getPasskeyImpl) redeem(
+	ctx context.Context, model *entities.Passkey, request *GetPasskeyRequest,
+) (bool, error) {
+	var affected int64
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		rows, err := tx.NewUpdate().
+			Model(model).
+			WherePK().
+			Where("version = ?", model.Version).
+			Where("redeemed_at IS NULL").
+			Set("redeemed_at = now()").
+			Set("redeemed_by = ?", request.RedeemedBy).
+			Set("version = version + 1").
+			Returning("redeemed_at, redeemed_by, version").
+			Exec(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("compare passkey: %w", err)
+			return fmt.Errorf("exec query: %w", err)
 		}
 
-		if !match {
-			return nil, ErrInvalidPasskey
+		if affected, err = rows.RowsAffected(); err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
 		}
+
+		return nil
+	})
+	if txErr != nil {
+		return false, fmt.Errorf("redeem passkey: %w", txErr)
 	}
 
-	return model, nil
+	return affected > 0, nil
+}
+
+// recordRedemption counts single-use passkeys redeemed by a successful validation.
+func recordRedemption(model *entities.Passkey, request *GetPasskeyRequest) {
+	if model.SingleUse && request.RawKey != nil {
+		metrics.Redemptions.WithLabelValues(metrics.NamespaceLabel(model.Namespace)).Inc()
+	}
+}
+
+// checkRevoked tells revoked passkeys apart from the ones that do not exist, once they are missing from the active
+// view.
+func (dao *getPasskeyImpl) checkRevoked(ctx context.Context, tx bun.Tx, request *GetPasskeyRequest) error {
+	revoked, err := tx.NewSelect().
+		Table("passkeys").
+		Where("id = ?", request.ID).
+		Where("namespace = ?", request.Namespace).
+		Where("revoked_at IS NOT NULL").
+		Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("check revocation: %w", err)
+	}
+
+	if revoked {
+		return ErrPasskeyRevoked
+	}
+
+	return ErrPasskeyNotFound
 }
 
 func NewGetPasskey(database bun.IDB) GetPasskey {
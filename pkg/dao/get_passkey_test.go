@@ -53,6 +53,24 @@ func TestGetPasskey(t *testing.T) {
 			Reward:       map[string]interface{}{"key": "value"},
 			CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
 		},
+		// Single use
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			Namespace:    "namespace",
+			EncryptedKey: encryptedPassword1,
+			SingleUse:    true,
+			CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Revoked
+		&entities.Passkey{
+			ID:               uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			Namespace:        "namespace",
+			EncryptedKey:     encryptedPassword1,
+			RevokedAt:        lo.ToPtr(time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)),
+			RevokedBy:        lo.ToPtr("admin"),
+			RevocationReason: lo.ToPtr("leaked"),
+			CreatedAt:        time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	testCases := []struct {
@@ -78,6 +96,7 @@ func TestGetPasskey(t *testing.T) {
 				Reward:       map[string]interface{}{"key": "value"},
 				ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
 				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      1,
 			},
 		},
 		{
@@ -94,6 +113,7 @@ func TestGetPasskey(t *testing.T) {
 				EncryptedKey: encryptedPassword2,
 				Reward:       map[string]interface{}{"key": "value"},
 				CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      1,
 			},
 		},
 		{
@@ -106,6 +126,17 @@ func TestGetPasskey(t *testing.T) {
 
 			expectErr: dao.ErrPasskeyNotFound,
 		},
+		{
+			name: "Get/Revoked",
+
+			request: &dao.GetPasskeyRequest{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+				Namespace: "namespace",
+				RawKey:    &password1,
+			},
+
+			expectErr: dao.ErrPasskeyRevoked,
+		},
 		{
 			name: "NotFound",
 
@@ -132,6 +163,7 @@ func TestGetPasskey(t *testing.T) {
 				Reward:       map[string]interface{}{"key": "value"},
 				ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
 				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      1,
 			},
 		},
 		{
@@ -145,6 +177,66 @@ func TestGetPasskey(t *testing.T) {
 
 			expectErr: dao.ErrInvalidPasskey,
 		},
+		{
+			name: "Get/SingleUse",
+
+			request: &dao.GetPasskeyRequest{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Namespace: "namespace",
+			},
+
+			expect: &entities.Passkey{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Namespace:    "namespace",
+				EncryptedKey: encryptedPassword1,
+				SingleUse:    true,
+				CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      1,
+			},
+		},
+		{
+			name: "Get/SingleUse/BadPassword",
+
+			request: &dao.GetPasskeyRequest{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Namespace: "namespace",
+				RawKey:    &password2,
+			},
+
+			expectErr: dao.ErrInvalidPasskey,
+		},
+		{
+			name: "Get/SingleUse/Redeem",
+
+			request: &dao.GetPasskeyRequest{
+				ID:         uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Namespace:  "namespace",
+				RawKey:     &password1,
+				RedeemedBy: lo.ToPtr("user-1"),
+			},
+
+			expect: &entities.Passkey{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Namespace:    "namespace",
+				EncryptedKey: encryptedPassword1,
+				SingleUse:    true,
+				RedeemedAt:   lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+				RedeemedBy:   lo.ToPtr("user-1"),
+				CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      2,
+			},
+		},
+		{
+			name: "Get/SingleUse/Redeemed",
+
+			request: &dao.GetPasskeyRequest{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Namespace: "namespace",
+				RawKey:    &password1,
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
 	}
 
 	database, closer, err := anoveldb.OpenTestDB(nil)
@@ -0,0 +1,46 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+// GenerateHash hashes a passkey with lib.GenerateFromPassword, in a span, and reports how long it took. Every storage
+// hashes passkeys through it.
+func GenerateHash(ctx context.Context, passkey string, params *lib.GenerateParams) (string, error) {
+	_, span := lib.StartSpan(ctx, "dao.GenerateHash")
+
+	span.SetAttributes(
+		attribute.Int64("argon2.memory", int64(params.Memory)),
+		attribute.Int64("argon2.iterations", int64(params.Iterations)),
+		attribute.Int("argon2.parallelism", int(params.Parallelism)),
+	)
+
+	timer := prometheus.NewTimer(metrics.HashDuration.WithLabelValues(metrics.HashGenerate))
+	encrypted, err := lib.GenerateFromPassword(passkey, params)
+	timer.ObserveDuration()
+
+	lib.EndSpan(span, err)
+
+	// Callers describe what the passkey was hashed for.
+	return encrypted, err //nolint:wrapcheck
+}
+
+// CompareHash checks a passkey against its hash with lib.ComparePasswordAndHash, in a span, and reports how long it
+// took.
+func CompareHash(ctx context.Context, passkey, encrypted string) (bool, error) {
+	_, span := lib.StartSpan(ctx, "dao.CompareHash")
+
+	timer := prometheus.NewTimer(metrics.HashDuration.WithLabelValues(metrics.HashCompare))
+	match, err := lib.ComparePasswordAndHash(passkey, encrypted)
+	timer.ObserveDuration()
+
+	lib.EndSpan(span, err)
+
+	return match, err //nolint:wrapcheck
+}
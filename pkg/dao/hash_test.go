@@ -0,0 +1,32 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+func TestHash(t *testing.T) {
+	encrypted, err := dao.GenerateHash(context.Background(), "password", lib.DefaultGenerateParams)
+	require.NoError(t, err)
+
+	match, err := dao.CompareHash(context.Background(), "password", encrypted)
+	require.NoError(t, err)
+	require.True(t, match)
+
+	match, err = dao.CompareHash(context.Background(), "other-password", encrypted)
+	require.NoError(t, err)
+	require.False(t, match)
+
+	_, err = dao.CompareHash(context.Background(), "password", "malformed")
+	require.ErrorIs(t, err, lib.ErrInvalidHash)
+
+	// Both operations are timed.
+	require.Equal(t, 2, testutil.CollectAndCount(metrics.HashDuration))
+}
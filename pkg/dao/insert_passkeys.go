@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type InsertPasskeysRequest struct {
+	Passkeys []*entities.Passkey
+	// Quotas of the namespaces of the passkeys, checked as on creation. Passkeys of other namespaces are not limited.
+	Quotas map[string]*PasskeyQuotas
+}
+
+// InsertPasskeys writes already encrypted passkeys in a single statement. It is meant for bulk operations, such as
+// imports, where the caller is responsible for hashing the keys.
+type InsertPasskeys interface {
+	Exec(ctx context.Context, now time.Time, request *InsertPasskeysRequest) error
+}
+
+type insertPasskeysImpl struct {
+	database bun.IDB
+}
+
+func (dao *insertPasskeysImpl) Exec(ctx context.Context, now time.Time, request *InsertPasskeysRequest) error {
+	if len(request.Passkeys) == 0 {
+		return nil
+	}
+
+	// Namespaces are locked in the same order by every import, so concurrent batches cannot deadlock.
+	namespaces := lo.Uniq(lo.Map(request.Passkeys, func(item *entities.Passkey, _ int) string {
+		return item.Namespace
+	}))
+	slices.Sort(namespaces)
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		for _, namespace := range namespaces {
+			quotas, ok := request.Quotas[namespace]
+			if !ok {
+				continue
+			}
+
+			pending := lo.Filter(request.Passkeys, func(item *entities.Passkey, _ int) bool {
+				return item.Namespace == namespace
+			})
+
+			if err := checkQuotas(ctx, tx, namespace, quotas, now, pending); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.NewInsert().Model(&request.Passkeys).Exec(ctx); err != nil {
+			if isUniqueViolation(err) {
+				return ErrPasskeyAlreadyExists
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return nil
+}
+
+func NewInsertPasskeys(database bun.IDB) InsertPasskeys {
+	return &insertPasskeysImpl{database: database}
+}
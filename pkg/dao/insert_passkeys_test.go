@@ -0,0 +1,187 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestInsertPasskeys(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Namespace:    "namespace",
+			EncryptedKey: "encrypted-key-1",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		passkeys []*entities.Passkey
+		quotas   map[string]*dao.PasskeyQuotas
+
+		expectErr error
+	}{
+		{
+			name: "Insert",
+
+			passkeys: []*entities.Passkey{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-2",
+					Reward:       map[string]interface{}{"key": "value"},
+					ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Namespace:    "namespace-2",
+					EncryptedKey: "encrypted-key-3",
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "Insert/Empty",
+		},
+		{
+			name: "Insert/Duplicate",
+
+			passkeys: []*entities.Passkey{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-2",
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-3",
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expectErr: dao.ErrPasskeyAlreadyExists,
+		},
+		{
+			name: "MaxActivePasskeys",
+
+			passkeys: []*entities.Passkey{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-2",
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					// Expired passkeys are not counted.
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-3",
+					ExpiresAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			quotas: map[string]*dao.PasskeyQuotas{"namespace": {MaxActivePasskeys: lo.ToPtr(2)}},
+		},
+		{
+			name: "MaxActivePasskeys/Exceeded",
+
+			passkeys: []*entities.Passkey{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-2",
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-3",
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			quotas: map[string]*dao.PasskeyQuotas{"namespace": {MaxActivePasskeys: lo.ToPtr(2)}},
+
+			expectErr: dao.ErrQuotaExceeded,
+		},
+		{
+			// Only the passkeys created over the window are counted.
+			name: "CreationRate/Exceeded",
+
+			passkeys: []*entities.Passkey{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-2",
+					CreatedAt:    time.Date(2021, 2, 1, 6, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Namespace:    "namespace",
+					EncryptedKey: "encrypted-key-3",
+					CreatedAt:    time.Date(2021, 2, 1, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			quotas: map[string]*dao.PasskeyQuotas{
+				"namespace": {CreationRateLimit: lo.ToPtr(1), CreationRateWindow: lo.ToPtr(24 * time.Hour)},
+			},
+
+			expectErr: dao.ErrQuotaExceeded,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			insertPasskeysDAO := dao.NewInsertPasskeys(transaction)
+
+			err := insertPasskeysDAO.Exec(
+				context.Background(),
+				time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC),
+				&dao.InsertPasskeysRequest{Passkeys: testCase.passkeys, Quotas: testCase.quotas},
+			)
+			if testCase.expectErr != nil {
+				require.ErrorIs(t, err, testCase.expectErr)
+
+				// The batch is inserted atomically.
+				exists, err := transaction.NewSelect().
+					Model((*entities.Passkey)(nil)).
+					Where("id = ?", uuid.MustParse("00000000-0000-0000-0000-000000000002")).
+					Exists(context.Background())
+				require.NoError(t, err)
+				require.False(t, exists)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, expected := range testCase.passkeys {
+				result := &entities.Passkey{ID: expected.ID, Namespace: expected.Namespace}
+				require.NoError(t, transaction.NewSelect().Model(result).WherePK().Scan(context.Background()))
+				require.Equal(t, expected, result)
+			}
+		})
+	}
+}
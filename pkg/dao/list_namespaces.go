@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type ListNamespacesRequest struct {
+	Limit  int
+	Offset int
+}
+
+type ListNamespaces interface {
+	Exec(ctx context.Context, request *ListNamespacesRequest) ([]*entities.Namespace, error)
+}
+
+type listNamespacesImpl struct {
+	database bun.IDB
+}
+
+func (dao *listNamespacesImpl) Exec(
+	ctx context.Context, request *ListNamespacesRequest,
+) ([]*entities.Namespace, error) {
+	var namespaces []*entities.Namespace
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Model(&namespaces).
+			Order("name").
+			Limit(request.Limit).
+			Offset(request.Offset).
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return namespaces, nil
+}
+
+func NewListNamespaces(database bun.IDB) ListNamespaces {
+	return &listNamespacesImpl{database: database}
+}
@@ -0,0 +1,109 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestListNamespaces(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Namespace{
+			Name:            "namespace-b",
+			NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Namespace{
+			Name:            "namespace-a",
+			NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			CreatedAt:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Namespace{
+			Name:            "namespace-c",
+			NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			CreatedAt:       time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ListNamespacesRequest
+
+		expect    []*entities.Namespace
+		expectErr error
+	}{
+		{
+			name: "List",
+
+			request: &dao.ListNamespacesRequest{Limit: 10},
+
+			expect: []*entities.Namespace{
+				{
+					Name:            "namespace-a",
+					NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+					CreatedAt:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Name:            "namespace-b",
+					NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+					CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Name:            "namespace-c",
+					NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+					CreatedAt:       time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "List/Paginated",
+
+			request: &dao.ListNamespacesRequest{Limit: 1, Offset: 1},
+
+			expect: []*entities.Namespace{
+				{
+					Name:            "namespace-b",
+					NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+					CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "List/OutOfRange",
+
+			request: &dao.ListNamespacesRequest{Limit: 10, Offset: 10},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listNamespacesDAO := dao.NewListNamespaces(transaction)
+
+			result, err := listNamespacesDAO.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if len(testCase.expect) == 0 {
+				require.Empty(t, result)
+			} else {
+				require.Equal(t, testCase.expect, result)
+			}
+		})
+	}
+}
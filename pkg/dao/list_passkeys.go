@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+// ListPasskeysCursor points to the last passkey of a previous page. Results are sorted by namespace, then ID, so
+// the cursor remains stable while new passkeys are inserted.
+type ListPasskeysCursor struct {
+	Namespace string
+	ID        uuid.UUID
+}
+
+type ListPasskeysRequest struct {
+	// Restrict results to a single namespace. Every namespace is returned when empty.
+	Namespace string
+	After     *ListPasskeysCursor
+	Limit     int
+	// IncludeInactive also returns the expired, redeemed and revoked passkeys.
+	IncludeInactive bool
+}
+
+type ListPasskeys interface {
+	Exec(ctx context.Context, request *ListPasskeysRequest) ([]*entities.Passkey, error)
+}
+
+type listPasskeysImpl struct {
+	database bun.IDB
+}
+
+func (dao *listPasskeysImpl) Exec(ctx context.Context, request *ListPasskeysRequest) ([]*entities.Passkey, error) {
+	var passkeys []*entities.Passkey
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewSelect().
+			Model(&passkeys).
+			Order("namespace", "id").
+			Limit(request.Limit)
+
+		if request.IncludeInactive {
+			query = query.ModelTableExpr("passkeys AS passkey")
+		}
+
+		if request.Namespace != "" {
+			query = query.Where("namespace = ?", request.Namespace)
+		}
+
+		if request.After != nil {
+			query = query.Where("(namespace, id) > (?, ?)", request.After.Namespace, request.After.ID)
+		}
+
+		if err := query.Scan(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return passkeys, nil
+}
+
+func NewListPasskeys(database bun.IDB) ListPasskeys {
+	return &listPasskeysImpl{database: database}
+}
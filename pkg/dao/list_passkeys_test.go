@@ -0,0 +1,132 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestListPasskeys(t *testing.T) {
+	passkey1 := &entities.Passkey{
+		ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Namespace:    "namespace-1",
+		EncryptedKey: "encrypted-key-1",
+		Reward:       map[string]interface{}{"key": "value"},
+		ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+		CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	passkey2 := &entities.Passkey{
+		ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+		Namespace:    "namespace-1",
+		EncryptedKey: "encrypted-key-2",
+		CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	passkey3 := &entities.Passkey{
+		ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+		Namespace:    "namespace-2",
+		EncryptedKey: "encrypted-key-3",
+		CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// Expired.
+	passkey4 := &entities.Passkey{
+		ID:           uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+		Namespace:    "namespace-1",
+		EncryptedKey: "encrypted-key-4",
+		ExpiresAt:    lo.ToPtr(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)),
+		CreatedAt:    time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	fixtures := []interface{}{passkey3, passkey1, passkey4, passkey2}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ListPasskeysRequest
+
+		expect []*entities.Passkey
+	}{
+		{
+			name: "List",
+
+			request: &dao.ListPasskeysRequest{Limit: 10},
+
+			expect: []*entities.Passkey{passkey1, passkey2, passkey3},
+		},
+		{
+			name: "List/Limit",
+
+			request: &dao.ListPasskeysRequest{Limit: 2},
+
+			expect: []*entities.Passkey{passkey1, passkey2},
+		},
+		{
+			name: "List/Namespace",
+
+			request: &dao.ListPasskeysRequest{Namespace: "namespace-2", Limit: 10},
+
+			expect: []*entities.Passkey{passkey3},
+		},
+		{
+			name: "List/After",
+
+			request: &dao.ListPasskeysRequest{
+				After: &dao.ListPasskeysCursor{Namespace: "namespace-1", ID: passkey2.ID},
+				Limit: 10,
+			},
+
+			expect: []*entities.Passkey{passkey3},
+		},
+		{
+			name: "List/IncludeInactive",
+
+			request: &dao.ListPasskeysRequest{Namespace: "namespace-1", Limit: 10, IncludeInactive: true},
+
+			expect: []*entities.Passkey{passkey1, passkey2, passkey4},
+		},
+		{
+			name: "List/Empty",
+
+			request: &dao.ListPasskeysRequest{Namespace: "namespace-3", Limit: 10},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(nil)
+	require.NoError(t, err)
+	defer closer()
+
+	require.NoError(t, anoveldb.FreezeTime(database, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	formatter := formatters.NewConsoleFormatter(loggers.NewSTDOut(), true)
+	require.NoError(t, anoveldb.Migrate(database, migrations.SQLMigrations, formatter))
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listPasskeysDAO := dao.NewListPasskeys(transaction)
+
+			result, err := listPasskeysDAO.Exec(context.Background(), testCase.request)
+
+			require.NoError(t, err)
+
+			if len(testCase.expect) == 0 {
+				require.Empty(t, result)
+			} else {
+				require.Equal(t, testCase.expect, result)
+			}
+		})
+	}
+}
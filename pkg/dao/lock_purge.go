@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// UnlockPurge releases the lock of the purge, and the connection it is held on.
+type UnlockPurge func(ctx context.Context) error
+
+// LockPurge acquires the lock that lets a single replica purge the table at a time. Calls that cannot acquire it
+// return ErrPurgeLocked immediately.
+//
+// The lock is held on a dedicated connection, for the whole run, until the returned function releases it. It is not
+// restricted to a tenant.
+type LockPurge interface {
+	Exec(ctx context.Context) (UnlockPurge, error)
+}
+
+type lockPurgeImpl struct {
+	database *bun.DB
+}
+
+// The two keys form of the lock does not overlap with the single key locks of CreatePasskey.
+const purgeLockKeys = "hashtext('passkeys'), hashtext('purge')"
+
+func (dao *lockPurgeImpl) Exec(ctx context.Context) (UnlockPurge, error) {
+	// Session locks are tied to the connection that acquired them, so it must not return to the pool until the lock
+	// is released.
+	conn, err := dao.database.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	var locked bool
+
+	if err = conn.NewRaw("SELECT pg_try_advisory_lock("+purgeLockKeys+")").Scan(ctx, &locked); err != nil {
+		discardConn(conn)
+
+		return nil, fmt.Errorf("acquire lock: %w", err)
+	}
+
+	if !locked {
+		_ = conn.Close()
+
+		return nil, ErrPurgeLocked
+	}
+
+	return func(ctx context.Context) error {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock("+purgeLockKeys+")"); err != nil {
+			// Closing the session is the only other way to release the lock.
+			discardConn(conn)
+
+			return fmt.Errorf("release lock: %w", err)
+		}
+
+		_ = conn.Close()
+
+		return nil
+	}, nil
+}
+
+// discardConn closes the underlying session of a connection, instead of returning it to the pool.
+func discardConn(conn bun.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+}
+
+func NewLockPurge(database *bun.DB) LockPurge {
+	return &lockPurgeImpl{database: database}
+}
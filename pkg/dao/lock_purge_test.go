@@ -0,0 +1,34 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+)
+
+func TestLockPurge(t *testing.T) {
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	lockPurgeDAO := dao.NewLockPurge(database)
+
+	unlock, err := lockPurgeDAO.Exec(context.Background())
+	require.NoError(t, err)
+
+	// The lock is held on its own connection, so it outlives any transaction of the run.
+	_, err = lockPurgeDAO.Exec(context.Background())
+	require.ErrorIs(t, err, dao.ErrPurgeLocked)
+
+	require.NoError(t, unlock(context.Background()))
+
+	unlock, err = lockPurgeDAO.Exec(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, unlock(context.Background()))
+}
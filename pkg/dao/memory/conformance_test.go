@@ -0,0 +1,28 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao/daotest"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/memory"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestPasskeysConformance(t *testing.T) {
+	daotest.RunPasskeys(t, func(t *testing.T, now time.Time, fixtures []*entities.Passkey) *daotest.PasskeyDAOs {
+		t.Helper()
+
+		store := memory.NewStore(func() time.Time { return now })
+		require.NoError(t, store.Insert(fixtures...))
+
+		return &daotest.PasskeyDAOs{
+			Create: memory.NewCreatePasskey(store),
+			Get:    memory.NewGetPasskey(store),
+			Update: memory.NewUpdatePasskey(store),
+			Delete: memory.NewDeletePasskey(store),
+		}
+	})
+}
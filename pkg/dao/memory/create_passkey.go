@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+type createPasskeyImpl struct {
+	store *Store
+}
+
+func (impl *createPasskeyImpl) Exec(
+	ctx context.Context, passkeyID uuid.UUID, now time.Time, request *dao.CreatePasskeyRequest,
+) (*entities.Passkey, error) {
+	encrypted, err := dao.GenerateHash(
+		ctx, request.Passkey, lo.CoalesceOrEmpty(request.HashParams, lib.DefaultGenerateParams),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt passkey: %w", err)
+	}
+
+	model := &entities.Passkey{
+		ID:           passkeyID,
+		Namespace:    request.Namespace,
+		Tenant:       dao.TenantFromContext(ctx),
+		EncryptedKey: encrypted,
+		Reward:       request.Reward,
+		SingleUse:    request.SingleUse,
+		ExpiresAt:    request.ExpiresAt,
+		CreatedAt:    now,
+		Version:      1,
+	}
+
+	impl.store.mu.Lock()
+	defer impl.store.mu.Unlock()
+
+	// Quotas are checked with the store locked, so concurrent creations cannot exceed them.
+	if err := impl.checkQuotas(ctx, now, request); err != nil {
+		return nil, err
+	}
+
+	if _, exists := impl.store.passkeys[keyOf(model)]; exists {
+		return nil, dao.ErrPasskeyAlreadyExists
+	}
+
+	impl.store.passkeys[keyOf(model)] = clonePasskey(model)
+
+	return model, nil
+}
+
+// checkQuotas counts the passkeys of the namespace in the tenant of ctx. The store must be locked.
+func (impl *createPasskeyImpl) checkQuotas(
+	ctx context.Context, now time.Time, request *dao.CreatePasskeyRequest,
+) error {
+	checkRate := request.CreationRateLimit != nil && request.CreationRateWindow != nil
+
+	if request.MaxActivePasskeys == nil && !checkRate {
+		return nil
+	}
+
+	var since time.Time
+	if checkRate {
+		since = now.Add(-*request.CreationRateWindow)
+	}
+
+	active, created := impl.countPasskeys(ctx, request.Namespace, since)
+
+	if request.MaxActivePasskeys != nil && active >= *request.MaxActivePasskeys {
+		return &dao.QuotaExceededError{
+			Namespace: request.Namespace,
+			Quota:     dao.QuotaActivePasskeys,
+			Limit:     *request.MaxActivePasskeys,
+		}
+	}
+
+	if checkRate && created >= *request.CreationRateLimit {
+		return &dao.QuotaExceededError{
+			Namespace: request.Namespace,
+			Quota:     dao.QuotaCreationRate,
+			Limit:     *request.CreationRateLimit,
+			Window:    *request.CreationRateWindow,
+		}
+	}
+
+	return nil
+}
+
+// countPasskeys returns the number of active passkeys in the namespace, and the number of passkeys created there after
+// since. Like in Postgres, passkeys that expired or were redeemed since count toward the latter. The store must be
+// locked.
+func (impl *createPasskeyImpl) countPasskeys(
+	ctx context.Context, namespace string, since time.Time,
+) (active int, created int) {
+	tenant := dao.TenantFromContext(ctx)
+
+	for _, passkey := range impl.store.passkeys {
+		if passkey.Namespace != namespace || passkey.Tenant != tenant {
+			continue
+		}
+
+		if impl.store.isActive(passkey) {
+			active++
+		}
+
+		if passkey.CreatedAt.After(since) {
+			created++
+		}
+	}
+
+	return active, created
+}
+
+func NewCreatePasskey(store *Store) dao.CreatePasskey {
+	return &createPasskeyImpl{store: store}
+}
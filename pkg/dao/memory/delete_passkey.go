@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type deletePasskeyImpl struct {
+	store *Store
+}
+
+func (impl *deletePasskeyImpl) Exec(ctx context.Context, request *dao.DeletePasskeyRequest) (*entities.Passkey, error) {
+	key := passkeyKey{id: request.ID, namespace: request.Namespace}
+
+	for {
+		// Expired, redeemed and revoked passkeys can be deleted as well.
+		passkey, _ := impl.store.snapshot(ctx, key)
+		if passkey == nil || (request.ExpectedVersion != nil && passkey.Version != *request.ExpectedVersion) {
+			return nil, checkVersionMismatch(passkey, request.ExpectedVersion)
+		}
+
+		if request.RawKey != nil {
+			match, err := dao.CompareHash(ctx, *request.RawKey, passkey.EncryptedKey)
+			if err != nil {
+				return nil, fmt.Errorf("compare passkey: %w", err)
+			}
+
+			if !match {
+				return nil, dao.ErrInvalidPasskey
+			}
+		}
+
+		if impl.store.remove(ctx, key, passkey.Version) {
+			return passkey, nil
+		}
+
+		// The passkey was modified while its secret was compared, so it must be checked again.
+	}
+}
+
+func NewDeletePasskey(store *Store) dao.DeletePasskey {
+	return &deletePasskeyImpl{store: store}
+}
@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+// getNamespaceImpl does not keep namespaces. Every namespace is reported as not registered, so passkeys use the
+// default policy.
+type getNamespaceImpl struct{}
+
+func (impl *getNamespaceImpl) Exec(_ context.Context, _ *dao.GetNamespaceRequest) (*entities.Namespace, error) {
+	return nil, dao.ErrNamespaceNotFound
+}
+
+func NewGetNamespace() dao.GetNamespace {
+	return &getNamespaceImpl{}
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+type getPasskeyImpl struct {
+	store *Store
+}
+
+func (impl *getPasskeyImpl) Exec(ctx context.Context, request *dao.GetPasskeyRequest) (*entities.Passkey, error) {
+	key := passkeyKey{id: request.ID, namespace: request.Namespace}
+
+	for {
+		passkey, err := impl.getActive(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if request.RawKey == nil {
+			return passkey, nil
+		}
+
+		match, err := dao.CompareHash(ctx, *request.RawKey, passkey.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("compare passkey: %w", err)
+		}
+
+		if !match {
+			return nil, dao.ErrInvalidPasskey
+		}
+
+		if !passkey.SingleUse {
+			return passkey, nil
+		}
+
+		redeemed := impl.store.commit(ctx, key, passkey.Version, func(passkey *entities.Passkey) {
+			passkey.RedeemedAt = lo.ToPtr(impl.store.clock())
+			passkey.RedeemedBy = request.RedeemedBy
+			passkey.Version++
+		})
+		if redeemed != nil {
+			metrics.Redemptions.WithLabelValues(metrics.NamespaceLabel(redeemed.Namespace)).Inc()
+
+			return redeemed, nil
+		}
+
+		// The passkey was modified while its secret was compared, and may have been redeemed by another request.
+	}
+}
+
+// getActive returns a copy of the passkey if it is active, and tells revoked passkeys apart from the ones that do not
+// exist otherwise.
+func (impl *getPasskeyImpl) getActive(ctx context.Context, key passkeyKey) (*entities.Passkey, error) {
+	impl.store.mu.Lock()
+	defer impl.store.mu.Unlock()
+
+	passkey := impl.store.find(ctx, key)
+
+	switch {
+	case passkey == nil:
+		return nil, dao.ErrPasskeyNotFound
+	case passkey.RevokedAt != nil:
+		return nil, dao.ErrPasskeyRevoked
+	case !impl.store.isActive(passkey):
+		return nil, dao.ErrPasskeyNotFound
+	}
+
+	return clonePasskey(passkey), nil
+}
+
+func NewGetPasskey(store *Store) dao.GetPasskey {
+	return &getPasskeyImpl{store: store}
+}
@@ -0,0 +1,181 @@
+// Package memory implements the passkey DAOs without a database, for tests and local development. It follows the
+// semantics of the Postgres DAOs, including tenant isolation, but everything is lost when the process exits.
+package memory
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+// passkeyKey identifies the passkey of a request, in the tenant of its context.
+type passkeyKey struct {
+	id        uuid.UUID
+	namespace string
+}
+
+// storeKey identifies a passkey in the store. Like in Postgres, IDs are unique within a tenant, across every
+// namespace.
+type storeKey struct {
+	tenant string
+	id     uuid.UUID
+}
+
+func keyOf(passkey *entities.Passkey) storeKey {
+	return storeKey{tenant: passkey.Tenant, id: passkey.ID}
+}
+
+// Store holds the passkeys shared by the DAOs of this package.
+//
+// Secrets are hashed and compared outside the lock, as those are slow. Writes then only apply if the passkey was not
+// modified in the meantime, otherwise the DAO reads it again. This gives the same guarantees as the row locks of the
+// Postgres DAOs, such as a single-use passkey being redeemed only once.
+type Store struct {
+	mu sync.Mutex
+
+	clock    func() time.Time
+	passkeys map[storeKey]*entities.Passkey
+	// history lists the previous secrets of each passkey, most recent first.
+	history map[storeKey][]string
+}
+
+// NewStore creates an empty store. The clock replaces the database time, to decide whether passkeys are expired and
+// to date redemptions.
+func NewStore(clock func() time.Time) *Store {
+	return &Store{
+		clock:    clock,
+		passkeys: make(map[storeKey]*entities.Passkey),
+		history:  make(map[storeKey][]string),
+	}
+}
+
+// Insert adds passkeys as they are, in the tenant they are set to. It can be used to seed the store. New passkeys
+// start at version 1 when none is set.
+func (store *Store) Insert(passkeys ...*entities.Passkey) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, passkey := range passkeys {
+		if _, exists := store.passkeys[keyOf(passkey)]; exists {
+			return dao.ErrPasskeyAlreadyExists
+		}
+
+		stored := clonePasskey(passkey)
+		if stored.Version == 0 {
+			stored.Version = 1
+		}
+
+		store.passkeys[keyOf(passkey)] = stored
+	}
+
+	return nil
+}
+
+// find returns the passkey if it belongs to the tenant of ctx, whether it is active or not. The store must be locked.
+func (store *Store) find(ctx context.Context, key passkeyKey) *entities.Passkey {
+	passkey, ok := store.passkeys[storeKey{tenant: dao.TenantFromContext(ctx), id: key.id}]
+	if !ok || passkey.Namespace != key.namespace {
+		return nil
+	}
+
+	return passkey
+}
+
+// isActive mirrors the active_passkeys view. The store must be locked.
+func (store *Store) isActive(passkey *entities.Passkey) bool {
+	if passkey.RedeemedAt != nil || passkey.RevokedAt != nil {
+		return false
+	}
+
+	return passkey.ExpiresAt == nil || !passkey.ExpiresAt.Before(store.clock())
+}
+
+// snapshot returns a copy of the passkey and of its previous secrets, or nil if the tenant of ctx has no such passkey.
+func (store *Store) snapshot(ctx context.Context, key passkeyKey) (*entities.Passkey, []string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	passkey := store.find(ctx, key)
+	if passkey == nil {
+		return nil, nil
+	}
+
+	return clonePasskey(passkey), append([]string(nil), store.history[keyOf(passkey)]...)
+}
+
+// commit runs change on the passkey, with the store locked, unless the passkey was modified or deleted since version.
+// It returns a copy of the changed passkey, or nil if the passkey must be read again.
+func (store *Store) commit(
+	ctx context.Context, key passkeyKey, version int64, change func(passkey *entities.Passkey),
+) *entities.Passkey {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	passkey := store.find(ctx, key)
+	if passkey == nil || passkey.Version != version {
+		return nil
+	}
+
+	change(passkey)
+
+	return clonePasskey(passkey)
+}
+
+// remove deletes the passkey and its history, unless the passkey was modified or deleted since version. It returns
+// false if the passkey must be read again.
+func (store *Store) remove(ctx context.Context, key passkeyKey, version int64) bool {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	passkey := store.find(ctx, key)
+	if passkey == nil || passkey.Version != version {
+		return false
+	}
+
+	delete(store.passkeys, keyOf(passkey))
+	delete(store.history, keyOf(passkey))
+
+	return true
+}
+
+// checkVersionMismatch explains why a write guarded by an expected version cannot apply, like its Postgres
+// counterpart.
+func checkVersionMismatch(passkey *entities.Passkey, expectedVersion *int64) error {
+	if expectedVersion == nil || passkey == nil {
+		return dao.ErrPasskeyNotFound
+	}
+
+	return dao.ErrVersionMismatch
+}
+
+// clonePasskey copies the passkey, so callers cannot alter the store through the values they get.
+func clonePasskey(passkey *entities.Passkey) *entities.Passkey {
+	clone := *passkey
+
+	clone.Reward = maps.Clone(passkey.Reward)
+	clone.RedeemedAt = clonePointer(passkey.RedeemedAt)
+	clone.RedeemedBy = clonePointer(passkey.RedeemedBy)
+	clone.RevokedAt = clonePointer(passkey.RevokedAt)
+	clone.RevokedBy = clonePointer(passkey.RevokedBy)
+	clone.RevocationReason = clonePointer(passkey.RevocationReason)
+	clone.ExpiresAt = clonePointer(passkey.ExpiresAt)
+	clone.UpdatedAt = clonePointer(passkey.UpdatedAt)
+
+	return &clone
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	clone := *value
+
+	return &clone
+}
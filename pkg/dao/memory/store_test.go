@@ -0,0 +1,137 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/memory"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+const concurrentRequests = 8
+
+// runConcurrently runs callback concurrently, and returns the number of calls that succeeded.
+func runConcurrently(callback func() error) int {
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+	)
+
+	for range concurrentRequests {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if callback() == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return int(succeeded.Load())
+}
+
+func newConcurrentStore(t *testing.T, singleUse bool) (*memory.Store, uuid.UUID) {
+	t.Helper()
+
+	encrypted, err := lib.GenerateFromPassword("password", lib.DefaultGenerateParams)
+	require.NoError(t, err)
+
+	passkeyID := uuid.New()
+
+	store := memory.NewStore(time.Now)
+	require.NoError(t, store.Insert(&entities.Passkey{
+		ID:           passkeyID,
+		Namespace:    "namespace",
+		EncryptedKey: encrypted,
+		SingleUse:    singleUse,
+		CreatedAt:    time.Now(),
+	}))
+
+	return store, passkeyID
+}
+
+func TestStoreConcurrency(t *testing.T) {
+	t.Run("Redeem", func(t *testing.T) {
+		store, passkeyID := newConcurrentStore(t, true)
+		getPasskeyDAO := memory.NewGetPasskey(store)
+
+		redeemed := runConcurrently(func() error {
+			_, err := getPasskeyDAO.Exec(context.Background(), &dao.GetPasskeyRequest{
+				ID: passkeyID, Namespace: "namespace", RawKey: lo.ToPtr("password"),
+			})
+
+			return err
+		})
+
+		require.Equal(t, 1, redeemed)
+	})
+
+	t.Run("ExpectedVersion", func(t *testing.T) {
+		store, passkeyID := newConcurrentStore(t, false)
+		updatePasskeyDAO := memory.NewUpdatePasskey(store)
+
+		updated := runConcurrently(func() error {
+			_, err := updatePasskeyDAO.Exec(context.Background(), passkeyID, time.Now(), &dao.UpdatePasskeyRequest{
+				Mask:            []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldPasskey},
+				Namespace:       "namespace",
+				Passkey:         "new-password",
+				CurrentKey:      lo.ToPtr("password"),
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			})
+
+			return err
+		})
+
+		require.Equal(t, 1, updated)
+	})
+
+	t.Run("MaxActivePasskeys", func(t *testing.T) {
+		store, _ := newConcurrentStore(t, false)
+		createPasskeyDAO := memory.NewCreatePasskey(store)
+
+		created := runConcurrently(func() error {
+			_, err := createPasskeyDAO.Exec(context.Background(), uuid.New(), time.Now(), &dao.CreatePasskeyRequest{
+				Namespace:         "namespace",
+				Passkey:           "password",
+				MaxActivePasskeys: lo.ToPtr(3),
+			})
+
+			return err
+		})
+
+		require.Equal(t, 2, created)
+	})
+}
+
+func TestStoreIsolation(t *testing.T) {
+	store, passkeyID := newConcurrentStore(t, false)
+
+	res, err := memory.NewGetPasskey(store).Exec(context.Background(), &dao.GetPasskeyRequest{
+		ID: passkeyID, Namespace: "namespace",
+	})
+	require.NoError(t, err)
+
+	// Changing the returned passkey does not change the stored one.
+	res.Reward = map[string]interface{}{"key": "value"}
+	res.ExpiresAt = lo.ToPtr(time.Now())
+
+	res, err = memory.NewGetPasskey(store).Exec(context.Background(), &dao.GetPasskeyRequest{
+		ID: passkeyID, Namespace: "namespace",
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Reward)
+	require.Nil(t, res.ExpiresAt)
+}
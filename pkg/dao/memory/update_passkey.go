@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var updatePasskeyAllFields = []dao.UpdatePasskeyField{
+	dao.UpdatePasskeyFieldPasskey,
+	dao.UpdatePasskeyFieldReward,
+	dao.UpdatePasskeyFieldExpiresAt,
+}
+
+type updatePasskeyImpl struct {
+	store *Store
+}
+
+func (impl *updatePasskeyImpl) Exec(
+	ctx context.Context, passkeyID uuid.UUID, now time.Time, request *dao.UpdatePasskeyRequest,
+) (*entities.Passkey, error) {
+	mask := lo.Uniq(lo.Ternary(len(request.Mask) == 0, updatePasskeyAllFields, request.Mask))
+	updateSecret := lo.Contains(mask, dao.UpdatePasskeyFieldPasskey)
+
+	encrypted, err := encryptSecret(ctx, updateSecret, request)
+	if err != nil {
+		return nil, err
+	}
+
+	key := passkeyKey{id: passkeyID, namespace: request.Namespace}
+
+	for {
+		passkey, history := impl.store.snapshot(ctx, key)
+
+		// Revoked passkeys cannot be updated, but expired ones can.
+		if passkey != nil && passkey.RevokedAt != nil {
+			return nil, dao.ErrPasskeyRevoked
+		}
+
+		if err := impl.checkSecrets(ctx, passkey, history, updateSecret, request); err != nil {
+			return nil, err
+		}
+
+		if passkey == nil || (request.ExpectedVersion != nil && passkey.Version != *request.ExpectedVersion) {
+			return nil, checkVersionMismatch(passkey, request.ExpectedVersion)
+		}
+
+		updated := impl.store.commit(ctx, key, passkey.Version, func(passkey *entities.Passkey) {
+			impl.apply(passkey, mask, encrypted, now, request)
+		})
+		if updated != nil {
+			return updated, nil
+		}
+
+		// The passkey was modified while its secrets were compared, so they must be checked again.
+	}
+}
+
+// checkSecrets verifies the current secret of the passkey, and that the new secret was not used recently, when the
+// request requires it.
+func (impl *updatePasskeyImpl) checkSecrets(
+	ctx context.Context, passkey *entities.Passkey, history []string, updateSecret bool, request *dao.UpdatePasskeyRequest,
+) error {
+	rotate := updateSecret && request.HistorySize > 0
+	if request.CurrentKey == nil && !rotate {
+		return nil
+	}
+
+	if passkey == nil {
+		return dao.ErrPasskeyNotFound
+	}
+
+	if request.CurrentKey != nil {
+		match, err := dao.CompareHash(ctx, *request.CurrentKey, passkey.EncryptedKey)
+		if err != nil {
+			return fmt.Errorf("compare current secret: %w", err)
+		}
+
+		if !match {
+			return dao.ErrInvalidPasskey
+		}
+	}
+
+	if !rotate {
+		return nil
+	}
+
+	return checkHistory(ctx, passkey, history, request)
+}
+
+// encryptSecret hashes the new secret of the passkey, if the request updates it.
+func encryptSecret(ctx context.Context, updateSecret bool, request *dao.UpdatePasskeyRequest) (string, error) {
+	if !updateSecret {
+		return "", nil
+	}
+
+	encrypted, err := dao.GenerateHash(
+		ctx, request.Passkey, lo.CoalesceOrEmpty(request.HashParams, lib.DefaultGenerateParams),
+	)
+	if err != nil {
+		return "", fmt.Errorf("encrypt passkey: %w", err)
+	}
+
+	return encrypted, nil
+}
+
+// checkHistory rejects the new secret if it matches the current one, or any of the HistorySize previous ones.
+func checkHistory(
+	ctx context.Context, passkey *entities.Passkey, history []string, request *dao.UpdatePasskeyRequest,
+) error {
+	previous := append([]string{passkey.EncryptedKey}, lo.Subset(history, 0, uint(request.HistorySize))...)
+
+	for _, secret := range previous {
+		match, err := dao.CompareHash(ctx, request.Passkey, secret)
+		if err != nil {
+			return fmt.Errorf("compare previous secret: %w", err)
+		}
+
+		if match {
+			return dao.ErrSecretReused
+		}
+	}
+
+	return nil
+}
+
+// apply writes the masked fields to the passkey. When the secret changes, the current one is moved to the history,
+// which is trimmed to HistorySize entries. The store must be locked.
+func (impl *updatePasskeyImpl) apply(
+	passkey *entities.Passkey,
+	mask []dao.UpdatePasskeyField,
+	encrypted string,
+	now time.Time,
+	request *dao.UpdatePasskeyRequest,
+) {
+	for _, field := range mask {
+		switch field {
+		case dao.UpdatePasskeyFieldPasskey:
+			if request.HistorySize > 0 {
+				impl.store.history[keyOf(passkey)] = lo.Subset(
+					append([]string{passkey.EncryptedKey}, impl.store.history[keyOf(passkey)]...),
+					0, uint(request.HistorySize),
+				)
+			}
+
+			passkey.EncryptedKey = encrypted
+		case dao.UpdatePasskeyFieldReward:
+			passkey.Reward = maps.Clone(request.Reward)
+		case dao.UpdatePasskeyFieldExpiresAt:
+			passkey.ExpiresAt = request.ExpiresAt
+		}
+	}
+
+	passkey.UpdatedAt = &now
+	passkey.Version++
+}
+
+func NewUpdatePasskey(store *Store) dao.UpdatePasskey {
+	return &updatePasskeyImpl{store: store}
+}
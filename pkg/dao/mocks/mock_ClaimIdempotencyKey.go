@@ -0,0 +1,101 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	entities "github.com/a-novel/uservice-passkeys/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockClaimIdempotencyKey is an autogenerated mock type for the ClaimIdempotencyKey type
+type MockClaimIdempotencyKey struct {
+	mock.Mock
+}
+
+type MockClaimIdempotencyKey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClaimIdempotencyKey) EXPECT() *MockClaimIdempotencyKey_Expecter {
+	return &MockClaimIdempotencyKey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now, request
+func (_m *MockClaimIdempotencyKey) Exec(ctx context.Context, now time.Time, request *dao.ClaimIdempotencyKeyRequest) (*entities.IdempotencyKey, error) {
+	ret := _m.Called(ctx, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.ClaimIdempotencyKeyRequest) (*entities.IdempotencyKey, error)); ok {
+		return rf(ctx, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.ClaimIdempotencyKeyRequest) *entities.IdempotencyKey); ok {
+		r0 = rf(ctx, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *dao.ClaimIdempotencyKeyRequest) error); ok {
+		r1 = rf(ctx, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClaimIdempotencyKey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockClaimIdempotencyKey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - request *dao.ClaimIdempotencyKeyRequest
+func (_e *MockClaimIdempotencyKey_Expecter) Exec(ctx interface{}, now interface{}, request interface{}) *MockClaimIdempotencyKey_Exec_Call {
+	return &MockClaimIdempotencyKey_Exec_Call{Call: _e.mock.On("Exec", ctx, now, request)}
+}
+
+func (_c *MockClaimIdempotencyKey_Exec_Call) Run(run func(ctx context.Context, now time.Time, request *dao.ClaimIdempotencyKeyRequest)) *MockClaimIdempotencyKey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*dao.ClaimIdempotencyKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockClaimIdempotencyKey_Exec_Call) Return(_a0 *entities.IdempotencyKey, _a1 error) *MockClaimIdempotencyKey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClaimIdempotencyKey_Exec_Call) RunAndReturn(run func(context.Context, time.Time, *dao.ClaimIdempotencyKeyRequest) (*entities.IdempotencyKey, error)) *MockClaimIdempotencyKey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClaimIdempotencyKey creates a new instance of MockClaimIdempotencyKey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClaimIdempotencyKey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClaimIdempotencyKey {
+	mock := &MockClaimIdempotencyKey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCompleteIdempotencyKey is an autogenerated mock type for the CompleteIdempotencyKey type
+type MockCompleteIdempotencyKey struct {
+	mock.Mock
+}
+
+type MockCompleteIdempotencyKey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCompleteIdempotencyKey) EXPECT() *MockCompleteIdempotencyKey_Expecter {
+	return &MockCompleteIdempotencyKey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockCompleteIdempotencyKey) Exec(ctx context.Context, request *dao.CompleteIdempotencyKeyRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.CompleteIdempotencyKeyRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCompleteIdempotencyKey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockCompleteIdempotencyKey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.CompleteIdempotencyKeyRequest
+func (_e *MockCompleteIdempotencyKey_Expecter) Exec(ctx interface{}, request interface{}) *MockCompleteIdempotencyKey_Exec_Call {
+	return &MockCompleteIdempotencyKey_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockCompleteIdempotencyKey_Exec_Call) Run(run func(ctx context.Context, request *dao.CompleteIdempotencyKeyRequest)) *MockCompleteIdempotencyKey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.CompleteIdempotencyKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockCompleteIdempotencyKey_Exec_Call) Return(_a0 error) *MockCompleteIdempotencyKey_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCompleteIdempotencyKey_Exec_Call) RunAndReturn(run func(context.Context, *dao.CompleteIdempotencyKeyRequest) error) *MockCompleteIdempotencyKey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCompleteIdempotencyKey creates a new instance of MockCompleteIdempotencyKey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCompleteIdempotencyKey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCompleteIdempotencyKey {
+	mock := &MockCompleteIdempotencyKey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	entities "github.com/a-novel/uservice-passkeys/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetNamespace is an autogenerated mock type for the GetNamespace type
+type MockGetNamespace struct {
+	mock.Mock
+}
+
+type MockGetNamespace_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetNamespace) EXPECT() *MockGetNamespace_Expecter {
+	return &MockGetNamespace_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockGetNamespace) Exec(ctx context.Context, request *dao.GetNamespaceRequest) (*entities.Namespace, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.GetNamespaceRequest) (*entities.Namespace, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.GetNamespaceRequest) *entities.Namespace); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.GetNamespaceRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetNamespace_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockGetNamespace_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.GetNamespaceRequest
+func (_e *MockGetNamespace_Expecter) Exec(ctx interface{}, request interface{}) *MockGetNamespace_Exec_Call {
+	return &MockGetNamespace_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockGetNamespace_Exec_Call) Run(run func(ctx context.Context, request *dao.GetNamespaceRequest)) *MockGetNamespace_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.GetNamespaceRequest))
+	})
+	return _c
+}
+
+func (_c *MockGetNamespace_Exec_Call) Return(_a0 *entities.Namespace, _a1 error) *MockGetNamespace_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetNamespace_Exec_Call) RunAndReturn(run func(context.Context, *dao.GetNamespaceRequest) (*entities.Namespace, error)) *MockGetNamespace_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetNamespace creates a new instance of MockGetNamespace. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetNamespace(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetNamespace {
+	mock := &MockGetNamespace{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockInsertPasskeys is an autogenerated mock type for the InsertPasskeys type
+type MockInsertPasskeys struct {
+	mock.Mock
+}
+
+type MockInsertPasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInsertPasskeys) EXPECT() *MockInsertPasskeys_Expecter {
+	return &MockInsertPasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now, request
+func (_m *MockInsertPasskeys) Exec(ctx context.Context, now time.Time, request *dao.InsertPasskeysRequest) error {
+	ret := _m.Called(ctx, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.InsertPasskeysRequest) error); ok {
+		r0 = rf(ctx, now, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInsertPasskeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockInsertPasskeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - request *dao.InsertPasskeysRequest
+func (_e *MockInsertPasskeys_Expecter) Exec(ctx interface{}, now interface{}, request interface{}) *MockInsertPasskeys_Exec_Call {
+	return &MockInsertPasskeys_Exec_Call{Call: _e.mock.On("Exec", ctx, now, request)}
+}
+
+func (_c *MockInsertPasskeys_Exec_Call) Run(run func(ctx context.Context, now time.Time, request *dao.InsertPasskeysRequest)) *MockInsertPasskeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*dao.InsertPasskeysRequest))
+	})
+	return _c
+}
+
+func (_c *MockInsertPasskeys_Exec_Call) Return(_a0 error) *MockInsertPasskeys_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInsertPasskeys_Exec_Call) RunAndReturn(run func(context.Context, time.Time, *dao.InsertPasskeysRequest) error) *MockInsertPasskeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInsertPasskeys creates a new instance of MockInsertPasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInsertPasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInsertPasskeys {
+	mock := &MockInsertPasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
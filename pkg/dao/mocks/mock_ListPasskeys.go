@@ -0,0 +1,98 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	entities "github.com/a-novel/uservice-passkeys/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListPasskeys is an autogenerated mock type for the ListPasskeys type
+type MockListPasskeys struct {
+	mock.Mock
+}
+
+type MockListPasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListPasskeys) EXPECT() *MockListPasskeys_Expecter {
+	return &MockListPasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockListPasskeys) Exec(ctx context.Context, request *dao.ListPasskeysRequest) ([]*entities.Passkey, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 []*entities.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.ListPasskeysRequest) ([]*entities.Passkey, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.ListPasskeysRequest) []*entities.Passkey); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.ListPasskeysRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListPasskeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockListPasskeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.ListPasskeysRequest
+func (_e *MockListPasskeys_Expecter) Exec(ctx interface{}, request interface{}) *MockListPasskeys_Exec_Call {
+	return &MockListPasskeys_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockListPasskeys_Exec_Call) Run(run func(ctx context.Context, request *dao.ListPasskeysRequest)) *MockListPasskeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.ListPasskeysRequest))
+	})
+	return _c
+}
+
+func (_c *MockListPasskeys_Exec_Call) Return(_a0 []*entities.Passkey, _a1 error) *MockListPasskeys_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListPasskeys_Exec_Call) RunAndReturn(run func(context.Context, *dao.ListPasskeysRequest) ([]*entities.Passkey, error)) *MockListPasskeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListPasskeys creates a new instance of MockListPasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListPasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListPasskeys {
+	mock := &MockListPasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
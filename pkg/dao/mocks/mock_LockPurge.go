@@ -0,0 +1,95 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLockPurge is an autogenerated mock type for the LockPurge type
+type MockLockPurge struct {
+	mock.Mock
+}
+
+type MockLockPurge_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLockPurge) EXPECT() *MockLockPurge_Expecter {
+	return &MockLockPurge_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx
+func (_m *MockLockPurge) Exec(ctx context.Context) (dao.UnlockPurge, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 dao.UnlockPurge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (dao.UnlockPurge, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) dao.UnlockPurge); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dao.UnlockPurge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLockPurge_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockLockPurge_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLockPurge_Expecter) Exec(ctx interface{}) *MockLockPurge_Exec_Call {
+	return &MockLockPurge_Exec_Call{Call: _e.mock.On("Exec", ctx)}
+}
+
+func (_c *MockLockPurge_Exec_Call) Run(run func(ctx context.Context)) *MockLockPurge_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockLockPurge_Exec_Call) Return(_a0 dao.UnlockPurge, _a1 error) *MockLockPurge_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLockPurge_Exec_Call) RunAndReturn(run func(context.Context) (dao.UnlockPurge, error)) *MockLockPurge_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLockPurge creates a new instance of MockLockPurge. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLockPurge(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLockPurge {
+	mock := &MockLockPurge{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPurgeIdempotencyKeys is an autogenerated mock type for the PurgeIdempotencyKeys type
+type MockPurgeIdempotencyKeys struct {
+	mock.Mock
+}
+
+type MockPurgeIdempotencyKeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPurgeIdempotencyKeys) EXPECT() *MockPurgeIdempotencyKeys_Expecter {
+	return &MockPurgeIdempotencyKeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockPurgeIdempotencyKeys) Exec(ctx context.Context, request *dao.PurgeIdempotencyKeysRequest) (int, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PurgeIdempotencyKeysRequest) (int, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PurgeIdempotencyKeysRequest) int); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.PurgeIdempotencyKeysRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPurgeIdempotencyKeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockPurgeIdempotencyKeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.PurgeIdempotencyKeysRequest
+func (_e *MockPurgeIdempotencyKeys_Expecter) Exec(ctx interface{}, request interface{}) *MockPurgeIdempotencyKeys_Exec_Call {
+	return &MockPurgeIdempotencyKeys_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockPurgeIdempotencyKeys_Exec_Call) Run(run func(ctx context.Context, request *dao.PurgeIdempotencyKeysRequest)) *MockPurgeIdempotencyKeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.PurgeIdempotencyKeysRequest))
+	})
+	return _c
+}
+
+func (_c *MockPurgeIdempotencyKeys_Exec_Call) Return(_a0 int, _a1 error) *MockPurgeIdempotencyKeys_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPurgeIdempotencyKeys_Exec_Call) RunAndReturn(run func(context.Context, *dao.PurgeIdempotencyKeysRequest) (int, error)) *MockPurgeIdempotencyKeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPurgeIdempotencyKeys creates a new instance of MockPurgeIdempotencyKeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPurgeIdempotencyKeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPurgeIdempotencyKeys {
+	mock := &MockPurgeIdempotencyKeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
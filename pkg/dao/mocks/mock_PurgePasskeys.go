@@ -0,0 +1,94 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPurgePasskeys is an autogenerated mock type for the PurgePasskeys type
+type MockPurgePasskeys struct {
+	mock.Mock
+}
+
+type MockPurgePasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPurgePasskeys) EXPECT() *MockPurgePasskeys_Expecter {
+	return &MockPurgePasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockPurgePasskeys) Exec(ctx context.Context, request *dao.PurgePasskeysRequest) (int, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PurgePasskeysRequest) (int, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PurgePasskeysRequest) int); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.PurgePasskeysRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPurgePasskeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockPurgePasskeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.PurgePasskeysRequest
+func (_e *MockPurgePasskeys_Expecter) Exec(ctx interface{}, request interface{}) *MockPurgePasskeys_Exec_Call {
+	return &MockPurgePasskeys_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockPurgePasskeys_Exec_Call) Run(run func(ctx context.Context, request *dao.PurgePasskeysRequest)) *MockPurgePasskeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.PurgePasskeysRequest))
+	})
+	return _c
+}
+
+func (_c *MockPurgePasskeys_Exec_Call) Return(_a0 int, _a1 error) *MockPurgePasskeys_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPurgePasskeys_Exec_Call) RunAndReturn(run func(context.Context, *dao.PurgePasskeysRequest) (int, error)) *MockPurgePasskeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPurgePasskeys creates a new instance of MockPurgePasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPurgePasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPurgePasskeys {
+	mock := &MockPurgePasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReleaseIdempotencyKey is an autogenerated mock type for the ReleaseIdempotencyKey type
+type MockReleaseIdempotencyKey struct {
+	mock.Mock
+}
+
+type MockReleaseIdempotencyKey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReleaseIdempotencyKey) EXPECT() *MockReleaseIdempotencyKey_Expecter {
+	return &MockReleaseIdempotencyKey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockReleaseIdempotencyKey) Exec(ctx context.Context, request *dao.ReleaseIdempotencyKeyRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.ReleaseIdempotencyKeyRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReleaseIdempotencyKey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockReleaseIdempotencyKey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.ReleaseIdempotencyKeyRequest
+func (_e *MockReleaseIdempotencyKey_Expecter) Exec(ctx interface{}, request interface{}) *MockReleaseIdempotencyKey_Exec_Call {
+	return &MockReleaseIdempotencyKey_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockReleaseIdempotencyKey_Exec_Call) Run(run func(ctx context.Context, request *dao.ReleaseIdempotencyKeyRequest)) *MockReleaseIdempotencyKey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.ReleaseIdempotencyKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockReleaseIdempotencyKey_Exec_Call) Return(_a0 error) *MockReleaseIdempotencyKey_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReleaseIdempotencyKey_Exec_Call) RunAndReturn(run func(context.Context, *dao.ReleaseIdempotencyKeyRequest) error) *MockReleaseIdempotencyKey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReleaseIdempotencyKey creates a new instance of MockReleaseIdempotencyKey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReleaseIdempotencyKey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReleaseIdempotencyKey {
+	mock := &MockReleaseIdempotencyKey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
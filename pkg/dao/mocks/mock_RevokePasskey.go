@@ -0,0 +1,101 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	entities "github.com/a-novel/uservice-passkeys/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockRevokePasskey is an autogenerated mock type for the RevokePasskey type
+type MockRevokePasskey struct {
+	mock.Mock
+}
+
+type MockRevokePasskey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevokePasskey) EXPECT() *MockRevokePasskey_Expecter {
+	return &MockRevokePasskey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now, request
+func (_m *MockRevokePasskey) Exec(ctx context.Context, now time.Time, request *dao.RevokePasskeyRequest) (*entities.Passkey, error) {
+	ret := _m.Called(ctx, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.RevokePasskeyRequest) (*entities.Passkey, error)); ok {
+		return rf(ctx, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.RevokePasskeyRequest) *entities.Passkey); ok {
+		r0 = rf(ctx, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *dao.RevokePasskeyRequest) error); ok {
+		r1 = rf(ctx, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRevokePasskey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRevokePasskey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - request *dao.RevokePasskeyRequest
+func (_e *MockRevokePasskey_Expecter) Exec(ctx interface{}, now interface{}, request interface{}) *MockRevokePasskey_Exec_Call {
+	return &MockRevokePasskey_Exec_Call{Call: _e.mock.On("Exec", ctx, now, request)}
+}
+
+func (_c *MockRevokePasskey_Exec_Call) Run(run func(ctx context.Context, now time.Time, request *dao.RevokePasskeyRequest)) *MockRevokePasskey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*dao.RevokePasskeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockRevokePasskey_Exec_Call) Return(_a0 *entities.Passkey, _a1 error) *MockRevokePasskey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRevokePasskey_Exec_Call) RunAndReturn(run func(context.Context, time.Time, *dao.RevokePasskeyRequest) (*entities.Passkey, error)) *MockRevokePasskey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevokePasskey creates a new instance of MockRevokePasskey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevokePasskey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevokePasskey {
+	mock := &MockRevokePasskey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
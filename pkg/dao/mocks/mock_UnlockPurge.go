@@ -0,0 +1,82 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUnlockPurge is an autogenerated mock type for the UnlockPurge type
+type MockUnlockPurge struct {
+	mock.Mock
+}
+
+type MockUnlockPurge_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUnlockPurge) EXPECT() *MockUnlockPurge_Expecter {
+	return &MockUnlockPurge_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx
+func (_m *MockUnlockPurge) Execute(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUnlockPurge_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockUnlockPurge_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUnlockPurge_Expecter) Execute(ctx interface{}) *MockUnlockPurge_Execute_Call {
+	return &MockUnlockPurge_Execute_Call{Call: _e.mock.On("Execute", ctx)}
+}
+
+func (_c *MockUnlockPurge_Execute_Call) Run(run func(ctx context.Context)) *MockUnlockPurge_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUnlockPurge_Execute_Call) Return(_a0 error) *MockUnlockPurge_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUnlockPurge_Execute_Call) RunAndReturn(run func(context.Context) error) *MockUnlockPurge_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUnlockPurge creates a new instance of MockUnlockPurge. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUnlockPurge(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUnlockPurge {
+	mock := &MockUnlockPurge{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-passkeys/pkg/dao"
+	entities "github.com/a-novel/uservice-passkeys/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockUpdateNamespace is an autogenerated mock type for the UpdateNamespace type
+type MockUpdateNamespace struct {
+	mock.Mock
+}
+
+type MockUpdateNamespace_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUpdateNamespace) EXPECT() *MockUpdateNamespace_Expecter {
+	return &MockUpdateNamespace_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now, request
+func (_m *MockUpdateNamespace) Exec(ctx context.Context, now time.Time, request *dao.UpdateNamespaceRequest) (*entities.Namespace, error) {
+	ret := _m.Called(ctx, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.UpdateNamespaceRequest) (*entities.Namespace, error)); ok {
+		return rf(ctx, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.UpdateNamespaceRequest) *entities.Namespace); ok {
+		r0 = rf(ctx, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *dao.UpdateNamespaceRequest) error); ok {
+		r1 = rf(ctx, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdateNamespace_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockUpdateNamespace_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - request *dao.UpdateNamespaceRequest
+func (_e *MockUpdateNamespace_Expecter) Exec(ctx interface{}, now interface{}, request interface{}) *MockUpdateNamespace_Exec_Call {
+	return &MockUpdateNamespace_Exec_Call{Call: _e.mock.On("Exec", ctx, now, request)}
+}
+
+func (_c *MockUpdateNamespace_Exec_Call) Run(run func(ctx context.Context, now time.Time, request *dao.UpdateNamespaceRequest)) *MockUpdateNamespace_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*dao.UpdateNamespaceRequest))
+	})
+	return _c
+}
+
+func (_c *MockUpdateNamespace_Exec_Call) Return(_a0 *entities.Namespace, _a1 error) *MockUpdateNamespace_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdateNamespace_Exec_Call) RunAndReturn(run func(context.Context, time.Time, *dao.UpdateNamespaceRequest) (*entities.Namespace, error)) *MockUpdateNamespace_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUpdateNamespace creates a new instance of MockUpdateNamespace. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUpdateNamespace(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUpdateNamespace {
+	mock := &MockUpdateNamespace{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,115 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+// PasskeyQuotas limits the passkeys of a namespace. No limit applies to empty values.
+type PasskeyQuotas struct {
+	// MaxActivePasskeys caps the number of active passkeys in the namespace.
+	MaxActivePasskeys *int
+	// CreationRateLimit caps the number of passkeys created in the namespace over the last CreationRateWindow. No
+	// limit applies when either is empty.
+	CreationRateLimit  *int
+	CreationRateWindow *time.Duration
+}
+
+// checkQuotas rejects the pending passkeys of a namespace, if inserting them would exceed its quotas. It must run in
+// the transaction that inserts them. Concurrent creations in the same namespace are serialized through an advisory
+// lock, so counts cannot go stale before the insert commits.
+func checkQuotas(
+	ctx context.Context, tx bun.Tx, namespace string, quotas *PasskeyQuotas, now time.Time, pending []*entities.Passkey,
+) error {
+	checkRate := quotas.CreationRateLimit != nil && quotas.CreationRateWindow != nil
+
+	if quotas.MaxActivePasskeys == nil && !checkRate {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", namespace); err != nil {
+		return fmt.Errorf("lock namespace: %w", err)
+	}
+
+	if quotas.MaxActivePasskeys != nil {
+		if err := checkActivePasskeys(ctx, tx, namespace, *quotas.MaxActivePasskeys, now, pending); err != nil {
+			return err
+		}
+	}
+
+	if checkRate {
+		err := checkCreationRate(
+			ctx, tx, namespace, *quotas.CreationRateLimit, *quotas.CreationRateWindow, now, pending,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkActivePasskeys(
+	ctx context.Context, tx bun.Tx, namespace string, limit int, now time.Time, pending []*entities.Passkey,
+) error {
+	count, err := tx.NewSelect().
+		Model((*entities.Passkey)(nil)).
+		Where("namespace = ?", namespace).
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("count active passkeys: %w", err)
+	}
+
+	for _, passkey := range pending {
+		if passkey.RedeemedAt == nil && passkey.RevokedAt == nil &&
+			(passkey.ExpiresAt == nil || !passkey.ExpiresAt.Before(now)) {
+			count++
+		}
+	}
+
+	if count > limit {
+		return &QuotaExceededError{Namespace: namespace, Quota: QuotaActivePasskeys, Limit: limit}
+	}
+
+	return nil
+}
+
+// checkCreationRate counts every passkey created over the window, including the ones that expired or were redeemed
+// since.
+func checkCreationRate(
+	ctx context.Context,
+	tx bun.Tx,
+	namespace string,
+	limit int,
+	window time.Duration,
+	now time.Time,
+	pending []*entities.Passkey,
+) error {
+	since := now.Add(-window)
+
+	count, err := tx.NewSelect().
+		Table("passkeys").
+		Where("namespace = ?", namespace).
+		Where("created_at > ?", since).
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("count created passkeys: %w", err)
+	}
+
+	for _, passkey := range pending {
+		if passkey.CreatedAt.After(since) {
+			count++
+		}
+	}
+
+	if count > limit {
+		return &QuotaExceededError{Namespace: namespace, Quota: QuotaCreationRate, Limit: limit, Window: window}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// checkVersionMismatch explains why a write did not affect any row. When the write was guarded by an expected
+// version, the passkey may exist, but have been modified since that version. Revoking a passkey also bumps its
+// version.
+func checkVersionMismatch(
+	ctx context.Context, tx bun.IDB, passkeyID uuid.UUID, namespace string, expectedVersion *int64,
+) error {
+	if expectedVersion == nil {
+		return ErrPasskeyNotFound
+	}
+
+	exists, err := tx.NewSelect().
+		Table("passkeys").
+		Where("id = ?", passkeyID).
+		Where("namespace = ?", namespace).
+		Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("check version: %w", err)
+	}
+
+	if exists {
+		return ErrVersionMismatch
+	}
+
+	return ErrPasskeyNotFound
+}
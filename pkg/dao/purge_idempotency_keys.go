@@ -0,0 +1,47 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type PurgeIdempotencyKeysRequest struct {
+	// Before removes the keys that expired before this date.
+	Before time.Time
+}
+
+// PurgeIdempotencyKeys deletes expired idempotency keys, and returns the number of deleted rows. Like PurgePasskeys,
+// it applies to all tenants.
+type PurgeIdempotencyKeys interface {
+	Exec(ctx context.Context, request *PurgeIdempotencyKeysRequest) (int, error)
+}
+
+type purgeIdempotencyKeysImpl struct {
+	database bun.IDB
+}
+
+func (dao *purgeIdempotencyKeysImpl) Exec(ctx context.Context, request *PurgeIdempotencyKeysRequest) (int, error) {
+	res, err := dao.database.NewDelete().
+		Model((*entities.IdempotencyKey)(nil)).
+		Where("expires_at < ?", request.Before).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("exec query: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return int(deleted), nil
+}
+
+func NewPurgeIdempotencyKeys(database bun.IDB) PurgeIdempotencyKeys {
+	return &purgeIdempotencyKeysImpl{database: database}
+}
@@ -0,0 +1,59 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestPurgeIdempotencyKeys(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.IdempotencyKey{
+			Key:         "active",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.IdempotencyKey{
+			Key:         "expired",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			Response:    []byte("response"),
+			CreatedAt:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	purgeIdempotencyKeysDAO := dao.NewPurgeIdempotencyKeys(transaction)
+
+	deleted, err := purgeIdempotencyKeysDAO.Exec(context.Background(), &dao.PurgeIdempotencyKeysRequest{
+		Before: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+
+	var remaining []string
+
+	err = transaction.NewSelect().
+		Table("idempotency_keys").
+		Column("key").
+		Scan(context.Background(), &remaining)
+	require.NoError(t, err)
+	require.Equal(t, []string{"active"}, remaining)
+}
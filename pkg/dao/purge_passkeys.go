@@ -0,0 +1,59 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type PurgePasskeysRequest struct {
+	// Before removes passkeys that expired or were revoked before this date.
+	Before    time.Time
+	BatchSize int
+}
+
+// PurgePasskeys deletes a single batch of inactive passkeys, and returns the number of deleted rows. It is not
+// restricted to a tenant, and purges all of them at once.
+//
+// Callers should hold the lock of LockPurge, so only one replica purges the table at a time.
+type PurgePasskeys interface {
+	Exec(ctx context.Context, request *PurgePasskeysRequest) (int, error)
+}
+
+type purgePasskeysImpl struct {
+	database bun.IDB
+}
+
+func (dao *purgePasskeysImpl) Exec(ctx context.Context, request *PurgePasskeysRequest) (int, error) {
+	batch := dao.database.NewSelect().
+		Table("passkeys").
+		Column("tenant", "id", "namespace").
+		WhereOr("expires_at < ?", request.Before).
+		WhereOr("revoked_at < ?", request.Before).
+		Limit(request.BatchSize).
+		For("UPDATE SKIP LOCKED")
+
+	res, err := dao.database.NewDelete().
+		Model((*entities.Passkey)(nil)).
+		// IDs are only unique within a tenant.
+		Where("(tenant, id, namespace) IN (?)", batch).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("exec query: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return int(deleted), nil
+}
+
+func NewPurgePasskeys(database bun.IDB) PurgePasskeys {
+	return &purgePasskeysImpl{database: database}
+}
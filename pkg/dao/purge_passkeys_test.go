@@ -0,0 +1,144 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestPurgePasskeys(t *testing.T) {
+	fixtures := []interface{}{
+		// Active.
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Namespace:    "namespace",
+			EncryptedKey: "encrypted",
+			CreatedAt:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Expired within the retention period.
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Namespace:    "namespace",
+			EncryptedKey: "encrypted",
+			ExpiresAt:    lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Expired.
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Namespace:    "namespace",
+			EncryptedKey: "encrypted",
+			ExpiresAt:    lo.ToPtr(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Revoked.
+		&entities.Passkey{
+			ID:               uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			Namespace:        "namespace",
+			EncryptedKey:     "encrypted",
+			RevokedAt:        lo.ToPtr(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+			RevokedBy:        lo.ToPtr("admin"),
+			RevocationReason: lo.ToPtr("leaked"),
+			CreatedAt:        time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Active, with the ID of an expired passkey of another tenant.
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Namespace:    "namespace",
+			Tenant:       "other-tenant",
+			EncryptedKey: "encrypted",
+			CreatedAt:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.PurgePasskeysRequest
+
+		expect          int
+		expectRemaining []uuid.UUID
+	}{
+		{
+			name: "Purge",
+
+			request: &dao.PurgePasskeysRequest{
+				Before:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				BatchSize: 10,
+			},
+
+			expect: 2,
+			expectRemaining: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+		},
+		{
+			name: "Purge/Batch",
+
+			request: &dao.PurgePasskeysRequest{
+				Before:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				BatchSize: 1,
+			},
+
+			expect: 1,
+		},
+		{
+			name: "Purge/Nothing",
+
+			request: &dao.PurgePasskeysRequest{
+				Before:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				BatchSize: 10,
+			},
+
+			expect: 0,
+			expectRemaining: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			purgePasskeysDAO := dao.NewPurgePasskeys(transaction)
+
+			deleted, err := purgePasskeysDAO.Exec(context.Background(), testCase.request)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expect, deleted)
+
+			if testCase.expectRemaining != nil {
+				var remaining []uuid.UUID
+
+				err = transaction.NewSelect().
+					Table("passkeys").
+					Column("id").
+					Order("id ASC").
+					Scan(context.Background(), &remaining)
+				require.NoError(t, err)
+				require.Equal(t, testCase.expectRemaining, remaining)
+			}
+		})
+	}
+}
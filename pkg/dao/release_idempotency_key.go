@@ -0,0 +1,54 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type ReleaseIdempotencyKeyRequest struct {
+	Key     string
+	Service string
+}
+
+// ReleaseIdempotencyKey frees an idempotency key whose request failed, so it can be retried with the same key.
+type ReleaseIdempotencyKey interface {
+	Exec(ctx context.Context, request *ReleaseIdempotencyKeyRequest) error
+}
+
+type releaseIdempotencyKeyImpl struct {
+	database bun.IDB
+}
+
+func (dao *releaseIdempotencyKeyImpl) Exec(ctx context.Context, request *ReleaseIdempotencyKeyRequest) error {
+	model := &entities.IdempotencyKey{
+		Key:     request.Key,
+		Service: request.Service,
+	}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model(model).
+			WherePK().
+			// Never drop the result of a completed request.
+			Where("response IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return nil
+}
+
+func NewReleaseIdempotencyKey(database bun.IDB) ReleaseIdempotencyKey {
+	return &releaseIdempotencyKeyImpl{database: database}
+}
@@ -0,0 +1,84 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestReleaseIdempotencyKey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.IdempotencyKey{
+			Key:         "completed",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			Response:    []byte("response"),
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.IdempotencyKey{
+			Key:         "pending",
+			Service:     "service",
+			Fingerprint: "fingerprint",
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiresAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ReleaseIdempotencyKeyRequest
+
+		expectRemaining []string
+	}{
+		{
+			name: "Release",
+
+			request: &dao.ReleaseIdempotencyKeyRequest{Key: "pending", Service: "service"},
+
+			expectRemaining: []string{"completed"},
+		},
+		{
+			name: "Release/Completed",
+
+			request: &dao.ReleaseIdempotencyKeyRequest{Key: "completed", Service: "service"},
+
+			expectRemaining: []string{"completed", "pending"},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			releaseIdempotencyKeyDAO := dao.NewReleaseIdempotencyKey(transaction)
+
+			err := releaseIdempotencyKeyDAO.Exec(context.Background(), testCase.request)
+			require.NoError(t, err)
+
+			var remaining []string
+
+			err = transaction.NewSelect().
+				Table("idempotency_keys").
+				Column("key").
+				Order("key ASC").
+				Scan(context.Background(), &remaining)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectRemaining, remaining)
+		})
+	}
+}
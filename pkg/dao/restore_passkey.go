@@ -0,0 +1,89 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type RestorePasskeyRequest struct {
+	ID        uuid.UUID
+	Namespace string
+	// RevokedAfter is the end of the retention window. Passkeys revoked before this date cannot be restored.
+	RevokedAfter time.Time
+}
+
+type RestorePasskey interface {
+	Exec(ctx context.Context, now time.Time, request *RestorePasskeyRequest) (*entities.Passkey, error)
+}
+
+type restorePasskeyImpl struct {
+	database bun.IDB
+}
+
+func (dao *restorePasskeyImpl) Exec(
+	ctx context.Context, now time.Time, request *RestorePasskeyRequest,
+) (*entities.Passkey, error) {
+	model := &entities.Passkey{
+		ID:        request.ID,
+		Namespace: request.Namespace,
+	}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		// Revoked passkeys are hidden from the active view, so read the table directly.
+		err := tx.NewSelect().
+			Model(model).
+			ModelTableExpr("passkeys AS passkey").
+			WherePK().
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrPasskeyNotFound
+			}
+
+			return fmt.Errorf("get passkey: %w", err)
+		}
+
+		if model.RevokedAt == nil {
+			return ErrPasskeyNotRevoked
+		}
+
+		if model.RevokedAt.Before(request.RevokedAfter) {
+			return ErrRestoreWindowExpired
+		}
+
+		model.RevokedAt = nil
+		model.RevokedBy = nil
+		model.RevocationReason = nil
+		model.UpdatedAt = &now
+		model.Version++
+
+		_, err = tx.NewUpdate().
+			Model(model).
+			Column("revoked_at", "revoked_by", "revocation_reason", "updated_at", "version").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("restore passkey: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+func NewRestorePasskey(database bun.IDB) RestorePasskey {
+	return &restorePasskeyImpl{database: database}
+}
@@ -0,0 +1,129 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestRestorePasskey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Passkey{
+			ID:               uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Namespace:        "namespace",
+			EncryptedKey:     "encrypted",
+			Reward:           map[string]interface{}{"key": "value"},
+			RevokedAt:        lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			RevokedBy:        lo.ToPtr("admin"),
+			RevocationReason: lo.ToPtr("leaked"),
+			CreatedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Not revoked.
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Namespace:    "namespace",
+			EncryptedKey: "encrypted",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now     time.Time
+		request *dao.RestorePasskeyRequest
+
+		expect    *entities.Passkey
+		expectErr error
+	}{
+		{
+			name: "Restore",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RestorePasskeyRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:    "namespace",
+				RevokedAfter: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &entities.Passkey{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:    "namespace",
+				EncryptedKey: "encrypted",
+				Reward:       map[string]interface{}{"key": "value"},
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:      2,
+			},
+		},
+		{
+			name: "RestoreWindowExpired",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RestorePasskeyRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:    "namespace",
+				RevokedAfter: time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrRestoreWindowExpired,
+		},
+		{
+			name: "NotRevoked",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RestorePasskeyRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace:    "namespace",
+				RevokedAfter: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrPasskeyNotRevoked,
+		},
+		{
+			name: "NotFound",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RestorePasskeyRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Namespace:    "namespace",
+				RevokedAfter: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			restorePasskeyDAO := dao.NewRestorePasskey(transaction)
+
+			result, err := restorePasskeyDAO.Exec(context.Background(), testCase.now, testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expect == nil {
+				require.Nil(t, result)
+			} else {
+				require.Equal(t, testCase.expect, result)
+			}
+		})
+	}
+}
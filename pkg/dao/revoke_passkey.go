@@ -0,0 +1,74 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type RevokePasskeyRequest struct {
+	ID        uuid.UUID
+	Namespace string
+	RevokedBy string
+	Reason    string
+}
+
+type RevokePasskey interface {
+	Exec(ctx context.Context, now time.Time, request *RevokePasskeyRequest) (*entities.Passkey, error)
+}
+
+type revokePasskeyImpl struct {
+	database bun.IDB
+}
+
+func (dao *revokePasskeyImpl) Exec(
+	ctx context.Context, now time.Time, request *RevokePasskeyRequest,
+) (*entities.Passkey, error) {
+	model := &entities.Passkey{
+		ID:               request.ID,
+		Namespace:        request.Namespace,
+		RevokedAt:        &now,
+		RevokedBy:        &request.RevokedBy,
+		RevocationReason: &request.Reason,
+	}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		rows, err := tx.NewUpdate().
+			Model(model).
+			Column("revoked_at", "revoked_by", "revocation_reason", "version").
+			Value("version", "version + 1").
+			WherePK().
+			// Revoking twice would overwrite the original reason.
+			Where("revoked_at IS NULL").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		affected, err := rows.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if affected == 0 {
+			return ErrPasskeyNotFound
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+func NewRevokePasskey(database bun.IDB) RevokePasskey {
+	return &revokePasskeyImpl{database: database}
+}
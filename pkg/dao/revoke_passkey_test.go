@@ -0,0 +1,117 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestRevokePasskey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Namespace:    "namespace",
+			EncryptedKey: "encrypted",
+			Reward:       map[string]interface{}{"key": "value"},
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Already revoked.
+		&entities.Passkey{
+			ID:               uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Namespace:        "namespace",
+			EncryptedKey:     "encrypted",
+			RevokedAt:        lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			RevokedBy:        lo.ToPtr("admin"),
+			RevocationReason: lo.ToPtr("leaked"),
+			CreatedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now     time.Time
+		request *dao.RevokePasskeyRequest
+
+		expect    *entities.Passkey
+		expectErr error
+	}{
+		{
+			name: "Revoke",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RevokePasskeyRequest{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "compromised",
+			},
+
+			expect: &entities.Passkey{
+				ID:               uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:        "namespace",
+				EncryptedKey:     "encrypted",
+				Reward:           map[string]interface{}{"key": "value"},
+				RevokedAt:        lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				RevokedBy:        lo.ToPtr("admin"),
+				RevocationReason: lo.ToPtr("compromised"),
+				CreatedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:          2,
+			},
+		},
+		{
+			name: "AlreadyRevoked",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RevokePasskeyRequest{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "compromised",
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "NotFound",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RevokePasskeyRequest{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "compromised",
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			revokePasskeyDAO := dao.NewRevokePasskey(transaction)
+
+			result, err := revokePasskeyDAO.Exec(context.Background(), testCase.now, testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, result)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package sqlite_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/sqlite"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+const concurrentRequests = 8
+
+// runConcurrently runs callback concurrently, and returns the number of calls that succeeded.
+func runConcurrently(callback func() error) int {
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+	)
+
+	for range concurrentRequests {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if callback() == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return int(succeeded.Load())
+}
+
+func newConcurrentDB(t *testing.T, singleUse bool) (*bun.DB, uuid.UUID) {
+	t.Helper()
+
+	encrypted, err := lib.GenerateFromPassword("password", lib.DefaultGenerateParams)
+	require.NoError(t, err)
+
+	passkeyID := uuid.New()
+
+	database := openTestDB(t)
+
+	_, err = database.NewInsert().Model(&entities.Passkey{
+		ID:           passkeyID,
+		Namespace:    "namespace",
+		EncryptedKey: encrypted,
+		SingleUse:    singleUse,
+		CreatedAt:    time.Now(),
+	}).Exec(context.Background())
+	require.NoError(t, err)
+
+	return database, passkeyID
+}
+
+func TestConcurrency(t *testing.T) {
+	t.Run("Redeem", func(t *testing.T) {
+		database, passkeyID := newConcurrentDB(t, true)
+		getPasskeyDAO := sqlite.NewGetPasskey(database, time.Now)
+
+		redeemed := runConcurrently(func() error {
+			_, err := getPasskeyDAO.Exec(context.Background(), &dao.GetPasskeyRequest{
+				ID: passkeyID, Namespace: "namespace", RawKey: lo.ToPtr("password"),
+			})
+
+			return err
+		})
+
+		require.Equal(t, 1, redeemed)
+	})
+
+	t.Run("ExpectedVersion", func(t *testing.T) {
+		database, passkeyID := newConcurrentDB(t, false)
+		updatePasskeyDAO := sqlite.NewUpdatePasskey(database)
+
+		updated := runConcurrently(func() error {
+			_, err := updatePasskeyDAO.Exec(context.Background(), passkeyID, time.Now(), &dao.UpdatePasskeyRequest{
+				Mask:            []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldPasskey},
+				Namespace:       "namespace",
+				Passkey:         "new-password",
+				CurrentKey:      lo.ToPtr("password"),
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			})
+
+			return err
+		})
+
+		require.Equal(t, 1, updated)
+	})
+
+	t.Run("MaxActivePasskeys", func(t *testing.T) {
+		database, _ := newConcurrentDB(t, false)
+		createPasskeyDAO := sqlite.NewCreatePasskey(database)
+
+		created := runConcurrently(func() error {
+			_, err := createPasskeyDAO.Exec(context.Background(), uuid.New(), time.Now(), &dao.CreatePasskeyRequest{
+				Namespace:         "namespace",
+				Passkey:           "password",
+				MaxActivePasskeys: lo.ToPtr(3),
+			})
+
+			return err
+		})
+
+		require.Equal(t, 2, created)
+	})
+}
+
+func TestActivePasskeysView(t *testing.T) {
+	database := openTestDB(t)
+	now := time.Now()
+
+	passkeys := []*entities.Passkey{
+		{ID: uuid.New(), EncryptedKey: "active"},
+		{ID: uuid.New(), EncryptedKey: "active", ExpiresAt: lo.ToPtr(now.Add(time.Hour))},
+		{ID: uuid.New(), EncryptedKey: "expired", ExpiresAt: lo.ToPtr(now.Add(-time.Second))},
+		{ID: uuid.New(), EncryptedKey: "redeemed", RedeemedAt: &now},
+		{ID: uuid.New(), EncryptedKey: "revoked", RevokedAt: &now},
+	}
+
+	for _, passkey := range passkeys {
+		passkey.Namespace = "namespace"
+		passkey.CreatedAt = now
+	}
+
+	_, err := database.NewInsert().Model(&passkeys).Exec(context.Background())
+	require.NoError(t, err)
+
+	var active []string
+
+	// The model reads from the view.
+	err = database.NewSelect().
+		Model((*entities.Passkey)(nil)).
+		Column("encrypted_key").
+		Scan(context.Background(), &active)
+	require.NoError(t, err)
+	require.Equal(t, []string{"active", "active"}, active)
+}
@@ -0,0 +1,51 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	anoveldb "github.com/a-novel/golib/database"
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	sqlitemigrations "github.com/a-novel/uservice-passkeys/migrations/sqlite"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/daotest"
+	"github.com/a-novel/uservice-passkeys/pkg/dao/sqlite"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+// openTestDB returns a migrated in-memory database, closed when the test ends.
+func openTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+
+	database, closer, err := sqlite.Open(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(closer)
+
+	formatter := formatters.NewConsoleFormatter(loggers.NewSTDOut(), true)
+	require.NoError(t, anoveldb.Migrate(database, sqlitemigrations.SQLMigrations, formatter))
+
+	return database
+}
+
+func TestPasskeysConformance(t *testing.T) {
+	daotest.RunPasskeys(t, func(t *testing.T, now time.Time, fixtures []*entities.Passkey) *daotest.PasskeyDAOs {
+		t.Helper()
+
+		database := openTestDB(t)
+
+		_, err := database.NewInsert().Model(&fixtures).Exec(context.Background())
+		require.NoError(t, err)
+
+		return &daotest.PasskeyDAOs{
+			Create: sqlite.NewCreatePasskey(database),
+			Get:    sqlite.NewGetPasskey(database, func() time.Time { return now }),
+			Update: sqlite.NewUpdatePasskey(database),
+			Delete: sqlite.NewDeletePasskey(database),
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+type createPasskeyImpl struct {
+	database bun.IDB
+}
+
+func (impl *createPasskeyImpl) Exec(
+	ctx context.Context, passkeyID uuid.UUID, now time.Time, request *dao.CreatePasskeyRequest,
+) (*entities.Passkey, error) {
+	encrypted, err := dao.GenerateHash(
+		ctx, request.Passkey, lo.CoalesceOrEmpty(request.HashParams, lib.DefaultGenerateParams),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt passkey: %w", err)
+	}
+
+	model := &entities.Passkey{
+		ID:           passkeyID,
+		Namespace:    request.Namespace,
+		Tenant:       dao.TenantFromContext(ctx),
+		EncryptedKey: encrypted,
+		Reward:       request.Reward,
+		SingleUse:    request.SingleUse,
+		ExpiresAt:    request.ExpiresAt,
+		CreatedAt:    now,
+	}
+
+	// Transactions hold the write lock of the database, so concurrent creations cannot exceed the quotas.
+	txErr := impl.database.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := impl.checkQuotas(ctx, tx, now, request); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+			if isUniqueViolation(err) {
+				return dao.ErrPasskeyAlreadyExists
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+// checkQuotas counts the passkeys of the namespace in the tenant of ctx. It must run in the transaction that inserts
+// the passkey.
+func (impl *createPasskeyImpl) checkQuotas(
+	ctx context.Context, tx bun.Tx, now time.Time, request *dao.CreatePasskeyRequest,
+) error {
+	if request.MaxActivePasskeys != nil {
+		if err := impl.checkActivePasskeys(ctx, tx, now, request); err != nil {
+			return err
+		}
+	}
+
+	if request.CreationRateLimit != nil && request.CreationRateWindow != nil {
+		if err := impl.checkCreationRate(ctx, tx, now, request); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkActivePasskeys applies the conditions of the active_passkeys view at now.
+func (impl *createPasskeyImpl) checkActivePasskeys(
+	ctx context.Context, tx bun.Tx, now time.Time, request *dao.CreatePasskeyRequest,
+) error {
+	count, err := tx.NewSelect().
+		Table("passkeys").
+		Where("namespace = ?", request.Namespace).
+		Where("tenant = ?", dao.TenantFromContext(ctx)).
+		Where("expires_at IS NULL OR julianday(expires_at) >= julianday(?)", now).
+		Where("redeemed_at IS NULL").
+		Where("revoked_at IS NULL").
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("count active passkeys: %w", err)
+	}
+
+	if count >= *request.MaxActivePasskeys {
+		return &dao.QuotaExceededError{
+			Namespace: request.Namespace,
+			Quota:     dao.QuotaActivePasskeys,
+			Limit:     *request.MaxActivePasskeys,
+		}
+	}
+
+	return nil
+}
+
+// checkCreationRate counts every passkey created over the window, including the ones that expired or were redeemed
+// since.
+func (impl *createPasskeyImpl) checkCreationRate(
+	ctx context.Context, tx bun.Tx, now time.Time, request *dao.CreatePasskeyRequest,
+) error {
+	count, err := tx.NewSelect().
+		Table("passkeys").
+		Where("namespace = ?", request.Namespace).
+		Where("tenant = ?", dao.TenantFromContext(ctx)).
+		Where("julianday(created_at) > julianday(?)", now.Add(-*request.CreationRateWindow)).
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("count created passkeys: %w", err)
+	}
+
+	if count >= *request.CreationRateLimit {
+		return &dao.QuotaExceededError{
+			Namespace: request.Namespace,
+			Quota:     dao.QuotaCreationRate,
+			Limit:     *request.CreationRateLimit,
+			Window:    *request.CreationRateWindow,
+		}
+	}
+
+	return nil
+}
+
+func NewCreatePasskey(database bun.IDB) dao.CreatePasskey {
+	return &createPasskeyImpl{database: database}
+}
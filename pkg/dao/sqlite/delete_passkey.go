@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type deletePasskeyImpl struct {
+	database bun.IDB
+}
+
+func (impl *deletePasskeyImpl) Exec(ctx context.Context, request *dao.DeletePasskeyRequest) (*entities.Passkey, error) {
+	for {
+		passkey, err := impl.getDeletable(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		deleted, err := impl.remove(ctx, passkey)
+		if err != nil {
+			return nil, err
+		}
+
+		if deleted {
+			return passkey, nil
+		}
+
+		// The passkey was modified while its secret was compared, so it must be checked again.
+	}
+}
+
+// getDeletable returns the passkey, once its secret was checked. Expired, redeemed and revoked passkeys can be deleted
+// as well.
+func (impl *deletePasskeyImpl) getDeletable(
+	ctx context.Context, request *dao.DeletePasskeyRequest,
+) (*entities.Passkey, error) {
+	passkey, err := selectPasskey(ctx, impl.database, request.ID, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if passkey == nil || (request.ExpectedVersion != nil && passkey.Version != *request.ExpectedVersion) {
+		return nil, checkVersionMismatch(passkey, request.ExpectedVersion)
+	}
+
+	if request.RawKey != nil {
+		match, err := dao.CompareHash(ctx, *request.RawKey, passkey.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("compare passkey: %w", err)
+		}
+
+		if !match {
+			return nil, dao.ErrInvalidPasskey
+		}
+	}
+
+	return passkey, nil
+}
+
+// remove deletes the passkey, unless it was modified since it was read. Its history is deleted by the foreign key.
+func (impl *deletePasskeyImpl) remove(ctx context.Context, passkey *entities.Passkey) (bool, error) {
+	result, err := impl.database.NewDelete().
+		Model(passkey).
+		WherePK().
+		Where("tenant = ?", passkey.Tenant).
+		Where("version = ?", passkey.Version).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("exec query: %w", err)
+	}
+
+	return applied(result)
+}
+
+func NewDeletePasskey(database bun.IDB) dao.DeletePasskey {
+	return &deletePasskeyImpl{database: database}
+}
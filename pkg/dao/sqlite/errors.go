@@ -0,0 +1,15 @@
+package sqlite
+
+import (
+	"errors"
+
+	sqlitedriver "modernc.org/sqlite"
+	sqlitecodes "modernc.org/sqlite/lib"
+)
+
+// https://www.sqlite.org/rescode.html#constraint_primarykey
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlitedriver.Error
+
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlitecodes.SQLITE_CONSTRAINT_PRIMARYKEY
+}
@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+type getPasskeyImpl struct {
+	database bun.IDB
+	clock    func() time.Time
+}
+
+func (impl *getPasskeyImpl) Exec(ctx context.Context, request *dao.GetPasskeyRequest) (*entities.Passkey, error) {
+	for {
+		passkey, err := impl.getActive(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		if request.RawKey == nil {
+			return passkey, nil
+		}
+
+		match, err := dao.CompareHash(ctx, *request.RawKey, passkey.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("compare passkey: %w", err)
+		}
+
+		if !match {
+			return nil, dao.ErrInvalidPasskey
+		}
+
+		if !passkey.SingleUse {
+			return passkey, nil
+		}
+
+		redeemed, err := impl.redeem(ctx, passkey, request)
+		if err != nil {
+			return nil, err
+		}
+
+		if redeemed {
+			metrics.Redemptions.WithLabelValues(metrics.NamespaceLabel(passkey.Namespace)).Inc()
+
+			return passkey, nil
+		}
+
+		// The passkey was modified while its secret was compared, and may have been redeemed by another request.
+	}
+}
+
+// getActive returns the passkey if it is active, and tells revoked passkeys apart from the ones that do not exist
+// otherwise.
+func (impl *getPasskeyImpl) getActive(ctx context.Context, request *dao.GetPasskeyRequest) (*entities.Passkey, error) {
+	passkey, err := selectPasskey(ctx, impl.database, request.ID, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case passkey == nil:
+		return nil, dao.ErrPasskeyNotFound
+	case passkey.RevokedAt != nil:
+		return nil, dao.ErrPasskeyRevoked
+	case !isActive(passkey, impl.clock()):
+		return nil, dao.ErrPasskeyNotFound
+	}
+
+	return passkey, nil
+}
+
+// redeem marks the passkey as redeemed, unless it was modified since it was read.
+func (impl *getPasskeyImpl) redeem(
+	ctx context.Context, passkey *entities.Passkey, request *dao.GetPasskeyRequest,
+) (bool, error) {
+	result, err := impl.database.NewUpdate().
+		Model(passkey).
+		WherePK().
+		Where("tenant = ?", passkey.Tenant).
+		Where("version = ?", passkey.Version).
+		Set("redeemed_at = ?", impl.clock()).
+		Set("redeemed_by = ?", request.RedeemedBy).
+		Set("version = version + 1").
+		Returning("redeemed_at, redeemed_by, version").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("redeem passkey: %w", err)
+	}
+
+	return applied(result)
+}
+
+// NewGetPasskey returns a DAO that decides whether passkeys are expired, and dates redemptions, with the clock.
+func NewGetPasskey(database bun.IDB, clock func() time.Time) dao.GetPasskey {
+	return &getPasskeyImpl{database: database, clock: clock}
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+// Secrets are hashed and compared outside transactions, as those are slow and SQLite runs one write at a time. Writes
+// then only apply if the version of the passkey did not change in the meantime, otherwise the DAO reads it again. This
+// gives the same guarantees as the row locks of the Postgres DAOs, such as a single-use passkey being redeemed only
+// once.
+
+// selectPasskey returns the passkey if it belongs to the tenant of ctx, whether it is active or not. It returns nil
+// if there is no such passkey.
+func selectPasskey(
+	ctx context.Context, database bun.IDB, passkeyID uuid.UUID, namespace string,
+) (*entities.Passkey, error) {
+	passkey := new(entities.Passkey)
+
+	err := database.NewSelect().
+		Model(passkey).
+		// The model reads from the active_passkeys view by default.
+		ModelTableExpr("passkeys AS passkey").
+		Where("id = ?", passkeyID).
+		Where("namespace = ?", namespace).
+		Where("tenant = ?", dao.TenantFromContext(ctx)).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get passkey: %w", err)
+	}
+
+	return passkey, nil
+}
+
+// isActive mirrors the active_passkeys view, with the time of the service rather than the one of the database.
+func isActive(passkey *entities.Passkey, now time.Time) bool {
+	if passkey.RedeemedAt != nil || passkey.RevokedAt != nil {
+		return false
+	}
+
+	return passkey.ExpiresAt == nil || !passkey.ExpiresAt.Before(now)
+}
+
+// applied tells whether a write guarded by the version of the passkey found it. If not, the passkey must be read again.
+func applied(result sql.Result) (bool, error) {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// checkVersionMismatch explains why a write guarded by an expected version cannot apply, like its Postgres
+// counterpart.
+func checkVersionMismatch(passkey *entities.Passkey, expectedVersion *int64) error {
+	if expectedVersion == nil || passkey == nil {
+		return dao.ErrPasskeyNotFound
+	}
+
+	return dao.ErrVersionMismatch
+}
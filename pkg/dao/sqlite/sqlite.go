@@ -0,0 +1,66 @@
+// Package sqlite implements the passkey DAOs over SQLite, for small deployments and edge tools that do not run
+// Postgres. It follows the semantics of the Postgres DAOs. SQLite has no row-level security, so every query filters
+// on the tenant of the context instead.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	_ "modernc.org/sqlite" // Registers the "sqlite" driver, which does not need cgo.
+)
+
+// connectionParams enforce foreign keys, so the history of a passkey is deleted with it. Transactions take the write
+// lock when they start, so quotas cannot be exceeded by concurrent processes, which wait for it instead of failing.
+const connectionParams = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate"
+
+// timeFormat is the format of the date functions of SQLite, with the precision of Postgres. Times are stored in UTC,
+// so they sort as text.
+const timeFormat = "2006-01-02 15:04:05.000000"
+
+// dialect stores times without an offset, which the driver reads back in UTC. The default format has one, and times
+// would be read in the local time zone instead.
+type dialect struct {
+	*sqlitedialect.Dialect
+}
+
+func (dialect) AppendTime(b []byte, tm time.Time) []byte {
+	b = append(b, '\'')
+	b = tm.UTC().AppendFormat(b, timeFormat)
+
+	return append(b, '\'')
+}
+
+// Open opens the SQLite database at path, creating the file if needed. Use ":memory:" for a database that is lost
+// when it is closed. The returned function closes the database.
+func Open(path string) (*bun.DB, func(), error) {
+	separator := lo.Ternary(strings.Contains(path, "?"), "&", "?")
+
+	sqldb, err := sql.Open("sqlite", path+separator+connectionParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open database: %w", err)
+	}
+
+	// SQLite runs one write at a time, and every connection to an in-memory database opens a different one.
+	sqldb.SetMaxOpenConns(1)
+
+	database := bun.NewDB(sqldb, dialect{Dialect: sqlitedialect.New()})
+
+	// Errors are ignored, because they are not relevant anymore when the process shuts down.
+	closer := func() {
+		_ = database.Close()
+	}
+
+	if err := database.Ping(); err != nil {
+		closer()
+
+		return nil, nil, fmt.Errorf("ping database: %w", err)
+	}
+
+	return database, closer, nil
+}
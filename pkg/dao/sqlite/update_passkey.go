@@ -0,0 +1,264 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var updatePasskeyAllFields = []dao.UpdatePasskeyField{
+	dao.UpdatePasskeyFieldPasskey,
+	dao.UpdatePasskeyFieldReward,
+	dao.UpdatePasskeyFieldExpiresAt,
+}
+
+var updatePasskeyFieldColumns = map[dao.UpdatePasskeyField]string{
+	dao.UpdatePasskeyFieldPasskey:   "encrypted_key",
+	dao.UpdatePasskeyFieldReward:    "reward",
+	dao.UpdatePasskeyFieldExpiresAt: "expires_at",
+}
+
+type updatePasskeyImpl struct {
+	database bun.IDB
+}
+
+func (impl *updatePasskeyImpl) Exec(
+	ctx context.Context, passkeyID uuid.UUID, now time.Time, request *dao.UpdatePasskeyRequest,
+) (*entities.Passkey, error) {
+	mask := lo.Uniq(lo.Ternary(len(request.Mask) == 0, updatePasskeyAllFields, request.Mask))
+	updateSecret := lo.Contains(mask, dao.UpdatePasskeyFieldPasskey)
+
+	encrypted, err := encryptSecret(ctx, updateSecret, request)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		passkey, err := impl.getUpdatable(ctx, passkeyID, updateSecret, request)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := impl.apply(ctx, passkey, mask, encrypted, now, request)
+		if err != nil {
+			return nil, err
+		}
+
+		if updated != nil {
+			return updated, nil
+		}
+
+		// The passkey was modified while its secrets were compared, so they must be checked again.
+	}
+}
+
+// getUpdatable returns the passkey, once its secrets were checked. Revoked passkeys cannot be updated, but expired
+// ones can.
+func (impl *updatePasskeyImpl) getUpdatable(
+	ctx context.Context, passkeyID uuid.UUID, updateSecret bool, request *dao.UpdatePasskeyRequest,
+) (*entities.Passkey, error) {
+	passkey, err := selectPasskey(ctx, impl.database, passkeyID, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if passkey != nil && passkey.RevokedAt != nil {
+		return nil, dao.ErrPasskeyRevoked
+	}
+
+	if err := impl.checkSecrets(ctx, passkey, updateSecret, request); err != nil {
+		return nil, err
+	}
+
+	if passkey == nil || (request.ExpectedVersion != nil && passkey.Version != *request.ExpectedVersion) {
+		return nil, checkVersionMismatch(passkey, request.ExpectedVersion)
+	}
+
+	return passkey, nil
+}
+
+// checkSecrets verifies the current secret of the passkey, and that the new secret was not used recently, when the
+// request requires it.
+func (impl *updatePasskeyImpl) checkSecrets(
+	ctx context.Context, passkey *entities.Passkey, updateSecret bool, request *dao.UpdatePasskeyRequest,
+) error {
+	rotate := updateSecret && request.HistorySize > 0
+	if request.CurrentKey == nil && !rotate {
+		return nil
+	}
+
+	if passkey == nil {
+		return dao.ErrPasskeyNotFound
+	}
+
+	if request.CurrentKey != nil {
+		match, err := dao.CompareHash(ctx, *request.CurrentKey, passkey.EncryptedKey)
+		if err != nil {
+			return fmt.Errorf("compare current secret: %w", err)
+		}
+
+		if !match {
+			return dao.ErrInvalidPasskey
+		}
+	}
+
+	if !rotate {
+		return nil
+	}
+
+	return impl.checkHistory(ctx, passkey, request)
+}
+
+// checkHistory rejects the new secret if it matches the current one, or any of the HistorySize previous ones.
+func (impl *updatePasskeyImpl) checkHistory(
+	ctx context.Context, passkey *entities.Passkey, request *dao.UpdatePasskeyRequest,
+) error {
+	var history []string
+
+	err := impl.database.NewSelect().
+		Model((*entities.PasskeyHistory)(nil)).
+		Column("encrypted_key").
+		Where("passkey_id = ?", passkey.ID).
+		Where("tenant = ?", passkey.Tenant).
+		Order("created_at DESC", "id DESC").
+		Limit(request.HistorySize).
+		Scan(ctx, &history)
+	if err != nil {
+		return fmt.Errorf("list previous secrets: %w", err)
+	}
+
+	for _, previous := range append([]string{passkey.EncryptedKey}, history...) {
+		match, err := dao.CompareHash(ctx, request.Passkey, previous)
+		if err != nil {
+			return fmt.Errorf("compare previous secret: %w", err)
+		}
+
+		if match {
+			return dao.ErrSecretReused
+		}
+	}
+
+	return nil
+}
+
+// encryptSecret hashes the new secret of the passkey, if the request updates it.
+func encryptSecret(ctx context.Context, updateSecret bool, request *dao.UpdatePasskeyRequest) (string, error) {
+	if !updateSecret {
+		return "", nil
+	}
+
+	encrypted, err := dao.GenerateHash(
+		ctx, request.Passkey, lo.CoalesceOrEmpty(request.HashParams, lib.DefaultGenerateParams),
+	)
+	if err != nil {
+		return "", fmt.Errorf("encrypt passkey: %w", err)
+	}
+
+	return encrypted, nil
+}
+
+// apply writes the masked fields to the passkey, unless it was modified since it was read. When the secret changes,
+// the current one is moved to the history, which is trimmed to HistorySize entries. It returns the updated passkey,
+// or nil if the passkey must be read again.
+func (impl *updatePasskeyImpl) apply(
+	ctx context.Context,
+	passkey *entities.Passkey,
+	mask []dao.UpdatePasskeyField,
+	encrypted string,
+	now time.Time,
+	request *dao.UpdatePasskeyRequest,
+) (*entities.Passkey, error) {
+	model := &entities.Passkey{
+		ID:           passkey.ID,
+		Namespace:    passkey.Namespace,
+		EncryptedKey: encrypted,
+		Reward:       request.Reward,
+		ExpiresAt:    request.ExpiresAt,
+		UpdatedAt:    &now,
+	}
+
+	columns := append([]string{"updated_at", "version"}, lo.Map(mask, func(item dao.UpdatePasskeyField, _ int) string {
+		return updatePasskeyFieldColumns[item]
+	})...)
+
+	var updated bool
+
+	txErr := impl.database.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().
+			Model(model).
+			WherePK().
+			Where("tenant = ?", passkey.Tenant).
+			Where("version = ?", passkey.Version).
+			// Only write the masked columns. Single-use state is set once at creation, and only changed by redemption.
+			Column(columns...).
+			Value("version", "version + 1").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		if updated, err = applied(result); err != nil || !updated {
+			return err
+		}
+
+		if lo.Contains(mask, dao.UpdatePasskeyFieldPasskey) && request.HistorySize > 0 {
+			return impl.archiveSecret(ctx, tx, passkey, now, request.HistorySize)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return lo.Ternary(updated, model, nil), nil
+}
+
+// archiveSecret moves the current secret of the passkey to its history, then trims it to historySize entries.
+func (impl *updatePasskeyImpl) archiveSecret(
+	ctx context.Context, tx bun.Tx, passkey *entities.Passkey, now time.Time, historySize int,
+) error {
+	entry := &entities.PasskeyHistory{
+		PasskeyID:    passkey.ID,
+		Tenant:       passkey.Tenant,
+		EncryptedKey: passkey.EncryptedKey,
+		CreatedAt:    now,
+	}
+
+	if _, err := tx.NewInsert().Model(entry).Exec(ctx); err != nil {
+		return fmt.Errorf("archive current secret: %w", err)
+	}
+
+	kept := tx.NewSelect().
+		Model((*entities.PasskeyHistory)(nil)).
+		Column("id").
+		Where("passkey_id = ?", passkey.ID).
+		Where("tenant = ?", passkey.Tenant).
+		Order("created_at DESC", "id DESC").
+		Limit(historySize)
+
+	_, err := tx.NewDelete().
+		Model((*entities.PasskeyHistory)(nil)).
+		Where("passkey_id = ?", passkey.ID).
+		Where("tenant = ?", passkey.Tenant).
+		Where("id NOT IN (?)", kept).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("trim history: %w", err)
+	}
+
+	return nil
+}
+
+func NewUpdatePasskey(database bun.IDB) dao.UpdatePasskey {
+	return &updatePasskeyImpl{database: database}
+}
@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultTenant owns the rows of callers without a tenant, and the rows created before tenants existed.
+const DefaultTenant = ""
+
+// tenantRole is the database role transactions switch to, so the row-level security policies apply even when the
+// service connects as a superuser or as the owner of the tables.
+const tenantRole = "passkeys_tenant"
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of ctx, whose queries are restricted to the rows of tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by WithTenant, or DefaultTenant.
+func TenantFromContext(ctx context.Context) string {
+	tenant, ok := ctx.Value(tenantContextKey{}).(string)
+	if !ok {
+		return DefaultTenant
+	}
+
+	return tenant
+}
+
+// runInTenantTx runs callback in a transaction that can only see and write the rows of the tenant from ctx. Isolation
+// is enforced by the database, so it holds even for queries that forget to filter by namespace.
+func runInTenantTx(ctx context.Context, database bun.IDB, callback func(ctx context.Context, tx bun.Tx) error) error {
+	// Callers wrap the error, as they would with RunInTx.
+	return database.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error { //nolint:wrapcheck
+		if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant', ?, true)", TenantFromContext(ctx)); err != nil {
+			return fmt.Errorf("set tenant: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "SET LOCAL ROLE "+tenantRole); err != nil {
+			return fmt.Errorf("set tenant role: %w", err)
+		}
+
+		return callback(ctx, tx)
+	})
+}
@@ -0,0 +1,142 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Passkey{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Namespace:    "namespace",
+			Tenant:       "tenant-a",
+			EncryptedKey: "encrypted",
+			CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Namespace{
+			Name:      "namespace",
+			Tenant:    "tenant-a",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	passkeyID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	tenantA := dao.WithTenant(context.Background(), "tenant-a")
+	tenantB := dao.WithTenant(context.Background(), "tenant-b")
+
+	t.Run("SameTenant", func(t *testing.T) {
+		transaction := anoveldb.BeginTestTX(database, fixtures)
+		defer anoveldb.RollbackTestTX(transaction)
+
+		passkey, err := dao.NewGetPasskey(transaction).Exec(tenantA, &dao.GetPasskeyRequest{
+			ID:        passkeyID,
+			Namespace: "namespace",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "tenant-a", passkey.Tenant)
+
+		namespaces, err := dao.NewListNamespaces(transaction).Exec(tenantA, &dao.ListNamespacesRequest{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, namespaces, 1)
+	})
+
+	t.Run("OtherTenant/Read", func(t *testing.T) {
+		transaction := anoveldb.BeginTestTX(database, fixtures)
+		defer anoveldb.RollbackTestTX(transaction)
+
+		_, err := dao.NewGetPasskey(transaction).Exec(tenantB, &dao.GetPasskeyRequest{
+			ID:        passkeyID,
+			Namespace: "namespace",
+		})
+		require.ErrorIs(t, err, dao.ErrPasskeyNotFound)
+
+		_, err = dao.NewGetNamespace(transaction).Exec(tenantB, &dao.GetNamespaceRequest{Name: "namespace"})
+		require.ErrorIs(t, err, dao.ErrNamespaceNotFound)
+
+		// Listing has no filter at all: only the database policies hide the rows of other tenants.
+		namespaces, err := dao.NewListNamespaces(transaction).Exec(tenantB, &dao.ListNamespacesRequest{Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, namespaces)
+
+		passkeys, err := dao.NewListPasskeys(transaction).Exec(tenantB, &dao.ListPasskeysRequest{Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, passkeys)
+	})
+
+	t.Run("OtherTenant/Write", func(t *testing.T) {
+		transaction := anoveldb.BeginTestTX(database, fixtures)
+		defer anoveldb.RollbackTestTX(transaction)
+
+		_, err := dao.NewUpdatePasskey(transaction).Exec(tenantB, passkeyID, time.Now(), &dao.UpdatePasskeyRequest{
+			Namespace: "namespace",
+			Mask:      []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+			Reward:    map[string]interface{}{"key": "value"},
+		})
+		require.ErrorIs(t, err, dao.ErrPasskeyNotFound)
+
+		_, err = dao.NewRevokePasskey(transaction).Exec(tenantB, time.Now(), &dao.RevokePasskeyRequest{
+			ID:        passkeyID,
+			Namespace: "namespace",
+			RevokedBy: "admin",
+			Reason:    "leaked",
+		})
+		require.ErrorIs(t, err, dao.ErrPasskeyNotFound)
+
+		_, err = dao.NewDeletePasskey(transaction).Exec(tenantB, &dao.DeletePasskeyRequest{
+			ID:        passkeyID,
+			Namespace: "namespace",
+		})
+		require.ErrorIs(t, err, dao.ErrPasskeyNotFound)
+
+		_, err = dao.NewDeleteNamespace(transaction).Exec(tenantB, &dao.DeleteNamespaceRequest{Name: "namespace"})
+		require.ErrorIs(t, err, dao.ErrNamespaceNotFound)
+
+		// The passkey is still there for its own tenant.
+		_, err = dao.NewGetPasskey(transaction).Exec(tenantA, &dao.GetPasskeyRequest{
+			ID:        passkeyID,
+			Namespace: "namespace",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("OtherTenant/SameNamespace", func(t *testing.T) {
+		transaction := anoveldb.BeginTestTX(database, fixtures)
+		defer anoveldb.RollbackTestTX(transaction)
+
+		namespace, err := dao.NewCreateNamespace(transaction).Exec(
+			tenantB, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), &dao.CreateNamespaceRequest{Name: "namespace"},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-b", namespace.Tenant)
+
+		passkey, err := dao.NewCreatePasskey(transaction).Exec(
+			tenantB,
+			uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			&dao.CreatePasskeyRequest{
+				Namespace:         "namespace",
+				Passkey:           "passkey",
+				MaxActivePasskeys: lo.ToPtr(1),
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-b", passkey.Tenant)
+	})
+}
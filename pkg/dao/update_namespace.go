@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+type UpdateNamespaceRequest struct {
+	Name   string
+	Policy entities.NamespacePolicy
+}
+
+type UpdateNamespace interface {
+	Exec(ctx context.Context, now time.Time, request *UpdateNamespaceRequest) (*entities.Namespace, error)
+}
+
+type updateNamespaceImpl struct {
+	database bun.IDB
+}
+
+func (dao *updateNamespaceImpl) Exec(
+	ctx context.Context, now time.Time, request *UpdateNamespaceRequest,
+) (*entities.Namespace, error) {
+	model := &entities.Namespace{
+		Name:            request.Name,
+		NamespacePolicy: request.Policy,
+		UpdatedAt:       &now,
+	}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		rows, err := tx.NewUpdate().
+			Model(model).
+			WherePK().
+			ExcludeColumn("created_at", "tenant").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		affected, err := rows.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if affected == 0 {
+			return ErrNamespaceNotFound
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+func NewUpdateNamespace(database bun.IDB) UpdateNamespace {
+	return &updateNamespaceImpl{database: database}
+}
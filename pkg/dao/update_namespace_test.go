@@ -0,0 +1,96 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-passkeys/migrations"
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+)
+
+func TestUpdateNamespace(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Namespace{
+			Name: "namespace",
+			NamespacePolicy: entities.NamespacePolicy{
+				MaxTTL:           lo.ToPtr(time.Hour),
+				HashAlgorithm:    entities.HashAlgorithmArgon2id,
+				SingleUseDefault: true,
+			},
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now     time.Time
+		request *dao.UpdateNamespaceRequest
+
+		expect    *entities.Namespace
+		expectErr error
+	}{
+		{
+			name: "Update",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdateNamespaceRequest{
+				Name: "namespace",
+				Policy: entities.NamespacePolicy{
+					DefaultTTL:        lo.ToPtr(time.Minute),
+					HashAlgorithm:     entities.HashAlgorithmArgon2id,
+					MaxActivePasskeys: lo.ToPtr(5),
+				},
+			},
+
+			expect: &entities.Namespace{
+				Name: "namespace",
+				NamespacePolicy: entities.NamespacePolicy{
+					DefaultTTL:        lo.ToPtr(time.Minute),
+					HashAlgorithm:     entities.HashAlgorithmArgon2id,
+					MaxActivePasskeys: lo.ToPtr(5),
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "NotFound",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdateNamespaceRequest{
+				Name:   "namespace-2",
+				Policy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			},
+
+			expectErr: dao.ErrNamespaceNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			updateNamespaceDAO := dao.NewUpdateNamespace(transaction)
+
+			result, err := updateNamespaceDAO.Exec(context.Background(), testCase.now, testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, result)
+		})
+	}
+}
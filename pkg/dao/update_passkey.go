@@ -2,21 +2,59 @@ package dao
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/uptrace/bun"
 
 	"github.com/a-novel/uservice-passkeys/pkg/entities"
 	"github.com/a-novel/uservice-passkeys/pkg/lib"
 )
 
+// UpdatePasskeyField is a field of a passkey that can be modified by UpdatePasskey.
+type UpdatePasskeyField string
+
+const (
+	UpdatePasskeyFieldPasskey   UpdatePasskeyField = "passkey"
+	UpdatePasskeyFieldReward    UpdatePasskeyField = "reward"
+	UpdatePasskeyFieldExpiresAt UpdatePasskeyField = "expires_at"
+)
+
+var updatePasskeyAllFields = []UpdatePasskeyField{
+	UpdatePasskeyFieldPasskey,
+	UpdatePasskeyFieldReward,
+	UpdatePasskeyFieldExpiresAt,
+}
+
+var updatePasskeyFieldColumns = map[UpdatePasskeyField]string{
+	UpdatePasskeyFieldPasskey:   "encrypted_key",
+	UpdatePasskeyFieldReward:    "reward",
+	UpdatePasskeyFieldExpiresAt: "expires_at",
+}
+
 type UpdatePasskeyRequest struct {
+	// Mask lists the fields to update. Fields outside the mask are left untouched, even if their value in the
+	// request is empty. Every field is updated when the mask is empty.
+	Mask []UpdatePasskeyField
+
 	Namespace string
 	Passkey   string
 	Reward    map[string]interface{}
 	ExpiresAt *time.Time
+	// CurrentKey must match the current secret of the passkey when set, otherwise the update is rejected.
+	CurrentKey *string
+	// ExpectedVersion rejects the update if the passkey was modified since this version, when set.
+	ExpectedVersion *int64
+
+	// HashParams defaults to lib.DefaultGenerateParams when empty.
+	HashParams *lib.GenerateParams
+	// HistorySize is the number of previous secrets the new one is checked against, on top of the current secret.
+	// No history is kept when 0.
+	HistorySize int
 }
 
 type UpdatePasskey interface {
@@ -30,40 +68,197 @@ type updatePasskeyImpl struct {
 func (dao *updatePasskeyImpl) Exec(
 	ctx context.Context, passkeyID uuid.UUID, now time.Time, request *UpdatePasskeyRequest,
 ) (*entities.Passkey, error) {
-	encrypted, err := lib.GenerateFromPassword(request.Passkey, lib.DefaultGenerateParams)
+	mask := lo.Ternary(len(request.Mask) == 0, updatePasskeyAllFields, request.Mask)
+	updateSecret := lo.Contains(mask, UpdatePasskeyFieldPasskey)
+
+	model := &entities.Passkey{
+		ID:        passkeyID,
+		Namespace: request.Namespace,
+		Reward:    request.Reward,
+		ExpiresAt: request.ExpiresAt,
+		UpdatedAt: &now,
+	}
+
+	columns := append([]string{"updated_at", "version"}, lo.Map(mask, func(item UpdatePasskeyField, _ int) string {
+		return updatePasskeyFieldColumns[item]
+	})...)
+
+	if updateSecret {
+		encrypted, err := GenerateHash(
+			ctx, request.Passkey, lo.CoalesceOrEmpty(request.HashParams, lib.DefaultGenerateParams),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt passkey: %w", err)
+		}
+
+		model.EncryptedKey = encrypted
+	}
+
+	txErr := runInTenantTx(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		if err := dao.checkSecrets(ctx, tx, passkeyID, now, updateSecret, request); err != nil {
+			return err
+		}
+
+		query := tx.NewUpdate().
+			Model(model).
+			WherePK().
+			Where("revoked_at IS NULL").
+			// Only write the masked columns. Single-use state is set once at creation, and only changed by redemption.
+			// Revocation has its own DAOs.
+			Column(columns...).
+			Value("version", "version + 1").
+			Returning("*")
+
+		if request.ExpectedVersion != nil {
+			query = query.Where("version = ?", *request.ExpectedVersion)
+		}
+
+		rows, err := query.Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		affected, err := rows.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if affected == 0 {
+			return checkNotUpdated(ctx, tx, passkeyID, request.Namespace, request.ExpectedVersion)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("exec transaction: %w", txErr)
+	}
+
+	return model, nil
+}
+
+// checkSecrets locks the passkey, then verifies its current secret and rotates the secret history, when the request
+// requires it.
+func (dao *updatePasskeyImpl) checkSecrets(
+	ctx context.Context, tx bun.Tx, passkeyID uuid.UUID, now time.Time, updateSecret bool, request *UpdatePasskeyRequest,
+) error {
+	rotate := updateSecret && request.HistorySize > 0
+	if request.CurrentKey == nil && !rotate {
+		return nil
+	}
+
+	var current string
+
+	err := tx.NewSelect().
+		Table("passkeys").
+		Column("encrypted_key").
+		Where("id = ?", passkeyID).
+		Where("namespace = ?", request.Namespace).
+		Where("revoked_at IS NULL").
+		For("UPDATE").
+		Scan(ctx, &current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return checkNotUpdated(ctx, tx, passkeyID, request.Namespace, nil)
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("encrypt passkey: %w", err)
+		return fmt.Errorf("get current secret: %w", err)
 	}
 
-	model := &entities.Passkey{
-		ID:           passkeyID,
-		Namespace:    request.Namespace,
-		EncryptedKey: encrypted,
-		Reward:       request.Reward,
-		ExpiresAt:    request.ExpiresAt,
-		UpdatedAt:    &now,
+	if request.CurrentKey != nil {
+		match, err := CompareHash(ctx, *request.CurrentKey, current)
+		if err != nil {
+			return fmt.Errorf("compare current secret: %w", err)
+		}
+
+		if !match {
+			return ErrInvalidPasskey
+		}
 	}
 
-	rows, err := dao.database.NewUpdate().
-		Model(model).
-		WherePK().
-		ExcludeColumn("created_at").
-		Returning("*").
-		Exec(ctx)
+	if !rotate {
+		return nil
+	}
+
+	return dao.rotateHistory(ctx, tx, passkeyID, now, current, request)
+}
+
+// checkNotUpdated tells why a passkey could not be updated: revoked passkeys cannot be updated, unlike expired ones.
+func checkNotUpdated(
+	ctx context.Context, tx bun.IDB, passkeyID uuid.UUID, namespace string, expectedVersion *int64,
+) error {
+	revoked, err := tx.NewSelect().
+		Table("passkeys").
+		Where("id = ?", passkeyID).
+		Where("namespace = ?", namespace).
+		Where("revoked_at IS NOT NULL").
+		Exists(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("exec query: %w", err)
+		return fmt.Errorf("check revocation: %w", err)
+	}
+
+	if revoked {
+		return ErrPasskeyRevoked
 	}
 
-	affected, err := rows.RowsAffected()
+	return checkVersionMismatch(ctx, tx, passkeyID, namespace, expectedVersion)
+}
+
+// rotateHistory rejects the new secret if it matches the current one, or any of the HistorySize previous ones.
+// The current secret is then moved to the history, which is trimmed to HistorySize entries.
+func (dao *updatePasskeyImpl) rotateHistory(
+	ctx context.Context, tx bun.Tx, passkeyID uuid.UUID, now time.Time, current string, request *UpdatePasskeyRequest,
+) error {
+	var history []string
+
+	err := tx.NewSelect().
+		Model((*entities.PasskeyHistory)(nil)).
+		Column("encrypted_key").
+		Where("passkey_id = ?", passkeyID).
+		Order("created_at DESC", "id DESC").
+		Limit(request.HistorySize).
+		Scan(ctx, &history)
 	if err != nil {
-		return nil, fmt.Errorf("get rows affected: %w", err)
+		return fmt.Errorf("list previous secrets: %w", err)
 	}
 
-	if affected == 0 {
-		return nil, ErrPasskeyNotFound
+	for _, previous := range append([]string{current}, history...) {
+		match, err := CompareHash(ctx, request.Passkey, previous)
+		if err != nil {
+			return fmt.Errorf("compare previous secret: %w", err)
+		}
+
+		if match {
+			return ErrSecretReused
+		}
 	}
 
-	return model, nil
+	entry := &entities.PasskeyHistory{
+		PasskeyID:    passkeyID,
+		EncryptedKey: current,
+		CreatedAt:    now,
+	}
+
+	if _, err := tx.NewInsert().Model(entry).Exec(ctx); err != nil {
+		return fmt.Errorf("archive current secret: %w", err)
+	}
+
+	kept := tx.NewSelect().
+		Model((*entities.PasskeyHistory)(nil)).
+		Column("id").
+		Where("passkey_id = ?", passkeyID).
+		Order("created_at DESC", "id DESC").
+		Limit(request.HistorySize)
+
+	_, err = tx.NewDelete().
+		Model((*entities.PasskeyHistory)(nil)).
+		Where("passkey_id = ?", passkeyID).
+		Where("id NOT IN (?)", kept).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("trim history: %w", err)
+	}
+
+	return nil
 }
 
 func NewUpdatePasskey(database bun.IDB) UpdatePasskey {
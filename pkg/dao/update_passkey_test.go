@@ -22,6 +22,10 @@ func TestUpdatePasskey(t *testing.T) {
 
 	encryptedPassword1, err := lib.GenerateFromPassword(password1, lib.DefaultGenerateParams)
 	require.NoError(t, err)
+	encryptedOldPassword1, err := lib.GenerateFromPassword("old-password1", lib.DefaultGenerateParams)
+	require.NoError(t, err)
+	encryptedOldPassword2, err := lib.GenerateFromPassword("old-password2", lib.DefaultGenerateParams)
+	require.NoError(t, err)
 
 	fixtures := []interface{}{
 		&entities.Passkey{
@@ -32,6 +36,16 @@ func TestUpdatePasskey(t *testing.T) {
 			ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
 			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
+		&entities.PasskeyHistory{
+			PasskeyID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			EncryptedKey: encryptedOldPassword1,
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.PasskeyHistory{
+			PasskeyID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			EncryptedKey: encryptedOldPassword2,
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	testCases := []struct {
@@ -43,6 +57,8 @@ func TestUpdatePasskey(t *testing.T) {
 
 		expect    *entities.Passkey
 		expectErr error
+		// expectHistory lists the secrets kept in the history after the update, from the most recent.
+		expectHistory []string
 	}{
 		{
 			name: "Update",
@@ -64,6 +80,7 @@ func TestUpdatePasskey(t *testing.T) {
 				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)),
 				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
 			},
 		},
 		{
@@ -82,8 +99,286 @@ func TestUpdatePasskey(t *testing.T) {
 				Namespace: "namespace",
 				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Update/Mask/Reward",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:      []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"new-key": "new-value"},
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"new-key": "new-value"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Update/Mask/ClearExpiration",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:      []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldExpiresAt},
+				Namespace: "namespace",
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Update/Mask/Passkey",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:        []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldPasskey},
+				Namespace:   "namespace",
+				Passkey:     "passkey",
+				HistorySize: 2,
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+			expectHistory: []string{password1, "old-password2"},
+		},
+		{
+			name: "Update/Mask/NoSecretHistory",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			// The secret is not rotated, so the history is left untouched.
+			request: &dao.UpdatePasskeyRequest{
+				Mask:        []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace:   "namespace",
+				HistorySize: 2,
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+			expectHistory: []string{"old-password2", "old-password1"},
+		},
+		{
+			name: "Update/CurrentKey",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:       []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldPasskey},
+				Namespace:  "namespace",
+				Passkey:    "passkey",
+				CurrentKey: &password1,
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+		},
+		{
+			name: "Update/CurrentKey/Invalid",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:       []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldPasskey},
+				Namespace:  "namespace",
+				Passkey:    "passkey",
+				CurrentKey: lo.ToPtr("old-password1"),
+			},
+
+			expectErr: dao.ErrInvalidPasskey,
+		},
+		{
+			name: "Update/CurrentKey/NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:  "namespace",
+				Passkey:    "passkey",
+				CurrentKey: &password1,
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "Update/ExpectedVersion",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:            []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace:       "namespace",
+				Reward:          map[string]interface{}{"new-key": "new-value"},
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"new-key": "new-value"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
 			},
 		},
+		{
+			name: "Update/ExpectedVersion/Mismatch",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:            []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace:       "namespace",
+				Reward:          map[string]interface{}{"new-key": "new-value"},
+				ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+
+			expectErr: dao.ErrVersionMismatch,
+		},
+		{
+			name: "Update/ExpectedVersion/NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Mask:            []dao.UpdatePasskeyField{dao.UpdatePasskeyFieldReward},
+				Namespace:       "namespace",
+				ExpectedVersion: lo.ToPtr(int64(1)),
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
+		{
+			name: "Update/History",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:   "namespace",
+				Passkey:     "passkey",
+				HistorySize: 2,
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+			expectHistory: []string{password1, "old-password2"},
+		},
+		{
+			name: "Update/History/ReuseCurrent",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:   "namespace",
+				Passkey:     password1,
+				HistorySize: 2,
+			},
+
+			expectErr: dao.ErrSecretReused,
+		},
+		{
+			name: "Update/History/ReusePrevious",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:   "namespace",
+				Passkey:     "old-password1",
+				HistorySize: 2,
+			},
+
+			expectErr: dao.ErrSecretReused,
+		},
+		{
+			name: "Update/History/OutsideHistory",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:   "namespace",
+				Passkey:     "old-password1",
+				HistorySize: 1,
+			},
+
+			expect: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
+			},
+			expectHistory: []string{password1},
+		},
+		{
+			name: "Update/History/NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.UpdatePasskeyRequest{
+				Namespace:   "namespace",
+				Passkey:     "passkey",
+				HistorySize: 2,
+			},
+
+			expectErr: dao.ErrPasskeyNotFound,
+		},
 		{
 			name: "NotFound",
 
@@ -123,11 +418,34 @@ func TestUpdatePasskey(t *testing.T) {
 				require.Equal(t, testCase.expect.ExpiresAt, result.ExpiresAt)
 				require.Equal(t, testCase.expect.CreatedAt, result.CreatedAt)
 				require.Equal(t, testCase.expect.UpdatedAt, result.UpdatedAt)
+				require.Equal(t, testCase.expect.Version, result.Version)
 
-				matching, err := lib.ComparePasswordAndHash(testCase.request.Passkey, result.EncryptedKey)
+				// The secret is left unchanged when it is not updated.
+				secret := lo.CoalesceOrEmpty(testCase.request.Passkey, password1)
+
+				matching, err := lib.ComparePasswordAndHash(secret, result.EncryptedKey)
 				require.NoError(t, err)
 				require.True(t, matching)
 			}
+
+			if testCase.expectHistory != nil {
+				var history []string
+
+				err = transaction.NewSelect().
+					Model((*entities.PasskeyHistory)(nil)).
+					Column("encrypted_key").
+					Where("passkey_id = ?", testCase.id).
+					Order("created_at DESC").
+					Scan(context.Background(), &history)
+				require.NoError(t, err)
+				require.Len(t, history, len(testCase.expectHistory))
+
+				for i, secret := range testCase.expectHistory {
+					matching, err := lib.ComparePasswordAndHash(secret, history[i])
+					require.NoError(t, err)
+					require.True(t, matching)
+				}
+			}
 		})
 	}
 }
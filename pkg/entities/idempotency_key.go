@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// IdempotencyKey records the result of a request sent with an idempotency key.
+type IdempotencyKey struct {
+	bun.BaseModel `bun:"table:idempotency_keys"`
+
+	Key     string `bun:"key,pk"`
+	Service string `bun:"service,pk"`
+	// Tenant defaults to the tenant of the transaction that claims the key.
+	Tenant string `bun:"tenant,nullzero"`
+
+	Fingerprint string `bun:"fingerprint"`
+	// Response is empty while the original request is still running.
+	Response []byte `bun:"response"`
+	// Version is the version header sent with the response, if any.
+	Version *int64 `bun:"version"`
+
+	CreatedAt time.Time `bun:"created_at"`
+	ExpiresAt time.Time `bun:"expires_at"`
+}
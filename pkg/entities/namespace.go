@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const HashAlgorithmArgon2id = "argon2id"
+
+// HashParams configures the argon2id hash of the passkeys within a namespace.
+type HashParams struct {
+	SaltLength  uint   `json:"salt_length"`
+	Iterations  uint32 `json:"iterations"`
+	Memory      uint32 `json:"memory"`
+	Parallelism uint8  `json:"parallelism"`
+	KeyLength   uint32 `json:"key_length"`
+}
+
+// StrengthRules restrict the secrets accepted for new passkeys.
+type StrengthRules struct {
+	MinLength        int  `json:"min_length"`
+	MaxLength        int  `json:"max_length"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSymbol    bool `json:"require_symbol"`
+}
+
+type NamespacePolicy struct {
+	// DefaultTTL is applied to passkeys created without an explicit expiration.
+	DefaultTTL *time.Duration `bun:"default_ttl"`
+	// MaxTTL is the longest lifetime allowed for a passkey. Passkeys never expire when empty.
+	MaxTTL *time.Duration `bun:"max_ttl"`
+
+	HashAlgorithm string         `bun:"hash_algorithm"`
+	HashParams    *HashParams    `bun:"hash_params,type:jsonb"`
+	StrengthRules *StrengthRules `bun:"strength_rules,type:jsonb"`
+
+	SingleUseDefault  bool `bun:"single_use_default"`
+	MaxActivePasskeys *int `bun:"max_active_passkeys"`
+	// CreationRateLimit is the maximum number of passkeys created in the namespace over a CreationRateWindow.
+	CreationRateLimit  *int           `bun:"creation_rate_limit"`
+	CreationRateWindow *time.Duration `bun:"creation_rate_window"`
+
+	// SecretHistorySize is the number of previous secrets of a passkey that cannot be reused on update.
+	SecretHistorySize int `bun:"secret_history_size"`
+}
+
+type Namespace struct {
+	bun.BaseModel `bun:"table:namespaces"`
+
+	Name string `bun:"name,pk"`
+	// Tenant defaults to the tenant of the transaction that creates the namespace. Names are only unique within a
+	// tenant.
+	Tenant string `bun:"tenant,nullzero"`
+
+	NamespacePolicy
+
+	CreatedAt time.Time  `bun:"created_at"`
+	UpdatedAt *time.Time `bun:"updated_at"`
+}
@@ -12,11 +12,28 @@ type Passkey struct {
 
 	ID        uuid.UUID `bun:"id,pk,type:uuid"`
 	Namespace string    `bun:"namespace,pk"`
+	// Tenant defaults to the tenant of the transaction that creates the passkey.
+	Tenant string `bun:"tenant,nullzero"`
 
 	EncryptedKey string                 `bun:"encrypted_key"`
 	Reward       map[string]interface{} `bun:"reward"`
 
+	// SingleUse passkeys are redeemed the first time they are successfully validated.
+	SingleUse  bool       `bun:"single_use"`
+	RedeemedAt *time.Time `bun:"redeemed_at"`
+	// RedeemedBy is the subject of the token that redeemed the passkey, when the request was authenticated with one.
+	RedeemedBy *string `bun:"redeemed_by"`
+
+	// Revoked passkeys are kept for history, and can be restored within a retention window.
+	RevokedAt        *time.Time `bun:"revoked_at"`
+	RevokedBy        *string    `bun:"revoked_by"`
+	RevocationReason *string    `bun:"revocation_reason"`
+
 	ExpiresAt *time.Time `bun:"expires_at"`
 	CreatedAt time.Time  `bun:"created_at"`
 	UpdatedAt *time.Time `bun:"updated_at"`
+
+	// Version is incremented on every change of the passkey, so clients can detect concurrent updates. New passkeys
+	// start at 1.
+	Version int64 `bun:"version,nullzero"`
 }
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PasskeyHistory is a previous secret of a passkey.
+type PasskeyHistory struct {
+	bun.BaseModel `bun:"table:passkey_history"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	PasskeyID uuid.UUID `bun:"passkey_id,type:uuid"`
+	// Tenant defaults to the tenant of the transaction that archives the secret.
+	Tenant string `bun:"tenant,nullzero"`
+
+	EncryptedKey string `bun:"encrypted_key"`
+
+	// CreatedAt is the date the secret was replaced.
+	CreatedAt time.Time `bun:"created_at"`
+}
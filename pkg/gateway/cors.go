@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+)
+
+// AnyOrigin allows requests from every origin.
+const AnyOrigin = "*"
+
+// CORSMethods are the methods used by the JSON routes, and the Connect and gRPC-Web protocols.
+var CORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete}
+
+// CORSHeaders are the request headers browsers are allowed to send. They cover the Connect and gRPC-Web protocols,
+// and the metadata read by the services.
+var CORSHeaders = []string{
+	"Content-Type",
+	"Accept-Encoding",
+	"Content-Encoding",
+	"Connect-Protocol-Version",
+	"Connect-Timeout-Ms",
+	"Connect-Accept-Encoding",
+	"Connect-Content-Encoding",
+	"Grpc-Timeout",
+	"X-Grpc-Web",
+	"X-User-Agent",
+	HeaderPasskey.Name,
+	HeaderCurrentPasskey.Name,
+	HeaderPasskeyID.Name,
+	HeaderUpdateMask.Name,
+	HeaderExpectedVersion.Name,
+	HeaderIdempotencyKey.Name,
+	HeaderTenant.Name,
+	HeaderAuthorization.Name,
+}
+
+// CORSExposedHeaders are the response headers browsers can read. gRPC-Web sends the status of calls in headers when
+// they fail early.
+var CORSExposedHeaders = []string{
+	handlers.VersionHeader,
+	"Grpc-Status",
+	"Grpc-Message",
+	"Grpc-Status-Details-Bin",
+}
+
+// CORS configures the cross-origin requests accepted by the HTTP server.
+type CORS struct {
+	// AllowedOrigins lists the origins allowed to send requests, or AnyOrigin. Cross-origin requests are rejected by
+	// browsers when it is empty.
+	AllowedOrigins []string
+	// MaxAge is how long browsers can cache the result of a preflight request. Browsers use their own default when
+	// it is zero.
+	MaxAge time.Duration
+}
+
+func (cors CORS) allowOrigin(origin string) string {
+	if slices.Contains(cors.AllowedOrigins, AnyOrigin) {
+		return AnyOrigin
+	}
+
+	if slices.Contains(cors.AllowedOrigins, origin) {
+		return origin
+	}
+
+	return ""
+}
+
+// NewCORSHandler answers preflight requests, and allows browsers to read the responses of the handler from the
+// allowed origins. The handler is returned as is when no origin is allowed.
+func NewCORSHandler(handler http.Handler, cors CORS) http.Handler {
+	if len(cors.AllowedOrigins) == 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		origin := request.Header.Get("Origin")
+		if origin == "" {
+			handler.ServeHTTP(writer, request)
+
+			return
+		}
+
+		writer.Header().Add("Vary", "Origin")
+
+		allowed := cors.allowOrigin(origin)
+		if allowed != "" {
+			writer.Header().Set("Access-Control-Allow-Origin", allowed)
+			writer.Header().Set("Access-Control-Expose-Headers", strings.Join(CORSExposedHeaders, ", "))
+		}
+
+		preflight := request.Method == http.MethodOptions && request.Header.Get("Access-Control-Request-Method") != ""
+		if !preflight {
+			handler.ServeHTTP(writer, request)
+
+			return
+		}
+
+		// Browsers block the request when the origin is not allowed, so preflights never reach the handler.
+		if allowed != "" {
+			writer.Header().Set("Access-Control-Allow-Methods", strings.Join(CORSMethods, ", "))
+			writer.Header().Set("Access-Control-Allow-Headers", strings.Join(CORSHeaders, ", "))
+
+			if cors.MaxAge > 0 {
+				writer.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cors.MaxAge.Seconds())))
+			}
+		}
+
+		writer.WriteHeader(http.StatusNoContent)
+	})
+}
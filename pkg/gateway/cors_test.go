@@ -0,0 +1,123 @@
+package gateway_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/gateway"
+)
+
+func TestCORSHandler(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		cors    gateway.CORS
+		method  string
+		headers map[string]string
+
+		expectStatus       int
+		expectAllowOrigin  string
+		expectAllowHeaders bool
+		expectMaxAge       string
+	}{
+		{
+			name: "Preflight",
+
+			cors:   gateway.CORS{AllowedOrigins: []string{"https://a.example"}, MaxAge: time.Hour},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://a.example",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+
+			expectStatus:       http.StatusNoContent,
+			expectAllowOrigin:  "https://a.example",
+			expectAllowHeaders: true,
+			expectMaxAge:       "3600",
+		},
+		{
+			name: "Preflight/AnyOrigin",
+
+			cors:   gateway.CORS{AllowedOrigins: []string{gateway.AnyOrigin}},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://b.example",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+
+			expectStatus:       http.StatusNoContent,
+			expectAllowOrigin:  gateway.AnyOrigin,
+			expectAllowHeaders: true,
+		},
+		{
+			name: "Preflight/OriginNotAllowed",
+
+			cors:   gateway.CORS{AllowedOrigins: []string{"https://a.example"}},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://b.example",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name: "Request",
+
+			cors:    gateway.CORS{AllowedOrigins: []string{"https://a.example"}},
+			method:  http.MethodPost,
+			headers: map[string]string{"Origin": "https://a.example"},
+
+			expectStatus:      http.StatusTeapot,
+			expectAllowOrigin: "https://a.example",
+		},
+		{
+			name: "Request/OriginNotAllowed",
+
+			cors:    gateway.CORS{AllowedOrigins: []string{"https://a.example"}},
+			method:  http.MethodPost,
+			headers: map[string]string{"Origin": "https://b.example"},
+
+			expectStatus: http.StatusTeapot,
+		},
+		{
+			name: "Disabled",
+
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://a.example",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+
+			expectStatus: http.StatusTeapot,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler := gateway.NewCORSHandler(
+				http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+					writer.WriteHeader(http.StatusTeapot)
+				}),
+				testCase.cors,
+			)
+
+			request := httptest.NewRequest(testCase.method, "/", nil)
+			for key, value := range testCase.headers {
+				request.Header.Set(key, value)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.expectStatus, recorder.Code)
+			require.Equal(t, testCase.expectAllowOrigin, recorder.Header().Get("Access-Control-Allow-Origin"))
+			require.Equal(t, testCase.expectAllowHeaders, recorder.Header().Get("Access-Control-Allow-Headers") != "")
+			require.Equal(t, testCase.expectMaxAge, recorder.Header().Get("Access-Control-Max-Age"))
+		})
+	}
+}
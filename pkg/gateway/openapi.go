@@ -0,0 +1,209 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification the document follows.
+const OpenAPIVersion = "3.0.3"
+
+// OpenAPIInfo describes the API in the OpenAPI document.
+type OpenAPIInfo struct {
+	Title   string
+	Version string
+}
+
+// Well-known types have a custom JSON representation.
+var wellKnownSchemas = map[protoreflect.FullName]map[string]any{
+	"google.protobuf.Struct":    {"type": "object", "additionalProperties": true},
+	"google.protobuf.Value":     {},
+	"google.protobuf.Duration":  {"type": "string", "example": "3600s"},
+	"google.protobuf.Timestamp": {"type": "string", "format": "date-time"},
+}
+
+// OpenAPI generates the OpenAPI document of the routes. Schemas are derived from the protobuf messages of each route,
+// using their JSON field names.
+func OpenAPI(routes []Route, info OpenAPIInfo) ([]byte, error) {
+	schemas := map[string]any{}
+	paths := map[string]map[string]any{}
+
+	for _, route := range routes {
+		if paths[route.Pattern] == nil {
+			paths[route.Pattern] = map[string]any{}
+		}
+
+		paths[route.Pattern][strings.ToLower(route.Method)] = openAPIOperation(route, schemas)
+	}
+
+	document := map[string]any{
+		"openapi":    OpenAPIVersion,
+		"info":       map[string]any{"title": info.Title, "version": info.Version},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+
+	// Maps are sorted when encoded, so the output is stable.
+	output, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode document: %w", err)
+	}
+
+	return output, nil
+}
+
+func openAPIOperation(route Route, schemas map[string]any) map[string]any {
+	request := route.newRequest().ProtoReflect().Descriptor()
+	response := route.newResponse().ProtoReflect().Descriptor()
+
+	wildcards := wildcardPattern.FindAllStringSubmatch(route.Pattern, -1)
+
+	parameters := make([]any, 0, len(wildcards)+len(route.Query)+len(route.Headers)+len(CommonHeaders))
+	inPath := make([]string, 0, len(wildcards))
+
+	for _, match := range wildcards {
+		inPath = append(inPath, match[1])
+		parameters = append(parameters, map[string]any{
+			"name": match[1], "in": "path", "required": true, "schema": fieldSchema(request, match[1], schemas),
+		})
+	}
+
+	for _, name := range route.Query {
+		parameters = append(parameters, map[string]any{
+			"name": name, "in": "query", "schema": fieldSchema(request, name, schemas),
+		})
+	}
+
+	for _, header := range append(slices.Clone(route.Headers), CommonHeaders...) {
+		parameters = append(parameters, map[string]any{
+			"name": header.Name, "in": "header", "description": header.Description, "schema": map[string]any{"type": "string"},
+		})
+	}
+
+	operation := map[string]any{
+		"operationId": strings.TrimPrefix(strings.ReplaceAll(route.FullMethod, "/", "."), "."),
+		"summary":     route.Summary,
+		"parameters":  parameters,
+		"responses": map[string]any{
+			strconv.Itoa(successStatus(route)): map[string]any{
+				"description": http.StatusText(successStatus(route)),
+				"content":     jsonContent(messageSchema(response, nil, schemas)),
+			},
+			"default": map[string]any{
+				"description": "The error, as a google.rpc.Status object.",
+				"content":     jsonContent(statusSchema),
+			},
+		},
+	}
+
+	if route.Body {
+		operation["requestBody"] = map[string]any{
+			"content": jsonContent(messageSchema(request, inPath, schemas)),
+		}
+	}
+
+	return operation
+}
+
+var statusSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"code":    map[string]any{"type": "integer"},
+		"message": map[string]any{"type": "string"},
+		"details": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+	},
+}
+
+func jsonContent(schema any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// messageSchema registers the schema of a message in the components, and returns a reference to it. Fields set from
+// the path are omitted from request bodies, so the schema is then inlined.
+func messageSchema(message protoreflect.MessageDescriptor, omit []string, schemas map[string]any) map[string]any {
+	if schema, isWellKnown := wellKnownSchemas[message.FullName()]; isWellKnown {
+		return schema
+	}
+
+	properties := map[string]any{}
+	fields := message.Fields()
+
+	for index := range fields.Len() {
+		field := fields.Get(index)
+		if slices.Contains(omit, field.JSONName()) || slices.Contains(omit, string(field.Name())) {
+			continue
+		}
+
+		properties[field.JSONName()] = kindSchema(field, schemas)
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(omit) > 0 {
+		return schema
+	}
+
+	name := string(message.FullName())
+	schemas[name] = schema
+
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func fieldSchema(message protoreflect.MessageDescriptor, name string, schemas map[string]any) map[string]any {
+	field := findField(message, name)
+	if field == nil {
+		return map[string]any{"type": "string"}
+	}
+
+	return kindSchema(field, schemas)
+}
+
+// 64-bit integers are encoded as strings in JSON. Unsigned 32-bit integers do not fit in the int32 format.
+var scalarSchemas = map[protoreflect.Kind]map[string]any{
+	protoreflect.BoolKind:     {"type": "boolean"},
+	protoreflect.StringKind:   {"type": "string"},
+	protoreflect.BytesKind:    {"type": "string", "format": "byte"},
+	protoreflect.FloatKind:    {"type": "number"},
+	protoreflect.DoubleKind:   {"type": "number"},
+	protoreflect.Int32Kind:    {"type": "integer", "format": "int32"},
+	protoreflect.Sint32Kind:   {"type": "integer", "format": "int32"},
+	protoreflect.Sfixed32Kind: {"type": "integer", "format": "int32"},
+	protoreflect.Uint32Kind:   {"type": "integer", "format": "int64", "minimum": 0},
+	protoreflect.Fixed32Kind:  {"type": "integer", "format": "int64", "minimum": 0},
+	protoreflect.Int64Kind:    {"type": "string", "format": "int64"},
+	protoreflect.Sint64Kind:   {"type": "string", "format": "int64"},
+	protoreflect.Sfixed64Kind: {"type": "string", "format": "int64"},
+	protoreflect.Uint64Kind:   {"type": "string", "format": "int64"},
+	protoreflect.Fixed64Kind:  {"type": "string", "format": "int64"},
+}
+
+func kindSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	var schema map[string]any
+
+	switch field.Kind() { //nolint:exhaustive
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+
+		names := make([]string, values.Len())
+		for index := range values.Len() {
+			names[index] = string(values.Get(index).Name())
+		}
+
+		schema = map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		schema = messageSchema(field.Message(), nil, schemas)
+	default:
+		schema = scalarSchemas[field.Kind()]
+	}
+
+	if field.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+
+	return schema
+}
@@ -0,0 +1,116 @@
+package gateway_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/gateway"
+)
+
+type openAPIParameter struct {
+	Name string `json:"name"`
+	In   string `json:"in"`
+}
+
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Paths   map[string]map[string]struct {
+		OperationID string             `json:"operationId"`
+		Parameters  []openAPIParameter `json:"parameters"`
+		RequestBody *struct {
+			Content map[string]struct {
+				Schema struct {
+					Properties map[string]any `json:"properties"`
+				} `json:"schema"`
+			} `json:"content"`
+		} `json:"requestBody"`
+		Responses map[string]any `json:"responses"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]any `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestOpenAPI(t *testing.T) {
+	handler, _ := newGatewayHandler(t, nil)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, gateway.OpenAPIPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var document openAPIDocument
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+
+	require.Equal(t, gateway.OpenAPIVersion, document.OpenAPI)
+	require.Len(t, document.Paths, 2)
+	require.Len(t, document.Paths["/v1/namespaces/{namespace}/passkeys/{id}"], 3)
+
+	create := document.Paths["/v1/namespaces/{namespace}/passkeys"]["post"]
+	require.Equal(t, "passkeys.v1.CreateService.Exec", create.OperationID)
+	require.Contains(t, create.Responses, "201")
+
+	// The namespace is read from the path, so it is not part of the body.
+	require.NotNil(t, create.RequestBody)
+	require.Equal(
+		t,
+		[]string{"expiresIn", "reward"},
+		sortedKeys(create.RequestBody.Content["application/json"].Schema.Properties),
+	)
+
+	parameters := make(map[string]string)
+	for _, parameter := range create.Parameters {
+		parameters[parameter.Name] = parameter.In
+	}
+
+	require.Equal(t, map[string]string{
+		"namespace":       "path",
+		"password":        "header",
+		"passkey-id":      "header",
+		"idempotency-key": "header",
+		"tenant":          "header",
+		"authorization":   "header",
+	}, parameters)
+
+	get := document.Paths["/v1/namespaces/{namespace}/passkeys/{id}"]["get"]
+	require.Nil(t, get.RequestBody)
+	require.True(t, slices.ContainsFunc(get.Parameters, func(parameter openAPIParameter) bool {
+		return parameter.Name == "idempotency-key"
+	}))
+
+	// Updates and deletions do not go through the idempotency interceptor.
+	for _, method := range []string{"patch", "delete"} {
+		operation := document.Paths["/v1/namespaces/{namespace}/passkeys/{id}"][method]
+		require.False(t, slices.ContainsFunc(operation.Parameters, func(parameter openAPIParameter) bool {
+			return parameter.Name == "idempotency-key"
+		}), method)
+	}
+	require.Contains(t, document.Components.Schemas, "passkeys.v1.GetServiceExecResponse")
+}
+
+func TestOpenAPIStable(t *testing.T) {
+	handler, _ := newGatewayHandler(t, nil)
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, gateway.OpenAPIPath, nil))
+
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, httptest.NewRequest(http.MethodGet, gateway.OpenAPIPath, nil))
+
+	require.Equal(t, first.Body.String(), second.Body.String())
+}
+
+func sortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
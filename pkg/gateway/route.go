@@ -0,0 +1,164 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+)
+
+// Header documents a request header, that is forwarded to the gRPC handlers as metadata of the same name.
+type Header struct {
+	Name        string
+	Description string
+}
+
+var (
+	HeaderPasskey = Header{
+		Name:        "password",
+		Description: "The secret of the passkey.",
+	}
+	HeaderCurrentPasskey = Header{
+		Name:        "current-password",
+		Description: "The current secret of the passkey. When sent, the update is rejected if it does not match.",
+	}
+	HeaderPasskeyID = Header{
+		Name:        "passkey-id",
+		Description: "The ID to give to the new passkey. A random ID is generated when omitted.",
+	}
+	HeaderUpdateMask = Header{
+		Name:        "update-mask",
+		Description: "Comma-separated list of the fields to update. Every field is updated when omitted.",
+	}
+	HeaderExpectedVersion = Header{
+		Name:        "expected-version",
+		Description: "Reject the update if the passkey no longer has this version.",
+	}
+	HeaderIdempotencyKey = Header{
+		Name:        handlers.IdempotencyKeyHeader,
+		Description: "Replay the response of a previous request sent with the same key.",
+	}
+	HeaderTenant = Header{
+		Name:        handlers.TenantHeader,
+		Description: "The tenant of the request, when callers are not authenticated by the service.",
+	}
+	HeaderAuthorization = Header{
+		Name:        handlers.AuthorizationHeader,
+		Description: "A bearer token, when the service authenticates callers with JWT.",
+	}
+)
+
+// CommonHeaders are accepted by every route.
+var CommonHeaders = []Header{HeaderTenant, HeaderAuthorization}
+
+// Route exposes a gRPC method as a JSON endpoint.
+type Route struct {
+	// Method and Pattern follow the syntax of http.ServeMux. Wildcards of the pattern are copied to the request
+	// fields of the same name.
+	Method  string
+	Pattern string
+	// FullMethod is the name of the gRPC method, as seen by the interceptors.
+	FullMethod string
+	Summary    string
+	// SuccessStatus is the HTTP status of successful responses. Defaults to http.StatusOK.
+	SuccessStatus int
+
+	// Query lists the request fields read from the query string.
+	Query []string
+	// Body reads the remaining request fields from a JSON body.
+	Body bool
+	// Headers lists the headers accepted by the route, in addition to CommonHeaders. HeaderIdempotencyKey is added
+	// to the methods listed in handlers.IdempotentMethods.
+	Headers []Header
+
+	newRequest  func() proto.Message
+	newResponse func() proto.Message
+	invoke      func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+// NewRoute binds a route to the Exec method of a gRPC handler.
+func NewRoute[In proto.Message, Out proto.Message](
+	route Route, exec func(ctx context.Context, req In) (Out, error),
+) Route {
+	var (
+		zeroIn  In
+		zeroOut Out
+	)
+
+	if _, idempotent := handlers.IdempotentMethods[route.FullMethod]; idempotent {
+		route.Headers = append(slices.Clone(route.Headers), HeaderIdempotencyKey)
+	}
+
+	route.newRequest = func() proto.Message { return zeroIn.ProtoReflect().New().Interface() }
+	route.newResponse = func() proto.Message { return zeroOut.ProtoReflect().New().Interface() }
+	route.invoke = func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		typed, _ := req.(In)
+
+		return exec(ctx, typed)
+	}
+
+	return route
+}
+
+// PasskeyRoutes exposes the passkeys services.
+func PasskeyRoutes(
+	create handlers.CreatePasskey,
+	get handlers.GetPasskey,
+	update handlers.UpdatePasskey,
+	del handlers.DeletePasskey,
+) []Route {
+	return []Route{
+		NewRoute(Route{
+			Method:        http.MethodPost,
+			Pattern:       "/v1/namespaces/{namespace}/passkeys",
+			FullMethod:    passkeysv1grpc.CreateService_Exec_FullMethodName,
+			Summary:       "Create a passkey.",
+			SuccessStatus: http.StatusCreated,
+			Body:          true,
+			Headers:       []Header{HeaderPasskey, HeaderPasskeyID},
+		}, create.Exec),
+		NewRoute(Route{
+			Method:     http.MethodGet,
+			Pattern:    "/v1/namespaces/{namespace}/passkeys/{id}",
+			FullMethod: passkeysv1grpc.GetService_Exec_FullMethodName,
+			Summary:    "Get a passkey. The passkey is checked against the password header when validate is set.",
+			Query:      []string{"validate"},
+			Headers:    []Header{HeaderPasskey},
+		}, get.Exec),
+		NewRoute(Route{
+			Method:     http.MethodPatch,
+			Pattern:    "/v1/namespaces/{namespace}/passkeys/{id}",
+			FullMethod: passkeysv1grpc.UpdateService_Exec_FullMethodName,
+			Summary:    "Update a passkey. The password header sets a new secret.",
+			Body:       true,
+			Headers:    []Header{HeaderPasskey, HeaderCurrentPasskey, HeaderUpdateMask, HeaderExpectedVersion},
+		}, update.Exec),
+		NewRoute(Route{
+			Method:     http.MethodDelete,
+			Pattern:    "/v1/namespaces/{namespace}/passkeys/{id}",
+			FullMethod: passkeysv1grpc.DeleteService_Exec_FullMethodName,
+			Summary:    "Delete a passkey. The passkey is checked against the password header when validate is set.",
+			Query:      []string{"validate"},
+			Headers:    []Header{HeaderPasskey},
+		}, del.Exec),
+	}
+}
+
+// ListPasskeyRoutes exposes the listings service. It is only served with Postgres, like the service itself.
+func ListPasskeyRoutes(list handlers.ListPasskeys) []Route {
+	return []Route{
+		NewRoute(Route{
+			Method:     http.MethodGet,
+			Pattern:    "/v1/namespaces/{namespace}/passkeys",
+			FullMethod: listingsv1.ListService_Exec_FullMethodName,
+			Summary:    "List the active passkeys of a namespace, ordered by ID.",
+			Query:      []string{"limit", "afterNamespace", "afterId"},
+		}, list.Exec),
+	}
+}
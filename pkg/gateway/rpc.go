@@ -0,0 +1,48 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/vanguard"
+	googlegrpc "google.golang.org/grpc"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+)
+
+// PasskeyServices are served over the Connect and gRPC-Web protocols. The listings service is only registered with
+// Postgres, and reports Unimplemented otherwise, like over gRPC.
+var PasskeyServices = []string{
+	passkeysv1grpc.CreateService_ServiceDesc.ServiceName,
+	passkeysv1grpc.GetService_ServiceDesc.ServiceName,
+	passkeysv1grpc.UpdateService_ServiceDesc.ServiceName,
+	passkeysv1grpc.DeleteService_ServiceDesc.ServiceName,
+	listingsv1.ListService_ServiceDesc.ServiceName,
+}
+
+// NewRPCHandler serves services of a gRPC server over the Connect and gRPC-Web protocols, so browsers can call them
+// without a proxy. Calls are transcoded to gRPC and handled by the server itself, so they go through its
+// interceptors. Requests that do not target one of the services are sent to fallback.
+func NewRPCHandler(server *googlegrpc.Server, services []string, fallback http.Handler) (http.Handler, error) {
+	transcoded := make([]*vanguard.Service, len(services))
+	for index, service := range services {
+		transcoded[index] = vanguard.NewService(service, server)
+	}
+
+	transcoder, err := vanguard.NewTranscoder(
+		transcoded,
+		vanguard.WithUnknownHandler(fallback),
+		// The gRPC server only understands the gRPC protocol, with the binary encoding.
+		vanguard.WithDefaultServiceOptions(
+			vanguard.WithTargetProtocols(vanguard.ProtocolGRPC),
+			vanguard.WithTargetCodecs(vanguard.CodecProto),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create transcoder: %w", err)
+	}
+
+	return transcoder, nil
+}
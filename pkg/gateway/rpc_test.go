@@ -0,0 +1,192 @@
+package gateway_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	googlegrpc "google.golang.org/grpc"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/gateway"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func newRPCHandler(t *testing.T) (http.Handler, *servicesmocks.MockGetPasskey) {
+	t.Helper()
+
+	getPasskey := servicesmocks.NewMockGetPasskey(t)
+
+	logger := adaptersmocks.NewMockGRPC(t)
+	logger.On("Report", mock.Anything, mock.Anything).Maybe()
+
+	server := googlegrpc.NewServer()
+	passkeysv1grpc.RegisterGetServiceServer(server, handlers.NewGetPasskey(getPasskey, logger))
+
+	fallback := http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusTeapot)
+	})
+
+	handler, err := gateway.NewRPCHandler(
+		server, []string{passkeysv1grpc.GetService_ServiceDesc.ServiceName}, fallback,
+	)
+	require.NoError(t, err)
+
+	return handler, getPasskey
+}
+
+func TestRPCHandler(t *testing.T) {
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+
+		target string
+		body   string
+
+		setup func(getPasskey *servicesmocks.MockGetPasskey)
+
+		expectStatus  int
+		expectBody    map[string]any
+		expectVersion string
+	}{
+		{
+			name: "Connect",
+
+			target: passkeysv1grpc.GetService_Exec_FullMethodName,
+			body:   `{"id":"` + passkeyID + `","namespace":"namespace","validate":true}`,
+
+			setup: func(getPasskey *servicesmocks.MockGetPasskey) {
+				getPasskey.
+					On("Exec", mock.Anything, &services.GetPasskeyRequest{
+						ID:        passkeyID,
+						Namespace: "namespace",
+						Passkey:   "passkey",
+						Validate:  true,
+					}).
+					Return(&services.GetPasskeyResponse{
+						ID:        passkeyID,
+						Namespace: "namespace",
+						CreatedAt: createdAt,
+						Version:   3,
+					}, nil)
+			},
+
+			expectStatus: http.StatusOK,
+			expectBody: map[string]any{
+				"id":        passkeyID,
+				"namespace": "namespace",
+				"createdAt": "2021-01-01T00:00:00Z",
+				"updatedAt": nil,
+			},
+			expectVersion: "3",
+		},
+		{
+			name: "Connect/NotFound",
+
+			target: passkeysv1grpc.GetService_Exec_FullMethodName,
+			body:   `{"id":"` + passkeyID + `","namespace":"namespace"}`,
+
+			setup: func(getPasskey *servicesmocks.MockGetPasskey) {
+				getPasskey.
+					On("Exec", mock.Anything, mock.Anything).
+					Return(nil, dao.ErrPasskeyNotFound)
+			},
+
+			expectStatus: http.StatusNotFound,
+			expectBody: map[string]any{
+				"code":    "not_found",
+				"message": dao.ErrPasskeyNotFound.Error(),
+			},
+		},
+		{
+			name: "UnknownService",
+
+			target: passkeysv1grpc.DeleteService_Exec_FullMethodName,
+			body:   `{}`,
+
+			expectStatus: http.StatusTeapot,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler, getPasskey := newRPCHandler(t)
+
+			if testCase.setup != nil {
+				testCase.setup(getPasskey)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, testCase.target, strings.NewReader(testCase.body))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Connect-Protocol-Version", "1")
+			request.Header.Set("Password", "passkey")
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.expectStatus, recorder.Code, recorder.Body.String())
+			require.Equal(t, testCase.expectVersion, recorder.Header().Get(handlers.VersionHeader))
+
+			if testCase.expectBody != nil {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, testCase.expectBody, body)
+			}
+		})
+	}
+}
+
+func TestRPCHandlerGRPCWeb(t *testing.T) {
+	handler, getPasskey := newRPCHandler(t)
+
+	getPasskey.
+		On("Exec", mock.Anything, mock.Anything).
+		Return(nil, dao.ErrPasskeyNotFound)
+
+	// An empty request message, framed as gRPC-Web.
+	request := httptest.NewRequest(
+		http.MethodPost, passkeysv1grpc.GetService_Exec_FullMethodName, strings.NewReader("\x00\x00\x00\x00\x00"),
+	)
+	request.Header.Set("Content-Type", "application/grpc-web+proto")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	// The call fails before any message is sent, so the status is sent in the headers.
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "5", recorder.Header().Get("Grpc-Status"))
+}
+
+func TestRPCHandlerPasskeyServices(t *testing.T) {
+	fallback := http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusTeapot)
+	})
+
+	// The listings service is not registered, as without Postgres.
+	handler, err := gateway.NewRPCHandler(googlegrpc.NewServer(), gateway.PasskeyServices, fallback)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(
+		http.MethodPost, listingsv1.ListService_Exec_FullMethodName, strings.NewReader(`{"limit":10}`),
+	)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Connect-Protocol-Version", "1")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusNotImplemented, recorder.Code, recorder.Body.String())
+}
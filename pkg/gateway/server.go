@@ -0,0 +1,356 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"sync"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OpenAPIPath serves the OpenAPI document of the routes.
+const OpenAPIPath = "/openapi.json"
+
+// MaxBodySize is the maximum size of a request body, in bytes.
+const MaxBodySize = 1 << 20
+
+var (
+	ErrUnknownField = errors.New("unknown field")
+	ErrInvalidField = errors.New("invalid field")
+)
+
+var wildcardPattern = regexp.MustCompile(`\{([^}.]+)(?:\.\.\.)?\}`)
+
+var marshalOptions = protojson.MarshalOptions{}
+
+// Codes that are not listed map to http.StatusInternalServerError.
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499, // Client Closed Request, as used by most proxies.
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// HTTPStatusFromCode converts a gRPC status code to the closest HTTP status.
+func HTTPStatusFromCode(code codes.Code) int {
+	if httpStatus, found := httpStatuses[code]; found {
+		return httpStatus
+	}
+
+	return http.StatusInternalServerError
+}
+
+// ChainUnaryInterceptors combines interceptors in a single one, that runs them in order.
+func ChainUnaryInterceptors(interceptors ...googlegrpc.UnaryServerInterceptor) googlegrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+	) (any, error) {
+		chained := handler
+
+		for index := len(interceptors) - 1; index >= 0; index-- {
+			interceptor, next := interceptors[index], chained
+			chained = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return chained(ctx, req)
+	}
+}
+
+// NewHandler serves the routes, and their OpenAPI document. Requests go through the interceptor like regular gRPC
+// calls, so authentication, tenancy and idempotency behave the same on both servers. The interceptor may be nil.
+func NewHandler(
+	routes []Route, interceptor googlegrpc.UnaryServerInterceptor, info OpenAPIInfo,
+) (http.Handler, error) {
+	mux := http.NewServeMux()
+
+	for _, route := range routes {
+		mux.Handle(route.Method+" "+route.Pattern, &routeHandler{route: route, interceptor: interceptor})
+	}
+
+	document, err := OpenAPI(routes, info)
+	if err != nil {
+		return nil, fmt.Errorf("generate OpenAPI document: %w", err)
+	}
+
+	mux.HandleFunc(http.MethodGet+" "+OpenAPIPath, func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write(document)
+	})
+
+	return mux, nil
+}
+
+type routeHandler struct {
+	route       Route
+	interceptor googlegrpc.UnaryServerInterceptor
+}
+
+func (handler *routeHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	req, err := handler.decode(writer, request)
+	if err != nil {
+		writeError(writer, status.Error(codes.InvalidArgument, err.Error()))
+
+		return
+	}
+
+	stream := &transportStream{method: handler.route.FullMethod, header: metadata.MD{}}
+
+	// Headers are forwarded as metadata, and the client certificate as the peer of the request.
+	ctx := metadata.NewIncomingContext(request.Context(), forwardedMetadata(request))
+	ctx = peer.NewContext(ctx, requestPeer(request))
+	ctx = googlegrpc.NewContextWithServerTransportStream(ctx, stream)
+
+	invoke := func(ctx context.Context, req any) (any, error) {
+		message, _ := req.(proto.Message)
+
+		return handler.route.invoke(ctx, message)
+	}
+
+	var res any
+	if handler.interceptor != nil {
+		res, err = handler.interceptor(ctx, req, &googlegrpc.UnaryServerInfo{FullMethod: handler.route.FullMethod}, invoke)
+	} else {
+		res, err = invoke(ctx, req)
+	}
+
+	for key, values := range stream.collected() {
+		for _, value := range values {
+			writer.Header().Add(key, value)
+		}
+	}
+
+	if err != nil {
+		writeError(writer, err)
+
+		return
+	}
+
+	message, _ := res.(proto.Message)
+
+	body, err := marshalOptions.Marshal(message)
+	if err != nil {
+		writeError(writer, status.Errorf(codes.Internal, "encode response: %v", err))
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(successStatus(handler.route))
+	_, _ = writer.Write(body)
+}
+
+// decode builds the request message from the body, then the query string and the path.
+func (handler *routeHandler) decode(writer http.ResponseWriter, request *http.Request) (proto.Message, error) {
+	req := handler.route.newRequest()
+
+	if handler.route.Body {
+		body, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, MaxBodySize))
+		if err != nil {
+			return nil, fmt.Errorf("read body: %w", err)
+		}
+
+		if len(body) > 0 {
+			if err := protojson.Unmarshal(body, req); err != nil {
+				return nil, fmt.Errorf("decode body: %w", err)
+			}
+		}
+	}
+
+	for _, name := range handler.route.Query {
+		if !request.URL.Query().Has(name) {
+			continue
+		}
+
+		if err := setField(req, name, request.URL.Query().Get(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, match := range wildcardPattern.FindAllStringSubmatch(handler.route.Pattern, -1) {
+		if err := setField(req, match[1], request.PathValue(match[1])); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+func forwardedMetadata(request *http.Request) metadata.MD {
+	incoming := metadata.MD{}
+
+	for key, values := range request.Header {
+		incoming.Append(key, values...)
+	}
+
+	return incoming
+}
+
+func requestPeer(request *http.Request) *peer.Peer {
+	caller := &peer.Peer{Addr: remoteAddr(request)}
+	if request.TLS != nil {
+		caller.AuthInfo = credentials.TLSInfo{State: *request.TLS}
+	}
+
+	return caller
+}
+
+func remoteAddr(request *http.Request) net.Addr {
+	addr, err := net.ResolveTCPAddr("tcp", request.RemoteAddr)
+	if err != nil {
+		return &net.TCPAddr{}
+	}
+
+	return addr
+}
+
+type scalarParser func(value string) (protoreflect.Value, error)
+
+func parseInt64(value string) (protoreflect.Value, error) {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+
+	return protoreflect.ValueOfInt64(parsed), err
+}
+
+func parseInt32(value string) (protoreflect.Value, error) {
+	parsed, err := strconv.ParseInt(value, 10, 32)
+
+	return protoreflect.ValueOfInt32(int32(parsed)), err
+}
+
+// Fields of other kinds cannot be set from the path or the query string.
+var scalarParsers = map[protoreflect.Kind]scalarParser{
+	protoreflect.StringKind: func(value string) (protoreflect.Value, error) {
+		return protoreflect.ValueOfString(value), nil
+	},
+	protoreflect.BoolKind: func(value string) (protoreflect.Value, error) {
+		parsed, err := strconv.ParseBool(value)
+
+		return protoreflect.ValueOfBool(parsed), err
+	},
+	protoreflect.Int64Kind:    parseInt64,
+	protoreflect.Sint64Kind:   parseInt64,
+	protoreflect.Sfixed64Kind: parseInt64,
+	protoreflect.Int32Kind:    parseInt32,
+	protoreflect.Sint32Kind:   parseInt32,
+	protoreflect.Sfixed32Kind: parseInt32,
+}
+
+func findField(message protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if field := message.Fields().ByJSONName(name); field != nil {
+		return field
+	}
+
+	return message.Fields().ByName(protoreflect.Name(name))
+}
+
+func setField(message proto.Message, name, value string) error {
+	field := findField(message.ProtoReflect().Descriptor(), name)
+	if field == nil || field.IsList() || field.IsMap() {
+		return fmt.Errorf("%w: %s", ErrUnknownField, name)
+	}
+
+	parse, found := scalarParsers[field.Kind()]
+	if !found {
+		return fmt.Errorf("%w: %s", ErrUnknownField, name)
+	}
+
+	parsed, err := parse(value)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidField, name, err)
+	}
+
+	message.ProtoReflect().Set(field, parsed)
+
+	return nil
+}
+
+func successStatus(route Route) int {
+	if route.SuccessStatus == 0 {
+		return http.StatusOK
+	}
+
+	return route.SuccessStatus
+}
+
+// writeError sends the status of an error as a google.rpc.Status JSON object.
+func writeError(writer http.ResponseWriter, err error) {
+	current := status.Convert(err)
+
+	body, marshalErr := marshalOptions.Marshal(current.Proto())
+	if marshalErr != nil {
+		body = []byte(`{"code":13,"message":"encode error"}`)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(HTTPStatusFromCode(current.Code()))
+	_, _ = writer.Write(body)
+}
+
+// transportStream collects the headers set by the gRPC handlers, so they can be sent as HTTP headers.
+type transportStream struct {
+	method string
+
+	mu     sync.Mutex
+	header metadata.MD
+}
+
+func (stream *transportStream) Method() string {
+	return stream.method
+}
+
+func (stream *transportStream) SetHeader(md metadata.MD) error {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+
+	stream.header = metadata.Join(stream.header, md)
+
+	return nil
+}
+
+func (stream *transportStream) SendHeader(md metadata.MD) error {
+	return stream.SetHeader(md)
+}
+
+// SetTrailer is a no-op, as trailers are not forwarded.
+func (stream *transportStream) SetTrailer(_ metadata.MD) error {
+	return nil
+}
+
+func (stream *transportStream) collected() map[string][]string {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+
+	output := make(map[string][]string, len(stream.header))
+	for key, values := range stream.header {
+		output[textproto.CanonicalMIMEHeaderKey(key)] = values
+	}
+
+	return output
+}
@@ -0,0 +1,354 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/gateway"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+const passkeyID = "00000000-0000-0000-0000-000000000001"
+
+type gatewayMocks struct {
+	create *servicesmocks.MockCreatePasskey
+	get    *servicesmocks.MockGetPasskey
+	update *servicesmocks.MockUpdatePasskey
+	delete *servicesmocks.MockDeletePasskey
+	list   *servicesmocks.MockListPasskeys
+}
+
+func newGatewayHandler(
+	t *testing.T, interceptor googlegrpc.UnaryServerInterceptor,
+) (http.Handler, *gatewayMocks) {
+	t.Helper()
+
+	mocks := &gatewayMocks{
+		create: servicesmocks.NewMockCreatePasskey(t),
+		get:    servicesmocks.NewMockGetPasskey(t),
+		update: servicesmocks.NewMockUpdatePasskey(t),
+		delete: servicesmocks.NewMockDeletePasskey(t),
+		list:   servicesmocks.NewMockListPasskeys(t),
+	}
+
+	logger := adaptersmocks.NewMockGRPC(t)
+	logger.On("Report", mock.Anything, mock.Anything).Maybe()
+
+	routes := gateway.PasskeyRoutes(
+		handlers.NewCreatePasskey(mocks.create, logger),
+		handlers.NewGetPasskey(mocks.get, logger),
+		handlers.NewUpdatePasskey(mocks.update, logger),
+		handlers.NewDeletePasskey(mocks.delete, logger),
+	)
+	routes = append(routes, gateway.ListPasskeyRoutes(handlers.NewListPasskeys(mocks.list, logger))...)
+
+	handler, err := gateway.NewHandler(
+		routes,
+		interceptor,
+		gateway.OpenAPIInfo{Title: "Passkeys", Version: "v1"},
+	)
+	require.NoError(t, err)
+
+	return handler, mocks
+}
+
+func TestHandler(t *testing.T) {
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+
+		method  string
+		target  string
+		headers map[string]string
+		body    string
+
+		setup func(mocks *gatewayMocks)
+
+		expectStatus  int
+		expectBody    map[string]any
+		expectVersion string
+	}{
+		{
+			name: "Create",
+
+			method: http.MethodPost,
+			target: "/v1/namespaces/namespace/passkeys",
+			headers: map[string]string{
+				"Password":   "passkey",
+				"Passkey-Id": passkeyID,
+			},
+			body: `{"reward":{"type":"reward"},"expiresIn":"3600s"}`,
+
+			setup: func(mocks *gatewayMocks) {
+				mocks.create.
+					On("Exec", mock.Anything, &services.CreatePasskeyRequest{
+						ID:        passkeyID,
+						Namespace: "namespace",
+						Passkey:   "passkey",
+						Reward:    map[string]any{"type": "reward"},
+						ExpiresIn: lo.ToPtr(time.Hour),
+					}).
+					Return(&services.CreatePasskeyResponse{
+						ID:        passkeyID,
+						Namespace: "namespace",
+						Reward:    map[string]any{"type": "reward"},
+						CreatedAt: createdAt,
+						Version:   1,
+					}, nil)
+			},
+
+			expectStatus: http.StatusCreated,
+			expectBody: map[string]any{
+				"id":        passkeyID,
+				"namespace": "namespace",
+				"reward":    map[string]any{"type": "reward"},
+				"createdAt": "2021-01-01T00:00:00Z",
+			},
+			expectVersion: "1",
+		},
+		{
+			name: "Get",
+
+			method:  http.MethodGet,
+			target:  "/v1/namespaces/namespace/passkeys/" + passkeyID + "?validate=true",
+			headers: map[string]string{"Password": "passkey"},
+
+			setup: func(mocks *gatewayMocks) {
+				mocks.get.
+					On("Exec", mock.Anything, &services.GetPasskeyRequest{
+						ID:        passkeyID,
+						Namespace: "namespace",
+						Passkey:   "passkey",
+						Validate:  true,
+					}).
+					Return(&services.GetPasskeyResponse{
+						ID:        passkeyID,
+						Namespace: "namespace",
+						CreatedAt: createdAt,
+						Version:   3,
+					}, nil)
+			},
+
+			expectStatus: http.StatusOK,
+			expectBody: map[string]any{
+				"id":        passkeyID,
+				"namespace": "namespace",
+				"createdAt": "2021-01-01T00:00:00Z",
+			},
+			expectVersion: "3",
+		},
+		{
+			name: "Get/NotFound",
+
+			method: http.MethodGet,
+			target: "/v1/namespaces/namespace/passkeys/" + passkeyID,
+
+			setup: func(mocks *gatewayMocks) {
+				mocks.get.
+					On("Exec", mock.Anything, mock.Anything).
+					Return(nil, dao.ErrPasskeyNotFound)
+			},
+
+			expectStatus: http.StatusNotFound,
+			expectBody: map[string]any{
+				"code":    float64(codes.NotFound),
+				"message": dao.ErrPasskeyNotFound.Error(),
+			},
+		},
+		{
+			name: "Get/InvalidQuery",
+
+			method: http.MethodGet,
+			target: "/v1/namespaces/namespace/passkeys/" + passkeyID + "?validate=maybe",
+
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Create/InvalidBody",
+
+			method: http.MethodPost,
+			target: "/v1/namespaces/namespace/passkeys",
+			body:   `{"unknown":true}`,
+
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Delete/InvalidPasskey",
+
+			method:  http.MethodDelete,
+			target:  "/v1/namespaces/namespace/passkeys/" + passkeyID + "?validate=true",
+			headers: map[string]string{"Password": "passkey", "Expected-Version": "2"},
+
+			setup: func(mocks *gatewayMocks) {
+				mocks.delete.
+					On("Exec", mock.Anything, &services.DeletePasskeyRequest{
+						ID:              passkeyID,
+						Namespace:       "namespace",
+						Passkey:         "passkey",
+						Validate:        true,
+						ExpectedVersion: lo.ToPtr[int64](2),
+					}).
+					Return(nil, dao.ErrInvalidPasskey)
+			},
+
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name: "List",
+
+			method: http.MethodGet,
+			target: "/v1/namespaces/namespace/passkeys?limit=10&afterNamespace=namespace&afterId=" + passkeyID,
+
+			setup: func(mocks *gatewayMocks) {
+				mocks.list.
+					On("Exec", mock.Anything, &services.ListPasskeysRequest{
+						Namespace:      "namespace",
+						AfterNamespace: "namespace",
+						AfterID:        passkeyID,
+						Limit:          10,
+					}).
+					Return(&services.ListPasskeysResponse{
+						Passkeys: []*services.ListedPasskey{
+							{ID: passkeyID, Namespace: "namespace", CreatedAt: createdAt, Version: 2},
+						},
+					}, nil)
+			},
+
+			expectStatus: http.StatusOK,
+			expectBody: map[string]any{
+				"passkeys": []any{
+					map[string]any{
+						"id":        passkeyID,
+						"namespace": "namespace",
+						"createdAt": "2021-01-01T00:00:00Z",
+						"version":   "2",
+					},
+				},
+			},
+		},
+		{
+			name: "UnknownRoute",
+
+			method: http.MethodPut,
+			target: "/v1/namespaces/namespace/passkeys",
+
+			expectStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler, mocks := newGatewayHandler(t, nil)
+
+			if testCase.setup != nil {
+				testCase.setup(mocks)
+			}
+
+			request := httptest.NewRequest(testCase.method, testCase.target, strings.NewReader(testCase.body))
+			for key, value := range testCase.headers {
+				request.Header.Set(key, value)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.expectStatus, recorder.Code, recorder.Body.String())
+			require.Equal(t, testCase.expectVersion, recorder.Header().Get(handlers.VersionHeader))
+
+			if testCase.expectBody != nil {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, testCase.expectBody, body)
+			}
+		})
+	}
+}
+
+func TestHandlerInterceptor(t *testing.T) {
+	var (
+		fullMethod string
+		tenant     []string
+	)
+
+	interceptor := func(
+		ctx context.Context, _ any, info *googlegrpc.UnaryServerInfo, _ googlegrpc.UnaryHandler,
+	) (any, error) {
+		fullMethod = info.FullMethod
+		incoming, _ := metadata.FromIncomingContext(ctx)
+		tenant = incoming.Get(handlers.TenantHeader)
+
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+
+	handler, _ := newGatewayHandler(t, interceptor)
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/namespaces/namespace/passkeys/"+passkeyID, nil)
+	request.Header.Set("Tenant", "tenant")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Equal(t, passkeysv1grpc.GetService_Exec_FullMethodName, fullMethod)
+	require.Equal(t, []string{"tenant"}, tenant)
+}
+
+func TestChainUnaryInterceptors(t *testing.T) {
+	var calls []string
+
+	record := func(name string) googlegrpc.UnaryServerInterceptor {
+		return func(
+			ctx context.Context, req any, _ *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+		) (any, error) {
+			calls = append(calls, name)
+
+			return handler(ctx, req)
+		}
+	}
+
+	res, err := gateway.ChainUnaryInterceptors(record("first"), record("second"))(
+		context.Background(),
+		"request",
+		&googlegrpc.UnaryServerInfo{},
+		func(_ context.Context, req any) (any, error) {
+			calls = append(calls, "handler")
+
+			return req, nil
+		},
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, "request", res)
+	require.Equal(t, []string{"first", "second", "handler"}, calls)
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	require.Equal(t, http.StatusOK, gateway.HTTPStatusFromCode(codes.OK))
+	require.Equal(t, http.StatusBadRequest, gateway.HTTPStatusFromCode(codes.InvalidArgument))
+	require.Equal(t, http.StatusNotFound, gateway.HTTPStatusFromCode(codes.NotFound))
+	require.Equal(t, http.StatusPreconditionFailed, gateway.HTTPStatusFromCode(codes.FailedPrecondition))
+	require.Equal(t, http.StatusTooManyRequests, gateway.HTTPStatusFromCode(codes.ResourceExhausted))
+	require.Equal(t, http.StatusInternalServerError, gateway.HTTPStatusFromCode(codes.Internal))
+	require.Equal(t, http.StatusInternalServerError, gateway.HTTPStatusFromCode(codes.Code(42)))
+}
@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+)
+
+// Operation is an action a caller may be allowed to perform on a namespace.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationGet    Operation = "get"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+	OperationList   Operation = "list"
+)
+
+// AllNamespaces matches every namespace. Listing namespaces requires it, as the result spans all of them.
+const AllNamespaces = "*"
+
+var (
+	ErrNoClientCertificate = errors.New("no verified client certificate")
+	ErrUnknownCaller       = errors.New("unknown caller")
+	ErrAmbiguousTenant     = errors.New("caller maps to more than one tenant")
+	ErrInvalidPermissions  = errors.New("invalid permissions")
+	ErrMissingIdentity     = errors.New("missing identity")
+	ErrUnknownOperation    = errors.New("unknown operation")
+)
+
+// CallerPermissions describes what a caller, identified by its client certificate, is allowed to do.
+type CallerPermissions struct {
+	// Identity is matched against the URI, DNS and email SANs of the certificate, then against its common name.
+	Identity string `yaml:"identity"`
+	// Tenant the requests of the caller are restricted to.
+	Tenant string `yaml:"tenant"`
+	// Namespaces the caller may access. Patterns follow the syntax of path.Match, so "*" matches every namespace.
+	Namespaces []string `yaml:"namespaces"`
+	// Operations the caller may perform on those namespaces.
+	Operations []Operation `yaml:"operations"`
+}
+
+func (permissions *CallerPermissions) allows(operation Operation, namespace string) bool {
+	if !slices.Contains(permissions.Operations, operation) {
+		return false
+	}
+
+	return slices.ContainsFunc(permissions.Namespaces, func(pattern string) bool {
+		return matchNamespace(pattern, namespace)
+	})
+}
+
+// matchNamespace reports whether a namespace pattern grants access to a namespace.
+func matchNamespace(pattern, namespace string) bool {
+	if pattern == AllNamespaces {
+		return true
+	}
+
+	// Operations on every namespace require the wildcard itself.
+	if namespace == AllNamespaces {
+		return false
+	}
+
+	matched, err := path.Match(pattern, namespace)
+
+	return err == nil && matched
+}
+
+// Permissions maps the callers of the service to what they are allowed to do.
+type Permissions struct {
+	Callers []*CallerPermissions `yaml:"callers"`
+}
+
+// match returns the permissions of every identity in the certificate.
+func (permissions *Permissions) match(certificate *x509.Certificate) []*CallerPermissions {
+	identities := certificateIdentities(certificate)
+
+	var output []*CallerPermissions
+
+	for _, caller := range permissions.Callers {
+		if slices.Contains(identities, caller.Identity) {
+			output = append(output, caller)
+		}
+	}
+
+	return output
+}
+
+func (permissions *Permissions) validate() error {
+	validOperations := []Operation{OperationCreate, OperationGet, OperationUpdate, OperationDelete, OperationList}
+	tenants := make(map[string]string)
+
+	for i, caller := range permissions.Callers {
+		if caller.Identity == "" {
+			return fmt.Errorf("caller %d: %w", i, ErrMissingIdentity)
+		}
+
+		if len(caller.Tenant) > MaxTenantLength {
+			return fmt.Errorf("caller %s: %w", caller.Identity, ErrInvalidTenant)
+		}
+
+		if tenant, ok := tenants[caller.Identity]; ok && tenant != caller.Tenant {
+			return fmt.Errorf("caller %s: %w", caller.Identity, ErrAmbiguousTenant)
+		}
+
+		tenants[caller.Identity] = caller.Tenant
+
+		for _, operation := range caller.Operations {
+			if !slices.Contains(validOperations, operation) {
+				return fmt.Errorf("caller %s: %w: %q", caller.Identity, ErrUnknownOperation, operation)
+			}
+		}
+
+		for _, pattern := range caller.Namespaces {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("caller %s: namespace pattern %q: %w", caller.Identity, pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPermissions reads the permissions of callers from a YAML file.
+func LoadPermissions(file string) (*Permissions, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read permissions file: %w", err)
+	}
+
+	permissions := new(Permissions)
+	if err := yaml.Unmarshal(content, permissions); err != nil {
+		return nil, errors.Join(ErrInvalidPermissions, fmt.Errorf("decode permissions file: %w", err))
+	}
+
+	if err := permissions.validate(); err != nil {
+		return nil, errors.Join(ErrInvalidPermissions, err)
+	}
+
+	return permissions, nil
+}
+
+// methodPermission returns the operation performed by a request, and the namespace it targets.
+type methodPermission func(req any) (Operation, string)
+
+type namespaceRequest interface {
+	GetNamespace() string
+}
+
+type nameRequest interface {
+	GetName() string
+}
+
+func onNamespace(operation Operation) methodPermission {
+	return func(req any) (Operation, string) {
+		if request, ok := req.(namespaceRequest); ok {
+			return operation, request.GetNamespace()
+		}
+
+		return operation, ""
+	}
+}
+
+func onName(operation Operation) methodPermission {
+	return func(req any) (Operation, string) {
+		if request, ok := req.(nameRequest); ok {
+			return operation, request.GetName()
+		}
+
+		return operation, ""
+	}
+}
+
+// onNamespaceOrAll targets the namespace of the request, or every namespace when it has none.
+func onNamespaceOrAll(operation Operation) methodPermission {
+	return func(req any) (Operation, string) {
+		if request, ok := req.(namespaceRequest); ok && request.GetNamespace() != "" {
+			return operation, request.GetNamespace()
+		}
+
+		return operation, AllNamespaces
+	}
+}
+
+func onAllNamespaces(operation Operation) methodPermission {
+	return func(_ any) (Operation, string) {
+		return operation, AllNamespaces
+	}
+}
+
+// MethodPermissions lists the methods that require a permission. Revoking and restoring passkeys count as updates.
+// Methods missing from this list are denied, unless they are public.
+var MethodPermissions = map[string]methodPermission{
+	passkeysv1grpc.CreateService_Exec_FullMethodName: onNamespace(OperationCreate),
+	passkeysv1grpc.GetService_Exec_FullMethodName:    onNamespace(OperationGet),
+	passkeysv1grpc.UpdateService_Exec_FullMethodName: onNamespace(OperationUpdate),
+	passkeysv1grpc.DeleteService_Exec_FullMethodName: onNamespace(OperationDelete),
+
+	namespacesv1.CreateService_Exec_FullMethodName: onName(OperationCreate),
+	namespacesv1.GetService_Exec_FullMethodName:    onName(OperationGet),
+	namespacesv1.UpdateService_Exec_FullMethodName: onName(OperationUpdate),
+	namespacesv1.DeleteService_Exec_FullMethodName: onName(OperationDelete),
+	namespacesv1.ListService_Exec_FullMethodName:   onAllNamespaces(OperationList),
+
+	revocationsv1.RevokeService_Exec_FullMethodName:  onNamespace(OperationUpdate),
+	revocationsv1.RestoreService_Exec_FullMethodName: onNamespace(OperationUpdate),
+
+	listingsv1.ListService_Exec_FullMethodName: onNamespaceOrAll(OperationList),
+}
+
+// PublicServices can be called by any authenticated caller.
+var PublicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+func isPublicMethod(method string) bool {
+	return slices.ContainsFunc(PublicServices, func(prefix string) bool {
+		return strings.HasPrefix(method, prefix)
+	})
+}
+
+// certificateIdentities lists the identities a certificate can be matched with, SANs first.
+func certificateIdentities(certificate *x509.Certificate) []string {
+	identities := make(
+		[]string, 0, len(certificate.URIs)+len(certificate.DNSNames)+len(certificate.EmailAddresses)+1,
+	)
+
+	for _, uri := range certificate.URIs {
+		identities = append(identities, uri.String())
+	}
+
+	identities = append(identities, certificate.DNSNames...)
+	identities = append(identities, certificate.EmailAddresses...)
+
+	if certificate.Subject.CommonName != "" {
+		identities = append(identities, certificate.Subject.CommonName)
+	}
+
+	return identities
+}
+
+// ClientCertificate returns the verified certificate the caller of a request authenticated with.
+func ClientCertificate(ctx context.Context) (*x509.Certificate, error) {
+	caller, hasPeer := peer.FromContext(ctx)
+	if !hasPeer {
+		return nil, ErrNoClientCertificate
+	}
+
+	tlsInfo, isTLS := caller.AuthInfo.(credentials.TLSInfo)
+	if !isTLS || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, ErrNoClientCertificate
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0], nil
+}
+
+type callerContextKey struct{}
+
+// WithCaller attaches the identity a client certificate was matched with in the permissions to a context.
+func WithCaller(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, identity)
+}
+
+// CallerFromContext returns the identity a request was authenticated with: the subject of its token, or the identity
+// its client certificate was matched with. It reports false when the request was not authenticated.
+func CallerFromContext(ctx context.Context) (string, bool) {
+	if claims, ok := ClaimsFromContext(ctx); ok {
+		return claims.Subject, true
+	}
+
+	identity, ok := ctx.Value(callerContextKey{}).(string)
+
+	return identity, ok
+}
+
+// TenantFromCertificate restricts callers to the tenant their certificate is mapped to in the permissions.
+func TenantFromCertificate(permissions *Permissions) TenantResolver {
+	return func(ctx context.Context) (string, error) {
+		certificate, err := ClientCertificate(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		callers := permissions.match(certificate)
+		if len(callers) == 0 {
+			return "", ErrUnknownCaller
+		}
+
+		// A certificate may carry identities that belong to different tenants.
+		for _, caller := range callers[1:] {
+			if caller.Tenant != callers[0].Tenant {
+				return "", ErrAmbiguousTenant
+			}
+		}
+
+		return callers[0].Tenant, nil
+	}
+}
+
+// NewAuthorizationInterceptor returns a server interceptor that only lets callers perform the operations their
+// client certificate grants them on the namespace of a request.
+func NewAuthorizationInterceptor(permissions *Permissions) googlegrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+	) (any, error) {
+		certificate, err := ClientCertificate(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		getPermission, ok := MethodPermissions[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "method %s is not allowed", info.FullMethod)
+		}
+
+		operation, namespace := getPermission(req)
+
+		callers := permissions.match(certificate)
+		if len(callers) == 0 {
+			return nil, status.Error(codes.PermissionDenied, ErrUnknownCaller.Error())
+		}
+
+		allowed := slices.IndexFunc(callers, func(caller *CallerPermissions) bool {
+			return caller.allows(operation, namespace)
+		})
+		if allowed < 0 {
+			return nil, status.Errorf(
+				codes.PermissionDenied, "operation %s is not allowed on namespace %q", operation, namespace,
+			)
+		}
+
+		return handler(WithCaller(ctx, callers[allowed].Identity), req)
+	}
+}
@@ -0,0 +1,395 @@
+package handlers_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+)
+
+func contextWithCertificate(certificate *x509.Certificate) context.Context {
+	if certificate == nil {
+		return context.Background()
+	}
+
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{certificate}}},
+		},
+	})
+}
+
+var testPermissions = &handlers.Permissions{
+	Callers: []*handlers.CallerPermissions{
+		{
+			Identity:   "spiffe://cluster.local/ns/default/sa/accounts",
+			Tenant:     "tenant-a",
+			Namespaces: []string{"accounts-*"},
+			Operations: []handlers.Operation{handlers.OperationCreate, handlers.OperationGet},
+		},
+		{
+			Identity:   "admin",
+			Tenant:     "tenant-b",
+			Namespaces: []string{handlers.AllNamespaces},
+			Operations: []handlers.Operation{
+				handlers.OperationCreate,
+				handlers.OperationGet,
+				handlers.OperationUpdate,
+				handlers.OperationDelete,
+				handlers.OperationList,
+			},
+		},
+		{
+			Identity:   "lister",
+			Namespaces: []string{"accounts-*"},
+			Operations: []handlers.Operation{handlers.OperationList},
+		},
+	},
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	accountsCertificate := &x509.Certificate{
+		URIs: []*url.URL{{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/default/sa/accounts"}},
+	}
+	adminCertificate := &x509.Certificate{Subject: pkix.Name{CommonName: "admin"}}
+	listerCertificate := &x509.Certificate{DNSNames: []string{"lister"}}
+
+	testCases := []struct {
+		name string
+
+		certificate *x509.Certificate
+		method      string
+		request     any
+
+		expectCaller string
+		expectCode   codes.Code
+	}{
+		{
+			name: "OK/URI",
+
+			certificate: accountsCertificate,
+			method:      passkeysv1grpc.CreateService_Exec_FullMethodName,
+			request:     &passkeysv1.CreateServiceExecRequest{Namespace: "accounts-email"},
+
+			expectCaller: "spiffe://cluster.local/ns/default/sa/accounts",
+		},
+		{
+			name: "OK/CommonName",
+
+			certificate: adminCertificate,
+			method:      passkeysv1grpc.DeleteService_Exec_FullMethodName,
+			request:     &passkeysv1.DeleteServiceExecRequest{Namespace: "billing"},
+
+			expectCaller: "admin",
+		},
+		{
+			name: "OK/NamespaceName",
+
+			certificate: accountsCertificate,
+			method:      namespacesv1.GetService_Exec_FullMethodName,
+			request:     &namespacesv1.GetServiceExecRequest{Name: "accounts-email"},
+		},
+		{
+			name: "OK/List",
+
+			certificate: adminCertificate,
+			method:      namespacesv1.ListService_Exec_FullMethodName,
+			request:     &namespacesv1.ListServiceExecRequest{},
+		},
+		{
+			name: "OK/ListPasskeys",
+
+			certificate: listerCertificate,
+			method:      listingsv1.ListService_Exec_FullMethodName,
+			request:     &listingsv1.ListServiceExecRequest{Namespace: "accounts-email"},
+		},
+		{
+			name: "OK/Public",
+
+			certificate: listerCertificate,
+			method:      "/grpc.health.v1.Health/Check",
+		},
+		{
+			name: "NoCertificate",
+
+			method:  passkeysv1grpc.CreateService_Exec_FullMethodName,
+			request: &passkeysv1.CreateServiceExecRequest{Namespace: "accounts-email"},
+
+			expectCode: codes.Unauthenticated,
+		},
+		{
+			name: "UnknownCaller",
+
+			certificate: &x509.Certificate{Subject: pkix.Name{CommonName: "stranger"}},
+			method:      passkeysv1grpc.CreateService_Exec_FullMethodName,
+			request:     &passkeysv1.CreateServiceExecRequest{Namespace: "accounts-email"},
+
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			name: "OperationNotAllowed",
+
+			certificate: accountsCertificate,
+			method:      passkeysv1grpc.DeleteService_Exec_FullMethodName,
+			request:     &passkeysv1.DeleteServiceExecRequest{Namespace: "accounts-email"},
+
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			name: "NamespaceNotAllowed",
+
+			certificate: accountsCertificate,
+			method:      passkeysv1grpc.CreateService_Exec_FullMethodName,
+			request:     &passkeysv1.CreateServiceExecRequest{Namespace: "billing"},
+
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			name: "RevokeRequiresUpdate",
+
+			certificate: accountsCertificate,
+			method:      revocationsv1.RevokeService_Exec_FullMethodName,
+			request:     &revocationsv1.RevokeServiceExecRequest{Namespace: "accounts-email"},
+
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			name: "ListRequiresAllNamespaces",
+
+			certificate: listerCertificate,
+			method:      namespacesv1.ListService_Exec_FullMethodName,
+			request:     &namespacesv1.ListServiceExecRequest{},
+
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			name: "ListPasskeysRequiresAllNamespaces",
+
+			certificate: listerCertificate,
+			method:      listingsv1.ListService_Exec_FullMethodName,
+			request:     &listingsv1.ListServiceExecRequest{},
+
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			name: "UnknownMethod",
+
+			certificate: adminCertificate,
+			method:      "/passkeys.v1.UnknownService/Exec",
+
+			expectCode: codes.PermissionDenied,
+		},
+	}
+
+	interceptor := handlers.NewAuthorizationInterceptor(testPermissions)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var called bool
+
+			handler := func(ctx context.Context, _ any) (any, error) {
+				called = true
+
+				if testCase.expectCaller != "" {
+					caller, ok := handlers.CallerFromContext(ctx)
+					require.True(t, ok)
+					require.Equal(t, testCase.expectCaller, caller)
+				}
+
+				return "response", nil
+			}
+
+			resp, err := interceptor(
+				contextWithCertificate(testCase.certificate),
+				testCase.request,
+				&googlegrpc.UnaryServerInfo{FullMethod: testCase.method},
+				handler,
+			)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expectCode == codes.OK, called)
+
+			if testCase.expectCode == codes.OK {
+				require.Equal(t, "response", resp)
+			}
+		})
+	}
+}
+
+func TestTenantFromCertificate(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		certificate *x509.Certificate
+
+		expectTenant string
+		expectErr    error
+	}{
+		{
+			name: "OK",
+
+			certificate: &x509.Certificate{Subject: pkix.Name{CommonName: "admin"}},
+
+			expectTenant: "tenant-b",
+		},
+		{
+			name: "OK/Default",
+
+			certificate: &x509.Certificate{DNSNames: []string{"lister"}},
+
+			expectTenant: dao.DefaultTenant,
+		},
+		{
+			name: "AmbiguousTenant",
+
+			certificate: &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "admin"},
+				DNSNames: []string{"lister"},
+			},
+
+			expectErr: handlers.ErrAmbiguousTenant,
+		},
+		{
+			name: "UnknownCaller",
+
+			certificate: &x509.Certificate{Subject: pkix.Name{CommonName: "stranger"}},
+
+			expectErr: handlers.ErrUnknownCaller,
+		},
+		{
+			name: "NoCertificate",
+
+			expectErr: handlers.ErrNoClientCertificate,
+		},
+	}
+
+	resolve := handlers.TenantFromCertificate(testPermissions)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tenant, err := resolve(contextWithCertificate(testCase.certificate))
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expectTenant, tenant)
+		})
+	}
+}
+
+func TestLoadPermissions(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		content string
+
+		expect    *handlers.Permissions
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			content: `
+callers:
+  - identity: spiffe://cluster.local/ns/default/sa/accounts
+    tenant: tenant-a
+    namespaces: ["accounts-*"]
+    operations: [create, get]
+`,
+
+			expect: &handlers.Permissions{
+				Callers: []*handlers.CallerPermissions{
+					{
+						Identity:   "spiffe://cluster.local/ns/default/sa/accounts",
+						Tenant:     "tenant-a",
+						Namespaces: []string{"accounts-*"},
+						Operations: []handlers.Operation{handlers.OperationCreate, handlers.OperationGet},
+					},
+				},
+			},
+		},
+		{
+			name: "MissingIdentity",
+
+			content: `
+callers:
+  - namespaces: ["*"]
+    operations: [get]
+`,
+
+			expectErr: handlers.ErrInvalidPermissions,
+		},
+		{
+			name: "UnknownOperation",
+
+			content: `
+callers:
+  - identity: admin
+    namespaces: ["*"]
+    operations: [purge]
+`,
+
+			expectErr: handlers.ErrInvalidPermissions,
+		},
+		{
+			name: "BadPattern",
+
+			content: `
+callers:
+  - identity: admin
+    namespaces: ["accounts-["]
+    operations: [get]
+`,
+
+			expectErr: handlers.ErrInvalidPermissions,
+		},
+		{
+			name: "AmbiguousTenant",
+
+			content: `
+callers:
+  - identity: admin
+    tenant: tenant-a
+    namespaces: ["*"]
+    operations: [get]
+  - identity: admin
+    tenant: tenant-b
+    namespaces: ["*"]
+    operations: [list]
+`,
+
+			expectErr: handlers.ErrInvalidPermissions,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "permissions.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(testCase.content), 0o600))
+
+			permissions, err := handlers.LoadPermissions(file)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, permissions)
+		})
+	}
+}
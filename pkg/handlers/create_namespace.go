@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const CreateNamespaceServiceName = "create_namespace"
+
+type CreateNamespace interface {
+	namespacesv1.CreateServiceServer
+}
+
+type createNamespaceImpl struct {
+	service services.CreateNamespace
+}
+
+var handleCreateNamespaceError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidCreateNamespaceRequest, codes.InvalidArgument).
+	Is(dao.ErrNamespaceAlreadyExists, codes.AlreadyExists).
+	Handle
+
+func (handler *createNamespaceImpl) Exec(
+	ctx context.Context, request *namespacesv1.CreateServiceExecRequest,
+) (*namespacesv1.CreateServiceExecResponse, error) {
+	res, err := handler.service.Exec(ctx, &services.CreateNamespaceRequest{
+		Name:   request.GetName(),
+		Policy: namespacePolicyFromProto(request.GetPolicy()),
+	})
+	if err != nil {
+		return nil, handleCreateNamespaceError(err)
+	}
+
+	return &namespacesv1.CreateServiceExecResponse{Namespace: namespaceToProto(res)}, nil
+}
+
+func NewCreateNamespace(service services.CreateNamespace, logger adapters.GRPC) CreateNamespace {
+	handler := &createNamespaceImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		CreateNamespaceServiceName, lib.ServiceWithTracing("handlers.CreateNamespace", handler), logger,
+	)
+}
@@ -0,0 +1,205 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestCreateNamespace(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *namespacesv1.CreateServiceExecRequest
+
+		callServiceWith *services.CreateNamespaceRequest
+		serviceResp     *services.Namespace
+		serviceErr      error
+
+		expect     *namespacesv1.CreateServiceExecResponse
+		expectCode codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &namespacesv1.CreateServiceExecRequest{
+				Name: "namespace",
+				Policy: &namespacesv1.Policy{
+					DefaultTtl:    durationpb.New(time.Hour),
+					MaxTtl:        durationpb.New(24 * time.Hour),
+					HashAlgorithm: "argon2id",
+					HashParams: &namespacesv1.HashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:      &namespacesv1.StrengthRules{MinLength: 8, RequireSymbol: true},
+					SingleUseDefault:   true,
+					MaxActivePasskeys:  lo.ToPtr[int32](10),
+					CreationRateLimit:  lo.ToPtr[int32](5),
+					CreationRateWindow: durationpb.New(time.Minute),
+					SecretHistorySize:  5,
+				},
+			},
+
+			callServiceWith: &services.CreateNamespaceRequest{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					DefaultTTL:    lo.ToPtr(time.Hour),
+					MaxTTL:        lo.ToPtr(24 * time.Hour),
+					HashAlgorithm: "argon2id",
+					HashParams: &services.NamespaceHashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:      &services.NamespaceStrengthRules{MinLength: 8, RequireSymbol: true},
+					SingleUseDefault:   true,
+					MaxActivePasskeys:  lo.ToPtr(10),
+					CreationRateLimit:  lo.ToPtr(5),
+					CreationRateWindow: lo.ToPtr(time.Minute),
+					SecretHistorySize:  5,
+				},
+			},
+			serviceResp: &services.Namespace{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					DefaultTTL:    lo.ToPtr(time.Hour),
+					MaxTTL:        lo.ToPtr(24 * time.Hour),
+					HashAlgorithm: "argon2id",
+					HashParams: &services.NamespaceHashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:      &services.NamespaceStrengthRules{MinLength: 8, RequireSymbol: true},
+					SingleUseDefault:   true,
+					MaxActivePasskeys:  lo.ToPtr(10),
+					CreationRateLimit:  lo.ToPtr(5),
+					CreationRateWindow: lo.ToPtr(time.Minute),
+					SecretHistorySize:  5,
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &namespacesv1.CreateServiceExecResponse{
+				Namespace: &namespacesv1.Namespace{
+					Name: "namespace",
+					Policy: &namespacesv1.Policy{
+						DefaultTtl:    durationpb.New(time.Hour),
+						MaxTtl:        durationpb.New(24 * time.Hour),
+						HashAlgorithm: "argon2id",
+						HashParams: &namespacesv1.HashParams{
+							SaltLength:  16,
+							Iterations:  2,
+							Memory:      32 * 1024,
+							Parallelism: 2,
+							KeyLength:   32,
+						},
+						StrengthRules:      &namespacesv1.StrengthRules{MinLength: 8, RequireSymbol: true},
+						SingleUseDefault:   true,
+						MaxActivePasskeys:  lo.ToPtr[int32](10),
+						CreationRateLimit:  lo.ToPtr[int32](5),
+						CreationRateWindow: durationpb.New(time.Minute),
+						SecretHistorySize:  5,
+					},
+					CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &namespacesv1.CreateServiceExecRequest{
+				Name: "namespace",
+			},
+
+			callServiceWith: &services.CreateNamespaceRequest{
+				Name: "namespace",
+			},
+
+			serviceErr: services.ErrInvalidCreateNamespaceRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "AlreadyExists",
+
+			request: &namespacesv1.CreateServiceExecRequest{
+				Name:   "namespace",
+				Policy: &namespacesv1.Policy{},
+			},
+
+			callServiceWith: &services.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{},
+			},
+
+			serviceErr: dao.ErrNamespaceAlreadyExists,
+
+			expectCode: codes.AlreadyExists,
+		},
+		{
+			name: "InternalError",
+
+			request: &namespacesv1.CreateServiceExecRequest{
+				Name:   "namespace",
+				Policy: &namespacesv1.Policy{},
+			},
+
+			callServiceWith: &services.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{},
+			},
+
+			serviceErr: errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := servicesmocks.NewMockCreateNamespace(t)
+			logger := adaptersmocks.NewMockGRPC(t)
+
+			service.
+				On("Exec", context.Background(), testCase.callServiceWith).
+				Return(testCase.serviceResp, testCase.serviceErr)
+
+			logger.On("Report", handlers.CreateNamespaceServiceName, mock.Anything)
+
+			handler := handlers.NewCreateNamespace(service, logger)
+			resp, err := handler.Exec(context.Background(), testCase.request)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expect, resp)
+
+			service.AssertExpectations(t)
+			logger.AssertExpectations(t)
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/a-novel/golib/grpc"
 	"github.com/a-novel/golib/loggers/adapters"
 
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
 )
 
@@ -28,12 +30,18 @@ type createPasskeyImpl struct {
 
 var handleCreatePasskeyError = grpc.HandleError(codes.Internal).
 	Is(services.ErrInvalidCreatePasskeyRequest, codes.InvalidArgument).
+	Is(services.ErrNamespaceNotRegistered, codes.FailedPrecondition).
+	Is(services.ErrPolicyViolation, codes.InvalidArgument).
+	Is(dao.ErrPasskeyAlreadyExists, codes.AlreadyExists).
+	Test(handleQuotaExceeded).
+	Is(dao.ErrQuotaExceeded, codes.ResourceExhausted).
 	Handle
 
 func (handler *createPasskeyImpl) Exec(
 	ctx context.Context, request *passkeysv1.CreateServiceExecRequest,
 ) (*passkeysv1.CreateServiceExecResponse, error) {
 	res, err := handler.service.Exec(ctx, &services.CreatePasskeyRequest{
+		ID:        ExtractPasskeyID(ctx),
 		Namespace: request.GetNamespace(),
 		Passkey:   ExtractPasskey(ctx),
 		Reward:    grpc.StructOptionalProto(request.GetReward()),
@@ -48,6 +56,8 @@ func (handler *createPasskeyImpl) Exec(
 		return nil, status.Errorf(codes.Internal, "convert reward: %v", err)
 	}
 
+	SendVersion(ctx, res.Version)
+
 	return &passkeysv1.CreateServiceExecResponse{
 		Id:        res.ID,
 		Namespace: res.Namespace,
@@ -59,5 +69,7 @@ func (handler *createPasskeyImpl) Exec(
 
 func NewCreatePasskey(service services.CreatePasskey, logger adapters.GRPC) CreatePasskey {
 	handler := &createPasskeyImpl{service: service}
-	return grpc.ServiceWithMetrics(CreatePasskeyServiceName, handler, logger)
+	return grpc.ServiceWithMetrics(
+		CreatePasskeyServiceName, lib.ServiceWithTracing("handlers.CreatePasskey", handler), logger,
+	)
 }
@@ -9,8 +9,11 @@ import (
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -20,6 +23,7 @@ import (
 	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
 	"github.com/a-novel/golib/testutils"
 
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
 	"github.com/a-novel/uservice-passkeys/pkg/handlers"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
 	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
@@ -39,8 +43,9 @@ func TestCreatePasskey(t *testing.T) {
 		serviceResp     *services.CreatePasskeyResponse
 		serviceErr      error
 
-		expect     *passkeysv1.CreateServiceExecResponse
-		expectCode codes.Code
+		expect                *passkeysv1.CreateServiceExecResponse
+		expectCode            codes.Code
+		expectQuotaViolations []*errdetails.QuotaFailure_Violation
 	}{
 		{
 			name: "OK",
@@ -102,6 +107,55 @@ func TestCreatePasskey(t *testing.T) {
 				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "OK/PasskeyID",
+
+			metadata: map[string]string{
+				"password":   "passkey",
+				"passkey-id": "00000000-0000-0000-0000-000000000001",
+			},
+			request: &passkeysv1.CreateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.CreatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+			serviceResp: &services.CreatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &passkeysv1.CreateServiceExecResponse{
+				Id:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "AlreadyExists",
+
+			metadata: map[string]string{
+				"password":   "passkey",
+				"passkey-id": "00000000-0000-0000-0000-000000000001",
+			},
+			request: &passkeysv1.CreateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.CreatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: dao.ErrPasskeyAlreadyExists,
+
+			expectCode: codes.AlreadyExists,
+		},
 		{
 			name: "InvalidRequest",
 
@@ -122,6 +176,94 @@ func TestCreatePasskey(t *testing.T) {
 
 			expectCode: codes.InvalidArgument,
 		},
+		{
+			name: "NamespaceNotRegistered",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+
+			request: &passkeysv1.CreateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: services.ErrNamespaceNotRegistered,
+
+			expectCode: codes.FailedPrecondition,
+		},
+		{
+			name: "PolicyViolation",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+
+			request: &passkeysv1.CreateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: services.ErrPolicyViolation,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "QuotaExceeded",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+
+			request: &passkeysv1.CreateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: dao.ErrQuotaExceeded,
+
+			expectCode: codes.ResourceExhausted,
+		},
+		{
+			name: "QuotaExceeded/Details",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+
+			request: &passkeysv1.CreateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: errors.Join(services.ErrCreatePasskey, &dao.QuotaExceededError{
+				Namespace: "namespace",
+				Quota:     dao.QuotaCreationRate,
+				Limit:     5,
+				Window:    time.Minute,
+			}),
+
+			expectCode: codes.ResourceExhausted,
+			expectQuotaViolations: []*errdetails.QuotaFailure_Violation{
+				{Subject: "namespace:namespace", Description: "at most 5 passkeys created per 1m0s"},
+			},
+		},
 		{
 			name: "InternalError",
 
@@ -163,6 +305,19 @@ func TestCreatePasskey(t *testing.T) {
 			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
 			require.Equal(t, testCase.expect, resp)
 
+			if testCase.expectQuotaViolations != nil {
+				details := status.Convert(err).Details()
+				require.Len(t, details, 1)
+
+				quotaFailure, ok := details[0].(*errdetails.QuotaFailure)
+				require.True(t, ok)
+				require.Len(t, quotaFailure.GetViolations(), len(testCase.expectQuotaViolations))
+
+				for i, violation := range testCase.expectQuotaViolations {
+					require.True(t, proto.Equal(violation, quotaFailure.GetViolations()[i]))
+				}
+			}
+
 			service.AssertExpectations(t)
 			logger.AssertExpectations(t)
 		})
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const DeleteNamespaceServiceName = "delete_namespace"
+
+type DeleteNamespace interface {
+	namespacesv1.DeleteServiceServer
+}
+
+type deleteNamespaceImpl struct {
+	service services.DeleteNamespace
+}
+
+var handleDeleteNamespaceError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidDeleteNamespaceRequest, codes.InvalidArgument).
+	Is(dao.ErrNamespaceNotFound, codes.NotFound).
+	Is(dao.ErrNamespaceNotEmpty, codes.FailedPrecondition).
+	Handle
+
+func (handler *deleteNamespaceImpl) Exec(
+	ctx context.Context, request *namespacesv1.DeleteServiceExecRequest,
+) (*namespacesv1.DeleteServiceExecResponse, error) {
+	res, err := handler.service.Exec(ctx, &services.DeleteNamespaceRequest{
+		Name: request.GetName(),
+	})
+	if err != nil {
+		return nil, handleDeleteNamespaceError(err)
+	}
+
+	return &namespacesv1.DeleteServiceExecResponse{Namespace: namespaceToProto(res)}, nil
+}
+
+func NewDeleteNamespace(service services.DeleteNamespace, logger adapters.GRPC) DeleteNamespace {
+	handler := &deleteNamespaceImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		DeleteNamespaceServiceName, lib.ServiceWithTracing("handlers.DeleteNamespace", handler), logger,
+	)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/a-novel/golib/loggers/adapters"
 
 	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
 )
 
@@ -30,17 +31,25 @@ type deletePasskeyImpl struct {
 var handleDeletePasskeyError = grpc.HandleError(codes.Internal).
 	Is(services.ErrInvalidDeletePasskeyRequest, codes.InvalidArgument).
 	Is(dao.ErrPasskeyNotFound, codes.NotFound).
-	Is(dao.ErrInvalidPasskey, codes.PermissionDenied).
+	Test(handleInvalidPasskey).
+	Is(dao.ErrVersionMismatch, codes.Aborted).
 	Handle
 
 func (handler *deletePasskeyImpl) Exec(
 	ctx context.Context, request *passkeysv1.DeleteServiceExecRequest,
 ) (*passkeysv1.DeleteServiceExecResponse, error) {
+	expectedVersion, err := ExtractExpectedVersion(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "expected-version metadata: %v", err)
+	}
+
 	res, err := handler.service.Exec(ctx, &services.DeletePasskeyRequest{
 		ID:        request.GetId(),
 		Namespace: request.GetNamespace(),
 		Passkey:   ExtractPasskey(ctx),
 		Validate:  request.GetValidate(),
+
+		ExpectedVersion: expectedVersion,
 	})
 	if err != nil {
 		return nil, handleDeletePasskeyError(err)
@@ -51,6 +60,8 @@ func (handler *deletePasskeyImpl) Exec(
 		return nil, status.Errorf(codes.Internal, "convert reward: %v", err)
 	}
 
+	SendVersion(ctx, res.Version)
+
 	return &passkeysv1.DeleteServiceExecResponse{
 		Id:        res.ID,
 		Namespace: res.Namespace,
@@ -63,5 +74,7 @@ func (handler *deletePasskeyImpl) Exec(
 
 func NewDeletePasskey(service services.DeletePasskey, logger adapters.GRPC) DeletePasskey {
 	handler := &deletePasskeyImpl{service: service}
-	return grpc.ServiceWithMetrics(DeletePasskeyServiceName, handler, logger)
+	return grpc.ServiceWithMetrics(
+		DeletePasskeyServiceName, lib.ServiceWithTracing("handlers.DeletePasskey", handler), logger,
+	)
 }
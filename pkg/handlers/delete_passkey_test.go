@@ -9,8 +9,10 @@ import (
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -39,9 +41,60 @@ func TestDeletePasskey(t *testing.T) {
 		serviceResp     *services.DeletePasskeyResponse
 		serviceErr      error
 
-		expect     *passkeysv1.DeleteServiceExecResponse
-		expectCode codes.Code
+		expect       *passkeysv1.DeleteServiceExecResponse
+		expectCode   codes.Code
+		expectReason string
 	}{
+		{
+			name: "OK/ExpectedVersion",
+
+			metadata: map[string]string{
+				"expected-version": "3",
+			},
+			request: &passkeysv1.DeleteServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.DeletePasskeyRequest{
+				ID:              "id",
+				Namespace:       "namespace",
+				ExpectedVersion: lo.ToPtr(int64(3)),
+			},
+			serviceResp: &services.DeletePasskeyResponse{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:   3,
+			},
+
+			expect: &passkeysv1.DeleteServiceExecResponse{
+				Id:        "id",
+				Namespace: "namespace",
+				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "VersionMismatch",
+
+			metadata: map[string]string{
+				"expected-version": "3",
+			},
+			request: &passkeysv1.DeleteServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.DeletePasskeyRequest{
+				ID:              "id",
+				Namespace:       "namespace",
+				ExpectedVersion: lo.ToPtr(int64(3)),
+			},
+
+			serviceErr: dao.ErrVersionMismatch,
+
+			expectCode: codes.Aborted,
+		},
 		{
 			name: "OK",
 
@@ -151,7 +204,8 @@ func TestDeletePasskey(t *testing.T) {
 			},
 			serviceErr: dao.ErrInvalidPasskey,
 
-			expectCode: codes.PermissionDenied,
+			expectCode:   codes.PermissionDenied,
+			expectReason: handlers.ReasonInvalidPasskey,
 		},
 		{
 			name: "InternalError",
@@ -196,6 +250,16 @@ func TestDeletePasskey(t *testing.T) {
 			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
 			require.Equal(t, testCase.expect, resp)
 
+			if testCase.expectReason != "" {
+				details := status.Convert(err).Details()
+				require.Len(t, details, 1)
+
+				errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+				require.True(t, ok)
+				require.Equal(t, testCase.expectReason, errorInfo.GetReason())
+				require.Equal(t, handlers.ErrorDomain, errorInfo.GetDomain())
+			}
+
 			service.AssertExpectations(t)
 			logger.AssertExpectations(t)
 		})
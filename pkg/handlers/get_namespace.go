@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const GetNamespaceServiceName = "get_namespace"
+
+type GetNamespace interface {
+	namespacesv1.GetServiceServer
+}
+
+type getNamespaceImpl struct {
+	service services.GetNamespace
+}
+
+var handleGetNamespaceError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidGetNamespaceRequest, codes.InvalidArgument).
+	Is(dao.ErrNamespaceNotFound, codes.NotFound).
+	Handle
+
+func (handler *getNamespaceImpl) Exec(
+	ctx context.Context, request *namespacesv1.GetServiceExecRequest,
+) (*namespacesv1.GetServiceExecResponse, error) {
+	res, err := handler.service.Exec(ctx, &services.GetNamespaceRequest{
+		Name: request.GetName(),
+	})
+	if err != nil {
+		return nil, handleGetNamespaceError(err)
+	}
+
+	return &namespacesv1.GetServiceExecResponse{Namespace: namespaceToProto(res)}, nil
+}
+
+func NewGetNamespace(service services.GetNamespace, logger adapters.GRPC) GetNamespace {
+	handler := &getNamespaceImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		GetNamespaceServiceName, lib.ServiceWithTracing("handlers.GetNamespace", handler), logger,
+	)
+}
@@ -0,0 +1,125 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestGetNamespace(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *namespacesv1.GetServiceExecRequest
+
+		callServiceWith *services.GetNamespaceRequest
+		serviceResp     *services.Namespace
+		serviceErr      error
+
+		expect     *namespacesv1.GetServiceExecResponse
+		expectCode codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &namespacesv1.GetServiceExecRequest{
+				Name: "namespace",
+			},
+
+			callServiceWith: &services.GetNamespaceRequest{
+				Name: "namespace",
+			},
+			serviceResp: &services.Namespace{
+				Name:      "namespace",
+				Policy:    &services.NamespacePolicy{HashAlgorithm: "argon2id"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &namespacesv1.GetServiceExecResponse{
+				Namespace: &namespacesv1.Namespace{
+					Name:      "namespace",
+					Policy:    &namespacesv1.Policy{HashAlgorithm: "argon2id"},
+					CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &namespacesv1.GetServiceExecRequest{},
+
+			callServiceWith: &services.GetNamespaceRequest{},
+
+			serviceErr: services.ErrInvalidGetNamespaceRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "NotFound",
+
+			request: &namespacesv1.GetServiceExecRequest{
+				Name: "namespace",
+			},
+
+			callServiceWith: &services.GetNamespaceRequest{
+				Name: "namespace",
+			},
+
+			serviceErr: dao.ErrNamespaceNotFound,
+
+			expectCode: codes.NotFound,
+		},
+		{
+			name: "InternalError",
+
+			request: &namespacesv1.GetServiceExecRequest{
+				Name: "namespace",
+			},
+
+			callServiceWith: &services.GetNamespaceRequest{
+				Name: "namespace",
+			},
+
+			serviceErr: errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := servicesmocks.NewMockGetNamespace(t)
+			logger := adaptersmocks.NewMockGRPC(t)
+
+			service.
+				On("Exec", context.Background(), testCase.callServiceWith).
+				Return(testCase.serviceResp, testCase.serviceErr)
+
+			logger.On("Report", handlers.GetNamespaceServiceName, mock.Anything)
+
+			handler := handlers.NewGetNamespace(service, logger)
+			resp, err := handler.Exec(context.Background(), testCase.request)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expect, resp)
+
+			service.AssertExpectations(t)
+			logger.AssertExpectations(t)
+		})
+	}
+}
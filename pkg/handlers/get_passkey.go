@@ -14,6 +14,7 @@ import (
 	"github.com/a-novel/golib/loggers/adapters"
 
 	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
 )
 
@@ -30,17 +31,22 @@ type getPasskeyImpl struct {
 var handleGetPasskeyError = grpc.HandleError(codes.Internal).
 	Is(services.ErrInvalidGetPasskeyRequest, codes.InvalidArgument).
 	Is(dao.ErrPasskeyNotFound, codes.NotFound).
-	Is(dao.ErrInvalidPasskey, codes.PermissionDenied).
+	Test(handleInvalidPasskey).
+	Is(dao.ErrPasskeyRevoked, codes.FailedPrecondition).
 	Handle
 
 func (handler *getPasskeyImpl) Exec(
 	ctx context.Context, request *passkeysv1.GetServiceExecRequest,
 ) (*passkeysv1.GetServiceExecResponse, error) {
+	redeemedBy, _ := CallerFromContext(ctx)
+
 	res, err := handler.service.Exec(ctx, &services.GetPasskeyRequest{
 		ID:        request.GetId(),
 		Namespace: request.GetNamespace(),
 		Passkey:   ExtractPasskey(ctx),
 		Validate:  request.GetValidate(),
+
+		RedeemedBy: redeemedBy,
 	})
 	if err != nil {
 		return nil, handleGetPasskeyError(err)
@@ -51,6 +57,8 @@ func (handler *getPasskeyImpl) Exec(
 		return nil, status.Errorf(codes.Internal, "convert reward: %v", err)
 	}
 
+	SendVersion(ctx, res.Version)
+
 	return &passkeysv1.GetServiceExecResponse{
 		Id:        res.ID,
 		Namespace: res.Namespace,
@@ -63,5 +71,7 @@ func (handler *getPasskeyImpl) Exec(
 
 func NewGetPasskey(service services.GetPasskey, logger adapters.GRPC) GetPasskey {
 	handler := &getPasskeyImpl{service: service}
-	return grpc.ServiceWithMetrics(GetPasskeyServiceName, handler, logger)
+	return grpc.ServiceWithMetrics(
+		GetPasskeyServiceName, lib.ServiceWithTracing("handlers.GetPasskey", handler), logger,
+	)
 }
@@ -6,11 +6,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -34,13 +37,15 @@ func TestGetPasskey(t *testing.T) {
 
 		metadata map[string]string
 		request  *passkeysv1.GetServiceExecRequest
+		subject  string
 
 		callServiceWith *services.GetPasskeyRequest
 		serviceResp     *services.GetPasskeyResponse
 		serviceErr      error
 
-		expect     *passkeysv1.GetServiceExecResponse
-		expectCode codes.Code
+		expect       *passkeysv1.GetServiceExecResponse
+		expectCode   codes.Code
+		expectReason string
 	}{
 		{
 			name: "OK",
@@ -78,6 +83,38 @@ func TestGetPasskey(t *testing.T) {
 				UpdatedAt: timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "OK/Subject",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+			request: &passkeysv1.GetServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+				Validate:  true,
+			},
+			subject: "user-1",
+
+			callServiceWith: &services.GetPasskeyRequest{
+				ID:         "id",
+				Namespace:  "namespace",
+				Passkey:    "passkey",
+				Validate:   true,
+				RedeemedBy: "user-1",
+			},
+			serviceResp: &services.GetPasskeyResponse{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &passkeysv1.GetServiceExecResponse{
+				Id:        "id",
+				Namespace: "namespace",
+				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
 		{
 			name: "OK/Minimal",
 
@@ -151,7 +188,30 @@ func TestGetPasskey(t *testing.T) {
 			},
 			serviceErr: dao.ErrInvalidPasskey,
 
-			expectCode: codes.PermissionDenied,
+			expectCode:   codes.PermissionDenied,
+			expectReason: handlers.ReasonInvalidPasskey,
+		},
+		{
+			name: "Revoked",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+			request: &passkeysv1.GetServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+				Validate:  true,
+			},
+
+			callServiceWith: &services.GetPasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+				Validate:  true,
+			},
+			serviceErr: dao.ErrPasskeyRevoked,
+
+			expectCode: codes.FailedPrecondition,
 		},
 		{
 			name: "InternalError",
@@ -183,6 +243,11 @@ func TestGetPasskey(t *testing.T) {
 			logger := adaptersmocks.NewMockGRPC(t)
 
 			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(testCase.metadata))
+			if testCase.subject != "" {
+				ctx = handlers.WithClaims(ctx, &handlers.Claims{
+					RegisteredClaims: jwt.RegisteredClaims{Subject: testCase.subject},
+				})
+			}
 
 			service.
 				On("Exec", ctx, testCase.callServiceWith).
@@ -196,6 +261,16 @@ func TestGetPasskey(t *testing.T) {
 			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
 			require.Equal(t, testCase.expect, resp)
 
+			if testCase.expectReason != "" {
+				details := status.Convert(err).Details()
+				require.Len(t, details, 1)
+
+				errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+				require.True(t, ok)
+				require.Equal(t, testCase.expectReason, errorInfo.GetReason())
+				require.Equal(t, handlers.ErrorDomain, errorInfo.GetDomain())
+			}
+
 			service.AssertExpectations(t)
 			logger.AssertExpectations(t)
 		})
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+
+	"github.com/a-novel/golib/grpc"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+// IdempotencyKeyHeader is the metadata that carries the idempotency key of a request.
+const IdempotencyKeyHeader = "idempotency-key"
+
+var ErrNotProtoMessage = errors.New("not a protobuf message")
+
+// IdempotentMethods lists the methods that accept an idempotency key, along with a constructor for their response.
+// Create would otherwise generate a new passkey on each retry, and get would fail once a single-use passkey is
+// redeemed.
+var IdempotentMethods = map[string]func() proto.Message{
+	passkeysv1grpc.CreateService_Exec_FullMethodName: func() proto.Message {
+		return new(passkeysv1.CreateServiceExecResponse)
+	},
+	passkeysv1grpc.GetService_Exec_FullMethodName: func() proto.Message {
+		return new(passkeysv1.GetServiceExecResponse)
+	},
+}
+
+var handleClaimIdempotencyKeyError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidClaimIdempotencyKeyRequest, codes.InvalidArgument).
+	Is(dao.ErrIdempotencyKeyReused, codes.InvalidArgument).
+	Is(dao.ErrIdempotencyKeyPending, codes.Aborted).
+	Handle
+
+type idempotencyImpl struct {
+	claim    services.ClaimIdempotencyKey
+	complete services.CompleteIdempotencyKey
+	release  services.ReleaseIdempotencyKey
+
+	fingerprintKey []byte
+}
+
+func (interceptor *idempotencyImpl) Intercept(
+	ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+) (any, error) {
+	newResponse, supported := IdempotentMethods[info.FullMethod]
+	if !supported {
+		return handler(ctx, req)
+	}
+
+	key := ExtractIdempotencyKey(ctx)
+	if key == "" {
+		return handler(ctx, req)
+	}
+
+	fingerprint, err := interceptor.fingerprint(ctx, info.FullMethod, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "compute request fingerprint: %v", err)
+	}
+
+	claimed, err := interceptor.claim.Exec(ctx, &services.ClaimIdempotencyKeyRequest{
+		Key:         key,
+		Service:     info.FullMethod,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return nil, handleClaimIdempotencyKeyError(err)
+	}
+
+	if claimed.Response != nil {
+		return replayResponse(ctx, newResponse(), claimed)
+	}
+
+	handlerCtx, sent := withSentVersion(ctx)
+
+	res, err := handler(handlerCtx, req)
+	if err != nil {
+		// A failure leaves the key claimed until it expires, in which case retries are aborted in the meantime.
+		_ = interceptor.release.Exec(ctx, &services.ReleaseIdempotencyKeyRequest{
+			Key:     key,
+			Service: info.FullMethod,
+		})
+
+		return nil, err
+	}
+
+	message, isMessage := res.(proto.Message)
+	if !isMessage {
+		return nil, status.Errorf(codes.Internal, "encode response: %v: %T", ErrNotProtoMessage, res)
+	}
+
+	raw, err := proto.Marshal(message)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encode response: %v", err)
+	}
+
+	// The request already went through, so do not report a failure to the client. Retries are aborted until the key
+	// expires.
+	_ = interceptor.complete.Exec(ctx, &services.CompleteIdempotencyKeyRequest{
+		Key:      key,
+		Service:  info.FullMethod,
+		Response: raw,
+		Version:  sent.version,
+	})
+
+	return res, nil
+}
+
+// replayResponse decodes the stored response of the original request, and sends its version header again.
+func replayResponse(
+	ctx context.Context, res proto.Message, claimed *services.ClaimIdempotencyKeyResponse,
+) (proto.Message, error) {
+	if err := proto.Unmarshal(claimed.Response, res); err != nil {
+		return nil, status.Errorf(codes.Internal, "decode stored response: %v", err)
+	}
+
+	if claimed.Version != nil {
+		SendVersion(ctx, *claimed.Version)
+	}
+
+	return res, nil
+}
+
+// fingerprint hashes everything that makes up a request, including the passkey and ID sent in the metadata, and the
+// identity of the caller. Fingerprints are stored, so they are keyed with a server secret: otherwise, the passkeys
+// they include could be recovered by hashing guesses.
+func (interceptor *idempotencyImpl) fingerprint(ctx context.Context, method string, req any) (string, error) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrNotProtoMessage, req)
+	}
+
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", fmt.Errorf("encode request: %w", err)
+	}
+
+	caller, _ := CallerFromContext(ctx)
+
+	hash := hmac.New(sha256.New, interceptor.fingerprintKey)
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write(raw)
+	hash.Write([]byte{0})
+	hash.Write([]byte(ExtractPasskey(ctx)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(ExtractPasskeyID(ctx)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(caller))
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ExtractIdempotencyKey reads the idempotency key of a request from its metadata.
+func ExtractIdempotencyKey(ctx context.Context) string {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	keyRaw := incoming.Get(IdempotencyKeyHeader)
+	if len(keyRaw) == 0 {
+		return ""
+	}
+
+	return keyRaw[0]
+}
+
+// NewIdempotencyInterceptor returns a server interceptor that replays the response of requests sent again with the
+// same idempotency key, instead of running them twice. The fingerprints of the requests are keyed with
+// fingerprintKey, which must be shared by every replica.
+func NewIdempotencyInterceptor(
+	claim services.ClaimIdempotencyKey,
+	complete services.CompleteIdempotencyKey,
+	release services.ReleaseIdempotencyKey,
+	fingerprintKey []byte,
+) googlegrpc.UnaryServerInterceptor {
+	interceptor := &idempotencyImpl{
+		claim:          claim,
+		complete:       complete,
+		release:        release,
+		fingerprintKey: fingerprintKey,
+	}
+	return interceptor.Intercept
+}
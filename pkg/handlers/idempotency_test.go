@@ -0,0 +1,311 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"buf.build/gen/go/a-novel/proto/grpc/go/passkeys/v1/passkeysv1grpc"
+	passkeysv1 "buf.build/gen/go/a-novel/proto/protocolbuffers/go/passkeys/v1"
+
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	storedResponse, err := proto.Marshal(&passkeysv1.CreateServiceExecResponse{Id: "stored-id"})
+	require.NoError(t, err)
+
+	handlerResponse := &passkeysv1.CreateServiceExecResponse{Id: "id"}
+
+	handlerResponseRaw, err := proto.Marshal(handlerResponse)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+
+		method   string
+		metadata map[string]string
+
+		shouldCallClaim bool
+		claimResp       *services.ClaimIdempotencyKeyResponse
+		claimErr        error
+
+		shouldCallHandler bool
+		handlerErr        error
+
+		shouldCallComplete bool
+		shouldCallRelease  bool
+
+		expect        proto.Message
+		expectVersion []string
+		expectCode    codes.Code
+	}{
+		{
+			name: "OK",
+
+			method:   passkeysv1grpc.CreateService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallClaim: true,
+			claimResp:       &services.ClaimIdempotencyKeyResponse{},
+
+			shouldCallHandler:  true,
+			shouldCallComplete: true,
+
+			expect:        handlerResponse,
+			expectVersion: []string{"3"},
+		},
+		{
+			name: "OK/Replay",
+
+			method:   passkeysv1grpc.CreateService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallClaim: true,
+			claimResp: &services.ClaimIdempotencyKeyResponse{
+				Response: storedResponse,
+				Version:  lo.ToPtr(int64(2)),
+			},
+
+			expect:        &passkeysv1.CreateServiceExecResponse{Id: "stored-id"},
+			expectVersion: []string{"2"},
+		},
+		{
+			name: "OK/NoKey",
+
+			method: passkeysv1grpc.CreateService_Exec_FullMethodName,
+
+			shouldCallHandler: true,
+
+			expect:        handlerResponse,
+			expectVersion: []string{"3"},
+		},
+		{
+			name: "OK/NotIdempotent",
+
+			method:   passkeysv1grpc.DeleteService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallHandler: true,
+
+			expect:        handlerResponse,
+			expectVersion: []string{"3"},
+		},
+		{
+			name: "Reused",
+
+			method:   passkeysv1grpc.CreateService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallClaim: true,
+			claimErr:        dao.ErrIdempotencyKeyReused,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "Pending",
+
+			method:   passkeysv1grpc.CreateService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallClaim: true,
+			claimErr:        dao.ErrIdempotencyKeyPending,
+
+			expectCode: codes.Aborted,
+		},
+		{
+			name: "InvalidKey",
+
+			method:   passkeysv1grpc.CreateService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallClaim: true,
+			claimErr:        services.ErrInvalidClaimIdempotencyKeyRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "HandlerError",
+
+			method:   passkeysv1grpc.CreateService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallClaim: true,
+			claimResp:       &services.ClaimIdempotencyKeyResponse{},
+
+			shouldCallHandler: true,
+			handlerErr:        status.Error(codes.FailedPrecondition, "uwups"),
+			shouldCallRelease: true,
+
+			expectCode: codes.FailedPrecondition,
+		},
+		{
+			name: "InternalError",
+
+			method:   passkeysv1grpc.CreateService_Exec_FullMethodName,
+			metadata: map[string]string{"idempotency-key": "key"},
+
+			shouldCallClaim: true,
+			claimErr:        errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			claim := servicesmocks.NewMockClaimIdempotencyKey(t)
+			complete := servicesmocks.NewMockCompleteIdempotencyKey(t)
+			release := servicesmocks.NewMockReleaseIdempotencyKey(t)
+
+			stream := &headerStream{}
+			ctx := googlegrpc.NewContextWithServerTransportStream(
+				metadata.NewIncomingContext(context.Background(), metadata.New(testCase.metadata)),
+				stream,
+			)
+			request := &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"}
+
+			if testCase.shouldCallClaim {
+				claim.
+					On("Exec", ctx, mock.MatchedBy(func(data *services.ClaimIdempotencyKeyRequest) bool {
+						return data.Key == "key" && data.Service == testCase.method && len(data.Fingerprint) == 64
+					})).
+					Return(testCase.claimResp, testCase.claimErr)
+			}
+
+			if testCase.shouldCallComplete {
+				complete.
+					On("Exec", ctx, &services.CompleteIdempotencyKeyRequest{
+						Key:      "key",
+						Service:  testCase.method,
+						Response: handlerResponseRaw,
+						Version:  lo.ToPtr(int64(3)),
+					}).
+					Return(nil)
+			}
+
+			if testCase.shouldCallRelease {
+				release.
+					On("Exec", ctx, &services.ReleaseIdempotencyKeyRequest{
+						Key:     "key",
+						Service: testCase.method,
+					}).
+					Return(nil)
+			}
+
+			handlerCalled := false
+			handler := func(ctx context.Context, _ any) (any, error) {
+				handlerCalled = true
+
+				if testCase.handlerErr != nil {
+					return nil, testCase.handlerErr
+				}
+
+				handlers.SendVersion(ctx, 3)
+
+				return handlerResponse, nil
+			}
+
+			interceptor := handlers.NewIdempotencyInterceptor(claim, complete, release, []byte("fingerprint-key"))
+			resp, err := interceptor(ctx, request, &googlegrpc.UnaryServerInfo{FullMethod: testCase.method}, handler)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.shouldCallHandler, handlerCalled)
+
+			if testCase.expect != nil {
+				require.True(t, proto.Equal(testCase.expect, resp.(proto.Message)))
+			} else {
+				require.Nil(t, resp)
+			}
+
+			require.Equal(t, testCase.expectVersion, stream.header.Get(handlers.VersionHeader))
+
+			claim.AssertExpectations(t)
+			complete.AssertExpectations(t)
+			release.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdempotencyInterceptorFingerprint(t *testing.T) {
+	claim := servicesmocks.NewMockClaimIdempotencyKey(t)
+	complete := servicesmocks.NewMockCompleteIdempotencyKey(t)
+	release := servicesmocks.NewMockReleaseIdempotencyKey(t)
+
+	var fingerprints []string
+
+	claim.
+		On("Exec", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fingerprints = append(fingerprints, args.Get(1).(*services.ClaimIdempotencyKeyRequest).Fingerprint)
+		}).
+		Return(nil, dao.ErrIdempotencyKeyPending)
+
+	interceptor := handlers.NewIdempotencyInterceptor(claim, complete, release, []byte("fingerprint-key"))
+	otherKeyInterceptor := handlers.NewIdempotencyInterceptor(claim, complete, release, []byte("other-key"))
+	info := &googlegrpc.UnaryServerInfo{FullMethod: passkeysv1grpc.CreateService_Exec_FullMethodName}
+
+	newContext := func(password, passkeyID, subject, caller string) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+			"idempotency-key": "key",
+			"password":        password,
+			"passkey-id":      passkeyID,
+		}))
+
+		if subject != "" {
+			ctx = handlers.WithClaims(ctx, &handlers.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: subject}})
+		}
+
+		if caller != "" {
+			ctx = handlers.WithCaller(ctx, caller)
+		}
+
+		return ctx
+	}
+
+	send := func(password, passkeyID, subject, caller string, request *passkeysv1.CreateServiceExecRequest) {
+		_, err := interceptor(newContext(password, passkeyID, subject, caller), request, info, nil)
+		testutils.RequireGRPCCodesEqual(t, err, codes.Aborted)
+	}
+
+	send("passkey", "", "", "", &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"})
+	send("passkey", "", "", "", &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"})
+	send("other-passkey", "", "", "", &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"})
+	send("passkey", "", "", "", &passkeysv1.CreateServiceExecRequest{Namespace: "other-namespace"})
+	send(
+		"passkey", "00000000-0000-0000-0000-000000000001", "", "",
+		&passkeysv1.CreateServiceExecRequest{Namespace: "namespace"},
+	)
+	send("passkey", "", "user-1", "", &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"})
+	send("passkey", "", "", "spiffe://cluster.local/ns/default/sa/accounts", &passkeysv1.CreateServiceExecRequest{
+		Namespace: "namespace",
+	})
+
+	// Fingerprints depend on the key of the server, so they cannot be computed from a guessed passkey.
+	_, err := otherKeyInterceptor(
+		newContext("passkey", "", "", ""), &passkeysv1.CreateServiceExecRequest{Namespace: "namespace"}, info, nil,
+	)
+	testutils.RequireGRPCCodesEqual(t, err, codes.Aborted)
+
+	require.Len(t, fingerprints, 8)
+	require.Equal(t, fingerprints[0], fingerprints[1])
+
+	for _, fingerprint := range fingerprints[2:] {
+		require.NotEqual(t, fingerprints[0], fingerprint)
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+)
+
+const (
+	// ErrorDomain is the domain of the ErrorInfo details sent by the service.
+	ErrorDomain = "passkeys.a-novel.com"
+	// ReasonInvalidPasskey tells a wrong secret apart from a caller that is not allowed to call the method, as both
+	// are reported with PermissionDenied.
+	ReasonInvalidPasskey = "INVALID_PASSKEY"
+)
+
+// handleInvalidPasskey converts a dao.ErrInvalidPasskey into a PermissionDenied status, with an ErrorInfo detail
+// whose reason is ReasonInvalidPasskey. Its signature is dictated by grpc.ErrorHandler.Test.
+func handleInvalidPasskey(err error) (error, bool) { //nolint:revive
+	if !errors.Is(err, dao.ErrInvalidPasskey) {
+		return nil, false
+	}
+
+	output, detailsErr := status.New(codes.PermissionDenied, err.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason: ReasonInvalidPasskey,
+		Domain: ErrorDomain,
+	})
+	if detailsErr != nil {
+		return status.Errorf(codes.PermissionDenied, "%s", err), true
+	}
+
+	return output.Err(), true //nolint:wrapcheck
+}
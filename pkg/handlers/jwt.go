@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+)
+
+// AuthorizationHeader is the metadata that carries the bearer token of a request.
+const AuthorizationHeader = "authorization"
+
+// ScopePrefix starts every scope understood by the service. Scopes look like "passkeys:<operation>:<namespace>",
+// where the namespace follows the same patterns as the mTLS permissions.
+const ScopePrefix = "passkeys"
+
+var (
+	ErrMissingBearerToken = errors.New("missing bearer token")
+	ErrMissingJWKS        = errors.New("no JWKS file or URL")
+)
+
+// SigningMethods lists the algorithms accepted for tokens. Only asymmetric algorithms are allowed, so a public key
+// cannot be used as an HMAC secret.
+var SigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// Claims of the bearer tokens accepted by the service.
+type Claims struct {
+	jwt.RegisteredClaims
+	// Scope is a space-separated list of scopes, as defined by RFC 8693.
+	Scope string `json:"scope,omitempty"`
+	// Tenant the requests of the caller are restricted to.
+	Tenant string `json:"tenant,omitempty"`
+}
+
+func (claims *Claims) allows(operation Operation, namespace string) bool {
+	for _, scope := range strings.Fields(claims.Scope) {
+		parts := strings.SplitN(scope, ":", 3)
+		if len(parts) != 3 || parts[0] != ScopePrefix || Operation(parts[1]) != operation {
+			continue
+		}
+
+		if matchNamespace(parts[2], namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type claimsContextKey struct{}
+
+// WithClaims attaches the claims of a verified token to a context.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the token a request was authenticated with, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+
+	return claims, ok
+}
+
+// SubjectFromContext returns the subject of the token a request was authenticated with. It is empty when the request
+// did not carry a token.
+func SubjectFromContext(ctx context.Context) string {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return claims.Subject
+}
+
+// TenantFromClaims restricts callers to the tenant of their token. It must run after the JWT interceptor.
+func TenantFromClaims(ctx context.Context) (string, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		// Public methods do not require a token.
+		return dao.DefaultTenant, nil
+	}
+
+	if len(claims.Tenant) > MaxTenantLength {
+		return "", ErrInvalidTenant
+	}
+
+	return claims.Tenant, nil
+}
+
+// ExtractBearerToken reads the bearer token of a request from its metadata.
+func ExtractBearerToken(ctx context.Context) string {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	authorizationRaw := incoming.Get(AuthorizationHeader)
+	if len(authorizationRaw) == 0 {
+		return ""
+	}
+
+	scheme, token, found := strings.Cut(authorizationRaw[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+// NewJWKSKeyfunc returns the keys used to verify tokens. Keys are read once from a local JWKS file, or fetched from
+// a URL and refreshed in the background until the context is canceled. The file takes precedence.
+func NewJWKSKeyfunc(ctx context.Context, file, url string) (jwt.Keyfunc, error) {
+	switch {
+	case file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read JWKS file: %w", err)
+		}
+
+		keys, err := keyfunc.NewJWKSetJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("decode JWKS file: %w", err)
+		}
+
+		return keys.Keyfunc, nil
+	case url != "":
+		keys, err := keyfunc.NewDefaultCtx(ctx, []string{url})
+		if err != nil {
+			return nil, fmt.Errorf("fetch JWKS: %w", err)
+		}
+
+		return keys.Keyfunc, nil
+	default:
+		return nil, ErrMissingJWKS
+	}
+}
+
+// NewJWTInterceptor returns a server interceptor that authenticates callers with the bearer token in their metadata,
+// and only lets them perform the operations granted by its scopes on the namespace of a request. Public methods do
+// not require a token.
+func NewJWTInterceptor(keys jwt.Keyfunc, options ...jwt.ParserOption) googlegrpc.UnaryServerInterceptor {
+	parser := jwt.NewParser(append([]jwt.ParserOption{
+		jwt.WithValidMethods(SigningMethods),
+		jwt.WithExpirationRequired(),
+	}, options...)...)
+
+	return func(
+		ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+	) (any, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		rawToken := ExtractBearerToken(ctx)
+		if rawToken == "" {
+			return nil, status.Error(codes.Unauthenticated, ErrMissingBearerToken.Error())
+		}
+
+		claims := new(Claims)
+		if _, err := parser.ParseWithClaims(rawToken, claims, keys); err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "verify token: %v", err)
+		}
+
+		getPermission, ok := MethodPermissions[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "method %s is not allowed", info.FullMethod)
+		}
+
+		operation, namespace := getPermission(req)
+		if !claims.allows(operation, namespace) {
+			return nil, status.Errorf(
+				codes.PermissionDenied, "missing scope %s:%s:%s", ScopePrefix, operation, namespace,
+			)
+		}
+
+		return handler(WithClaims(ctx, claims), req)
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const ListNamespacesServiceName = "list_namespaces"
+
+type ListNamespaces interface {
+	namespacesv1.ListServiceServer
+}
+
+type listNamespacesImpl struct {
+	service services.ListNamespaces
+}
+
+var handleListNamespacesError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidListNamespacesRequest, codes.InvalidArgument).
+	Handle
+
+func (handler *listNamespacesImpl) Exec(
+	ctx context.Context, request *namespacesv1.ListServiceExecRequest,
+) (*namespacesv1.ListServiceExecResponse, error) {
+	res, err := handler.service.Exec(ctx, &services.ListNamespacesRequest{
+		Limit:  int(request.GetLimit()),
+		Offset: int(request.GetOffset()),
+	})
+	if err != nil {
+		return nil, handleListNamespacesError(err)
+	}
+
+	return &namespacesv1.ListServiceExecResponse{
+		Namespaces: lo.Map(res.Namespaces, func(item *services.Namespace, _ int) *namespacesv1.Namespace {
+			return namespaceToProto(item)
+		}),
+	}, nil
+}
+
+func NewListNamespaces(service services.ListNamespaces, logger adapters.GRPC) ListNamespaces {
+	handler := &listNamespacesImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		ListNamespacesServiceName, lib.ServiceWithTracing("handlers.ListNamespaces", handler), logger,
+	)
+}
@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestListNamespaces(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *namespacesv1.ListServiceExecRequest
+
+		callServiceWith *services.ListNamespacesRequest
+		serviceResp     *services.ListNamespacesResponse
+		serviceErr      error
+
+		expect     *namespacesv1.ListServiceExecResponse
+		expectCode codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &namespacesv1.ListServiceExecRequest{
+				Limit:  10,
+				Offset: 5,
+			},
+
+			callServiceWith: &services.ListNamespacesRequest{
+				Limit:  10,
+				Offset: 5,
+			},
+			serviceResp: &services.ListNamespacesResponse{
+				Namespaces: []*services.Namespace{
+					{
+						Name:      "namespace",
+						Policy:    &services.NamespacePolicy{HashAlgorithm: "argon2id"},
+						CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+
+			expect: &namespacesv1.ListServiceExecResponse{
+				Namespaces: []*namespacesv1.Namespace{
+					{
+						Name:      "namespace",
+						Policy:    &namespacesv1.Policy{HashAlgorithm: "argon2id"},
+						CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &namespacesv1.ListServiceExecRequest{},
+
+			callServiceWith: &services.ListNamespacesRequest{},
+
+			serviceErr: services.ErrInvalidListNamespacesRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "InternalError",
+
+			request: &namespacesv1.ListServiceExecRequest{
+				Limit: 10,
+			},
+
+			callServiceWith: &services.ListNamespacesRequest{
+				Limit: 10,
+			},
+
+			serviceErr: errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := servicesmocks.NewMockListNamespaces(t)
+			logger := adaptersmocks.NewMockGRPC(t)
+
+			service.
+				On("Exec", context.Background(), testCase.callServiceWith).
+				Return(testCase.serviceResp, testCase.serviceErr)
+
+			logger.On("Report", handlers.ListNamespacesServiceName, mock.Anything)
+
+			handler := handlers.NewListNamespaces(service, logger)
+			resp, err := handler.Exec(context.Background(), testCase.request)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expect, resp)
+
+			service.AssertExpectations(t)
+			logger.AssertExpectations(t)
+		})
+	}
+}
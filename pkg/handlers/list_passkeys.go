@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const ListPasskeysServiceName = "list_passkeys"
+
+type ListPasskeys interface {
+	listingsv1.ListServiceServer
+}
+
+type listPasskeysImpl struct {
+	service services.ListPasskeys
+}
+
+var handleListPasskeysError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidListPasskeysRequest, codes.InvalidArgument).
+	Handle
+
+func (handler *listPasskeysImpl) Exec(
+	ctx context.Context, request *listingsv1.ListServiceExecRequest,
+) (*listingsv1.ListServiceExecResponse, error) {
+	res, err := handler.service.Exec(ctx, &services.ListPasskeysRequest{
+		Namespace:      request.GetNamespace(),
+		AfterNamespace: request.GetAfterNamespace(),
+		AfterID:        request.GetAfterId(),
+		Limit:          int(request.GetLimit()),
+	})
+	if err != nil {
+		return nil, handleListPasskeysError(err)
+	}
+
+	passkeys := make([]*listingsv1.Passkey, len(res.Passkeys))
+
+	for index, passkey := range res.Passkeys {
+		reward, err := grpc.StructOptional(passkey.Reward)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "convert reward: %v", err)
+		}
+
+		passkeys[index] = &listingsv1.Passkey{
+			Id:        passkey.ID,
+			Namespace: passkey.Namespace,
+			Reward:    reward,
+			SingleUse: passkey.SingleUse,
+			ExpiresAt: grpc.TimestampOptional(passkey.ExpiresAt),
+			CreatedAt: timestamppb.New(passkey.CreatedAt),
+			UpdatedAt: grpc.TimestampOptional(passkey.UpdatedAt),
+			Version:   passkey.Version,
+		}
+	}
+
+	return &listingsv1.ListServiceExecResponse{Passkeys: passkeys}, nil
+}
+
+func NewListPasskeys(service services.ListPasskeys, logger adapters.GRPC) ListPasskeys {
+	handler := &listPasskeysImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		ListPasskeysServiceName, lib.ServiceWithTracing("handlers.ListPasskeys", handler), logger,
+	)
+}
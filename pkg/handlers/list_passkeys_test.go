@@ -0,0 +1,136 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestListPasskeys(t *testing.T) {
+	reward, err := structpb.NewStruct(map[string]interface{}{"type": "reward"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+
+		request *listingsv1.ListServiceExecRequest
+
+		callServiceWith *services.ListPasskeysRequest
+		serviceResp     *services.ListPasskeysResponse
+		serviceErr      error
+
+		expect     *listingsv1.ListServiceExecResponse
+		expectCode codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &listingsv1.ListServiceExecRequest{
+				Namespace:      "namespace",
+				Limit:          10,
+				AfterNamespace: "namespace",
+				AfterId:        "00000000-0000-0000-0000-000000000001",
+			},
+
+			callServiceWith: &services.ListPasskeysRequest{
+				Namespace:      "namespace",
+				AfterNamespace: "namespace",
+				AfterID:        "00000000-0000-0000-0000-000000000001",
+				Limit:          10,
+			},
+			serviceResp: &services.ListPasskeysResponse{
+				Passkeys: []*services.ListedPasskey{
+					{
+						ID:        "00000000-0000-0000-0000-000000000002",
+						Namespace: "namespace",
+						Reward:    map[string]interface{}{"type": "reward"},
+						SingleUse: true,
+						ExpiresAt: lo.ToPtr(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+						CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+						Version:   2,
+					},
+				},
+			},
+
+			expect: &listingsv1.ListServiceExecResponse{
+				Passkeys: []*listingsv1.Passkey{
+					{
+						Id:        "00000000-0000-0000-0000-000000000002",
+						Namespace: "namespace",
+						Reward:    reward,
+						SingleUse: true,
+						ExpiresAt: timestamppb.New(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+						CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+						UpdatedAt: timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+						Version:   2,
+					},
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &listingsv1.ListServiceExecRequest{},
+
+			callServiceWith: &services.ListPasskeysRequest{},
+
+			serviceErr: services.ErrInvalidListPasskeysRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "InternalError",
+
+			request: &listingsv1.ListServiceExecRequest{
+				Limit: 10,
+			},
+
+			callServiceWith: &services.ListPasskeysRequest{
+				Limit: 10,
+			},
+
+			serviceErr: errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := servicesmocks.NewMockListPasskeys(t)
+			logger := adaptersmocks.NewMockGRPC(t)
+
+			service.
+				On("Exec", context.Background(), testCase.callServiceWith).
+				Return(testCase.serviceResp, testCase.serviceErr)
+
+			logger.On("Report", handlers.ListPasskeysServiceName, mock.Anything)
+
+			handler := handlers.NewListPasskeys(service, logger)
+			resp, err := handler.Exec(context.Background(), testCase.request)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expect, resp)
+
+			service.AssertExpectations(t)
+			logger.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+)
+
+// MockDeleteNamespace is an autogenerated mock type for the DeleteNamespace type
+type MockDeleteNamespace struct {
+	mock.Mock
+}
+
+type MockDeleteNamespace_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeleteNamespace) EXPECT() *MockDeleteNamespace_Expecter {
+	return &MockDeleteNamespace_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: _a0, _a1
+func (_m *MockDeleteNamespace) Exec(_a0 context.Context, _a1 *namespacesv1.DeleteServiceExecRequest) (*namespacesv1.DeleteServiceExecResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *namespacesv1.DeleteServiceExecResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *namespacesv1.DeleteServiceExecRequest) (*namespacesv1.DeleteServiceExecResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *namespacesv1.DeleteServiceExecRequest) *namespacesv1.DeleteServiceExecResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*namespacesv1.DeleteServiceExecResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *namespacesv1.DeleteServiceExecRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeleteNamespace_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockDeleteNamespace_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *namespacesv1.DeleteServiceExecRequest
+func (_e *MockDeleteNamespace_Expecter) Exec(_a0 interface{}, _a1 interface{}) *MockDeleteNamespace_Exec_Call {
+	return &MockDeleteNamespace_Exec_Call{Call: _e.mock.On("Exec", _a0, _a1)}
+}
+
+func (_c *MockDeleteNamespace_Exec_Call) Run(run func(_a0 context.Context, _a1 *namespacesv1.DeleteServiceExecRequest)) *MockDeleteNamespace_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*namespacesv1.DeleteServiceExecRequest))
+	})
+	return _c
+}
+
+func (_c *MockDeleteNamespace_Exec_Call) Return(_a0 *namespacesv1.DeleteServiceExecResponse, _a1 error) *MockDeleteNamespace_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeleteNamespace_Exec_Call) RunAndReturn(run func(context.Context, *namespacesv1.DeleteServiceExecRequest) (*namespacesv1.DeleteServiceExecResponse, error)) *MockDeleteNamespace_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeleteNamespace creates a new instance of MockDeleteNamespace. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeleteNamespace(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeleteNamespace {
+	mock := &MockDeleteNamespace{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
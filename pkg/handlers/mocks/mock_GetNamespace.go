@@ -0,0 +1,97 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+)
+
+// MockGetNamespace is an autogenerated mock type for the GetNamespace type
+type MockGetNamespace struct {
+	mock.Mock
+}
+
+type MockGetNamespace_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetNamespace) EXPECT() *MockGetNamespace_Expecter {
+	return &MockGetNamespace_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: _a0, _a1
+func (_m *MockGetNamespace) Exec(_a0 context.Context, _a1 *namespacesv1.GetServiceExecRequest) (*namespacesv1.GetServiceExecResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *namespacesv1.GetServiceExecResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *namespacesv1.GetServiceExecRequest) (*namespacesv1.GetServiceExecResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *namespacesv1.GetServiceExecRequest) *namespacesv1.GetServiceExecResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*namespacesv1.GetServiceExecResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *namespacesv1.GetServiceExecRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetNamespace_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockGetNamespace_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *namespacesv1.GetServiceExecRequest
+func (_e *MockGetNamespace_Expecter) Exec(_a0 interface{}, _a1 interface{}) *MockGetNamespace_Exec_Call {
+	return &MockGetNamespace_Exec_Call{Call: _e.mock.On("Exec", _a0, _a1)}
+}
+
+func (_c *MockGetNamespace_Exec_Call) Run(run func(_a0 context.Context, _a1 *namespacesv1.GetServiceExecRequest)) *MockGetNamespace_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*namespacesv1.GetServiceExecRequest))
+	})
+	return _c
+}
+
+func (_c *MockGetNamespace_Exec_Call) Return(_a0 *namespacesv1.GetServiceExecResponse, _a1 error) *MockGetNamespace_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetNamespace_Exec_Call) RunAndReturn(run func(context.Context, *namespacesv1.GetServiceExecRequest) (*namespacesv1.GetServiceExecResponse, error)) *MockGetNamespace_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetNamespace creates a new instance of MockGetNamespace. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetNamespace(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetNamespace {
+	mock := &MockGetNamespace{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
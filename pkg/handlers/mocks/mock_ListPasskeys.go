@@ -0,0 +1,97 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import (
+	context "context"
+
+	listingsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/listings/v1"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListPasskeys is an autogenerated mock type for the ListPasskeys type
+type MockListPasskeys struct {
+	mock.Mock
+}
+
+type MockListPasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListPasskeys) EXPECT() *MockListPasskeys_Expecter {
+	return &MockListPasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: _a0, _a1
+func (_m *MockListPasskeys) Exec(_a0 context.Context, _a1 *listingsv1.ListServiceExecRequest) (*listingsv1.ListServiceExecResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *listingsv1.ListServiceExecResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *listingsv1.ListServiceExecRequest) (*listingsv1.ListServiceExecResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *listingsv1.ListServiceExecRequest) *listingsv1.ListServiceExecResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*listingsv1.ListServiceExecResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *listingsv1.ListServiceExecRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListPasskeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockListPasskeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *listingsv1.ListServiceExecRequest
+func (_e *MockListPasskeys_Expecter) Exec(_a0 interface{}, _a1 interface{}) *MockListPasskeys_Exec_Call {
+	return &MockListPasskeys_Exec_Call{Call: _e.mock.On("Exec", _a0, _a1)}
+}
+
+func (_c *MockListPasskeys_Exec_Call) Run(run func(_a0 context.Context, _a1 *listingsv1.ListServiceExecRequest)) *MockListPasskeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*listingsv1.ListServiceExecRequest))
+	})
+	return _c
+}
+
+func (_c *MockListPasskeys_Exec_Call) Return(_a0 *listingsv1.ListServiceExecResponse, _a1 error) *MockListPasskeys_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListPasskeys_Exec_Call) RunAndReturn(run func(context.Context, *listingsv1.ListServiceExecRequest) (*listingsv1.ListServiceExecResponse, error)) *MockListPasskeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListPasskeys creates a new instance of MockListPasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListPasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListPasskeys {
+	mock := &MockListPasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
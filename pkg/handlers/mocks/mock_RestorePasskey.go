@@ -0,0 +1,97 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+)
+
+// MockRestorePasskey is an autogenerated mock type for the RestorePasskey type
+type MockRestorePasskey struct {
+	mock.Mock
+}
+
+type MockRestorePasskey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRestorePasskey) EXPECT() *MockRestorePasskey_Expecter {
+	return &MockRestorePasskey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: _a0, _a1
+func (_m *MockRestorePasskey) Exec(_a0 context.Context, _a1 *revocationsv1.RestoreServiceExecRequest) (*revocationsv1.RestoreServiceExecResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *revocationsv1.RestoreServiceExecResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *revocationsv1.RestoreServiceExecRequest) (*revocationsv1.RestoreServiceExecResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *revocationsv1.RestoreServiceExecRequest) *revocationsv1.RestoreServiceExecResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*revocationsv1.RestoreServiceExecResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *revocationsv1.RestoreServiceExecRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRestorePasskey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRestorePasskey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *revocationsv1.RestoreServiceExecRequest
+func (_e *MockRestorePasskey_Expecter) Exec(_a0 interface{}, _a1 interface{}) *MockRestorePasskey_Exec_Call {
+	return &MockRestorePasskey_Exec_Call{Call: _e.mock.On("Exec", _a0, _a1)}
+}
+
+func (_c *MockRestorePasskey_Exec_Call) Run(run func(_a0 context.Context, _a1 *revocationsv1.RestoreServiceExecRequest)) *MockRestorePasskey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*revocationsv1.RestoreServiceExecRequest))
+	})
+	return _c
+}
+
+func (_c *MockRestorePasskey_Exec_Call) Return(_a0 *revocationsv1.RestoreServiceExecResponse, _a1 error) *MockRestorePasskey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRestorePasskey_Exec_Call) RunAndReturn(run func(context.Context, *revocationsv1.RestoreServiceExecRequest) (*revocationsv1.RestoreServiceExecResponse, error)) *MockRestorePasskey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRestorePasskey creates a new instance of MockRestorePasskey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRestorePasskey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRestorePasskey {
+	mock := &MockRestorePasskey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+)
+
+// MockRevokePasskey is an autogenerated mock type for the RevokePasskey type
+type MockRevokePasskey struct {
+	mock.Mock
+}
+
+type MockRevokePasskey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevokePasskey) EXPECT() *MockRevokePasskey_Expecter {
+	return &MockRevokePasskey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: _a0, _a1
+func (_m *MockRevokePasskey) Exec(_a0 context.Context, _a1 *revocationsv1.RevokeServiceExecRequest) (*revocationsv1.RevokeServiceExecResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *revocationsv1.RevokeServiceExecResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *revocationsv1.RevokeServiceExecRequest) (*revocationsv1.RevokeServiceExecResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *revocationsv1.RevokeServiceExecRequest) *revocationsv1.RevokeServiceExecResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*revocationsv1.RevokeServiceExecResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *revocationsv1.RevokeServiceExecRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRevokePasskey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRevokePasskey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *revocationsv1.RevokeServiceExecRequest
+func (_e *MockRevokePasskey_Expecter) Exec(_a0 interface{}, _a1 interface{}) *MockRevokePasskey_Exec_Call {
+	return &MockRevokePasskey_Exec_Call{Call: _e.mock.On("Exec", _a0, _a1)}
+}
+
+func (_c *MockRevokePasskey_Exec_Call) Run(run func(_a0 context.Context, _a1 *revocationsv1.RevokeServiceExecRequest)) *MockRevokePasskey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*revocationsv1.RevokeServiceExecRequest))
+	})
+	return _c
+}
+
+func (_c *MockRevokePasskey_Exec_Call) Return(_a0 *revocationsv1.RevokeServiceExecResponse, _a1 error) *MockRevokePasskey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRevokePasskey_Exec_Call) RunAndReturn(run func(context.Context, *revocationsv1.RevokeServiceExecRequest) (*revocationsv1.RevokeServiceExecResponse, error)) *MockRevokePasskey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevokePasskey creates a new instance of MockRevokePasskey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevokePasskey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevokePasskey {
+	mock := &MockRevokePasskey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTenantResolver is an autogenerated mock type for the TenantResolver type
+type MockTenantResolver struct {
+	mock.Mock
+}
+
+type MockTenantResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTenantResolver) EXPECT() *MockTenantResolver_Expecter {
+	return &MockTenantResolver_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx
+func (_m *MockTenantResolver) Execute(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTenantResolver_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockTenantResolver_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTenantResolver_Expecter) Execute(ctx interface{}) *MockTenantResolver_Execute_Call {
+	return &MockTenantResolver_Execute_Call{Call: _e.mock.On("Execute", ctx)}
+}
+
+func (_c *MockTenantResolver_Execute_Call) Run(run func(ctx context.Context)) *MockTenantResolver_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTenantResolver_Execute_Call) Return(_a0 string, _a1 error) *MockTenantResolver_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTenantResolver_Execute_Call) RunAndReturn(run func(context.Context) (string, error)) *MockTenantResolver_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTenantResolver creates a new instance of MockTenantResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTenantResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTenantResolver {
+	mock := &MockTenantResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
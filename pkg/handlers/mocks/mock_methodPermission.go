@@ -0,0 +1,91 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import (
+	handlers "github.com/a-novel/uservice-passkeys/pkg/handlers"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockmethodPermission is an autogenerated mock type for the methodPermission type
+type MockmethodPermission struct {
+	mock.Mock
+}
+
+type MockmethodPermission_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockmethodPermission) EXPECT() *MockmethodPermission_Expecter {
+	return &MockmethodPermission_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: req
+func (_m *MockmethodPermission) Execute(req any) (handlers.Operation, string) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 handlers.Operation
+	var r1 string
+	if rf, ok := ret.Get(0).(func(any) (handlers.Operation, string)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(any) handlers.Operation); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(handlers.Operation)
+	}
+
+	if rf, ok := ret.Get(1).(func(any) string); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// MockmethodPermission_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockmethodPermission_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - req any
+func (_e *MockmethodPermission_Expecter) Execute(req interface{}) *MockmethodPermission_Execute_Call {
+	return &MockmethodPermission_Execute_Call{Call: _e.mock.On("Execute", req)}
+}
+
+func (_c *MockmethodPermission_Execute_Call) Run(run func(req any)) *MockmethodPermission_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(any))
+	})
+	return _c
+}
+
+func (_c *MockmethodPermission_Execute_Call) Return(_a0 handlers.Operation, _a1 string) *MockmethodPermission_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockmethodPermission_Execute_Call) RunAndReturn(run func(any) (handlers.Operation, string)) *MockmethodPermission_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockmethodPermission creates a new instance of MockmethodPermission. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockmethodPermission(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockmethodPermission {
+	mock := &MockmethodPermission{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
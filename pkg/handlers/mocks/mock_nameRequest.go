@@ -0,0 +1,77 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MocknameRequest is an autogenerated mock type for the nameRequest type
+type MocknameRequest struct {
+	mock.Mock
+}
+
+type MocknameRequest_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MocknameRequest) EXPECT() *MocknameRequest_Expecter {
+	return &MocknameRequest_Expecter{mock: &_m.Mock}
+}
+
+// GetName provides a mock function with given fields:
+func (_m *MocknameRequest) GetName() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MocknameRequest_GetName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetName'
+type MocknameRequest_GetName_Call struct {
+	*mock.Call
+}
+
+// GetName is a helper method to define mock.On call
+func (_e *MocknameRequest_Expecter) GetName() *MocknameRequest_GetName_Call {
+	return &MocknameRequest_GetName_Call{Call: _e.mock.On("GetName")}
+}
+
+func (_c *MocknameRequest_GetName_Call) Run(run func()) *MocknameRequest_GetName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MocknameRequest_GetName_Call) Return(_a0 string) *MocknameRequest_GetName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MocknameRequest_GetName_Call) RunAndReturn(run func() string) *MocknameRequest_GetName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMocknameRequest creates a new instance of MocknameRequest. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMocknameRequest(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MocknameRequest {
+	mock := &MocknameRequest{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
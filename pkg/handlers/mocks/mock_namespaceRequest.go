@@ -0,0 +1,77 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package handlersmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MocknamespaceRequest is an autogenerated mock type for the namespaceRequest type
+type MocknamespaceRequest struct {
+	mock.Mock
+}
+
+type MocknamespaceRequest_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MocknamespaceRequest) EXPECT() *MocknamespaceRequest_Expecter {
+	return &MocknamespaceRequest_Expecter{mock: &_m.Mock}
+}
+
+// GetNamespace provides a mock function with given fields:
+func (_m *MocknamespaceRequest) GetNamespace() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespace")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MocknamespaceRequest_GetNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNamespace'
+type MocknamespaceRequest_GetNamespace_Call struct {
+	*mock.Call
+}
+
+// GetNamespace is a helper method to define mock.On call
+func (_e *MocknamespaceRequest_Expecter) GetNamespace() *MocknamespaceRequest_GetNamespace_Call {
+	return &MocknamespaceRequest_GetNamespace_Call{Call: _e.mock.On("GetNamespace")}
+}
+
+func (_c *MocknamespaceRequest_GetNamespace_Call) Run(run func()) *MocknamespaceRequest_GetNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MocknamespaceRequest_GetNamespace_Call) Return(_a0 string) *MocknamespaceRequest_GetNamespace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MocknamespaceRequest_GetNamespace_Call) RunAndReturn(run func() string) *MocknamespaceRequest_GetNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMocknamespaceRequest creates a new instance of MocknamespaceRequest. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMocknamespaceRequest(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MocknamespaceRequest {
+	mock := &MocknamespaceRequest{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"math"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/a-novel/golib/grpc"
+
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func namespacePolicyFromProto(policy *namespacesv1.Policy) *services.NamespacePolicy {
+	if policy == nil {
+		return nil
+	}
+
+	output := &services.NamespacePolicy{
+		DefaultTTL:         grpc.DurationOptionalProto(policy.GetDefaultTtl()),
+		MaxTTL:             grpc.DurationOptionalProto(policy.GetMaxTtl()),
+		HashAlgorithm:      policy.GetHashAlgorithm(),
+		SingleUseDefault:   policy.GetSingleUseDefault(),
+		CreationRateWindow: grpc.DurationOptionalProto(policy.GetCreationRateWindow()),
+		SecretHistorySize:  int(policy.GetSecretHistorySize()),
+	}
+
+	if policy.MaxActivePasskeys != nil {
+		output.MaxActivePasskeys = lo.ToPtr(int(policy.GetMaxActivePasskeys()))
+	}
+
+	if policy.CreationRateLimit != nil {
+		output.CreationRateLimit = lo.ToPtr(int(policy.GetCreationRateLimit()))
+	}
+
+	if params := policy.GetHashParams(); params != nil {
+		output.HashParams = &services.NamespaceHashParams{
+			SaltLength:  uint(params.GetSaltLength()),
+			Iterations:  params.GetIterations(),
+			Memory:      params.GetMemory(),
+			Parallelism: uint8(min(params.GetParallelism(), math.MaxUint8)),
+			KeyLength:   params.GetKeyLength(),
+		}
+	}
+
+	if rules := policy.GetStrengthRules(); rules != nil {
+		output.StrengthRules = &services.NamespaceStrengthRules{
+			MinLength:        int(rules.GetMinLength()),
+			MaxLength:        int(rules.GetMaxLength()),
+			RequireLowercase: rules.GetRequireLowercase(),
+			RequireUppercase: rules.GetRequireUppercase(),
+			RequireDigit:     rules.GetRequireDigit(),
+			RequireSymbol:    rules.GetRequireSymbol(),
+		}
+	}
+
+	return output
+}
+
+func namespaceToProto(namespace *services.Namespace) *namespacesv1.Namespace {
+	policy := &namespacesv1.Policy{
+		DefaultTtl:         grpc.DurationOptional(namespace.Policy.DefaultTTL),
+		MaxTtl:             grpc.DurationOptional(namespace.Policy.MaxTTL),
+		HashAlgorithm:      namespace.Policy.HashAlgorithm,
+		SingleUseDefault:   namespace.Policy.SingleUseDefault,
+		CreationRateWindow: grpc.DurationOptional(namespace.Policy.CreationRateWindow),
+		SecretHistorySize:  int32(min(namespace.Policy.SecretHistorySize, math.MaxInt32)),
+	}
+
+	if namespace.Policy.MaxActivePasskeys != nil {
+		policy.MaxActivePasskeys = lo.ToPtr(int32(min(*namespace.Policy.MaxActivePasskeys, math.MaxInt32)))
+	}
+
+	if namespace.Policy.CreationRateLimit != nil {
+		policy.CreationRateLimit = lo.ToPtr(int32(min(*namespace.Policy.CreationRateLimit, math.MaxInt32)))
+	}
+
+	if params := namespace.Policy.HashParams; params != nil {
+		policy.HashParams = &namespacesv1.HashParams{
+			SaltLength:  uint32(min(params.SaltLength, math.MaxUint32)),
+			Iterations:  params.Iterations,
+			Memory:      params.Memory,
+			Parallelism: uint32(params.Parallelism),
+			KeyLength:   params.KeyLength,
+		}
+	}
+
+	if rules := namespace.Policy.StrengthRules; rules != nil {
+		policy.StrengthRules = &namespacesv1.StrengthRules{
+			MinLength:        int32(min(rules.MinLength, math.MaxInt32)),
+			MaxLength:        int32(min(rules.MaxLength, math.MaxInt32)),
+			RequireLowercase: rules.RequireLowercase,
+			RequireUppercase: rules.RequireUppercase,
+			RequireDigit:     rules.RequireDigit,
+			RequireSymbol:    rules.RequireSymbol,
+		}
+	}
+
+	return &namespacesv1.Namespace{
+		Name:      namespace.Name,
+		Policy:    policy,
+		CreatedAt: timestamppb.New(namespace.CreatedAt),
+		UpdatedAt: grpc.TimestampOptional(namespace.UpdatedAt),
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+)
+
+// handleQuotaExceeded converts a dao.QuotaExceededError into a ResourceExhausted status, with the quota attached
+// as a QuotaFailure detail. Its signature is dictated by grpc.ErrorHandler.Test.
+func handleQuotaExceeded(err error) (error, bool) { //nolint:revive
+	var quotaErr *dao.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return nil, false
+	}
+
+	description := fmt.Sprintf("at most %d %s", quotaErr.Limit, quotaErr.Quota)
+	if quotaErr.Window > 0 {
+		description = fmt.Sprintf("at most %d passkeys created per %s", quotaErr.Limit, quotaErr.Window)
+	}
+
+	output, detailsErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "namespace:" + quotaErr.Namespace, Description: description},
+		},
+	})
+	if detailsErr != nil {
+		return status.Errorf(codes.ResourceExhausted, "%s", err), true
+	}
+
+	return output.Err(), true //nolint:wrapcheck
+}
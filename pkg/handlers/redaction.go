@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+// SecretMetadata lists the request metadata that carry secrets.
+var SecretMetadata = []string{"password", "current-password", AuthorizationHeader}
+
+// RequestSecrets returns the secrets sent in the metadata of a request. Bearer tokens are returned both with and
+// without their scheme.
+func RequestSecrets(ctx context.Context) []string {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var secrets []string
+
+	for _, key := range SecretMetadata {
+		for _, value := range incoming.Get(key) {
+			secrets = append(secrets, value)
+
+			if _, token, isBearer := strings.Cut(value, " "); isBearer {
+				secrets = append(secrets, token)
+			}
+		}
+	}
+
+	return secrets
+}
+
+// NewRedactionInterceptor returns a server interceptor that keeps secrets out of logs and error messages. The secrets
+// of a request are tracked by the redactor while it runs, so they are masked in any log the request writes. It must
+// run first, so the errors of every other interceptor are redacted.
+func NewRedactionInterceptor(redactor *lib.Redactor) googlegrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, _ *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+	) (any, error) {
+		release := redactor.Track(RequestSecrets(ctx)...)
+		defer release()
+
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, redactError(redactor, err)
+		}
+
+		return res, nil
+	}
+}
+
+func redactError(redactor *lib.Redactor, err error) error {
+	current := status.Convert(err)
+
+	message := redactor.Redact(current.Message())
+	if message == current.Message() {
+		return err
+	}
+
+	// Details are kept, only the message is rewritten.
+	redacted := current.Proto()
+	redacted.Message = message
+
+	return status.FromProto(redacted).Err() //nolint:wrapcheck
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const RestorePasskeyServiceName = "restore_passkey"
+
+type RestorePasskey interface {
+	revocationsv1.RestoreServiceServer
+}
+
+type restorePasskeyImpl struct {
+	service services.RestorePasskey
+}
+
+var handleRestorePasskeyError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidRestorePasskeyRequest, codes.InvalidArgument).
+	Is(dao.ErrPasskeyNotFound, codes.NotFound).
+	Is(dao.ErrPasskeyNotRevoked, codes.FailedPrecondition).
+	Is(dao.ErrRestoreWindowExpired, codes.FailedPrecondition).
+	Handle
+
+func (handler *restorePasskeyImpl) Exec(
+	ctx context.Context, request *revocationsv1.RestoreServiceExecRequest,
+) (*revocationsv1.RestoreServiceExecResponse, error) {
+	res, err := handler.service.Exec(ctx, &services.RestorePasskeyRequest{
+		ID:        request.GetId(),
+		Namespace: request.GetNamespace(),
+	})
+	if err != nil {
+		return nil, handleRestorePasskeyError(err)
+	}
+
+	reward, err := grpc.StructOptional(res.Reward)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "convert reward: %v", err)
+	}
+
+	SendVersion(ctx, res.Version)
+
+	return &revocationsv1.RestoreServiceExecResponse{
+		Passkey: &revocationsv1.Passkey{
+			Id:        res.ID,
+			Namespace: res.Namespace,
+			Reward:    reward,
+			ExpiresAt: grpc.TimestampOptional(res.ExpiresAt),
+			CreatedAt: timestamppb.New(res.CreatedAt),
+			UpdatedAt: grpc.TimestampOptional(res.UpdatedAt),
+			Version:   res.Version,
+		},
+	}, nil
+}
+
+func NewRestorePasskey(service services.RestorePasskey, logger adapters.GRPC) RestorePasskey {
+	handler := &restorePasskeyImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		RestorePasskeyServiceName, lib.ServiceWithTracing("handlers.RestorePasskey", handler), logger,
+	)
+}
@@ -0,0 +1,175 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestRestorePasskey(t *testing.T) {
+	reward, err := structpb.NewStruct(map[string]interface{}{"type": "reward"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+
+		request *revocationsv1.RestoreServiceExecRequest
+
+		callServiceWith *services.RestorePasskeyRequest
+		serviceResp     *services.RestorePasskeyResponse
+		serviceErr      error
+
+		expect     *revocationsv1.RestoreServiceExecResponse
+		expectCode codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &revocationsv1.RestoreServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.RestorePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+			},
+			serviceResp: &services.RestorePasskeyResponse{
+				ID:        "id",
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"type": "reward"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   3,
+			},
+
+			expect: &revocationsv1.RestoreServiceExecResponse{
+				Passkey: &revocationsv1.Passkey{
+					Id:        "id",
+					Namespace: "namespace",
+					Reward:    reward,
+					CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					UpdatedAt: timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+					Version:   3,
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &revocationsv1.RestoreServiceExecRequest{
+				Id: "id",
+			},
+
+			callServiceWith: &services.RestorePasskeyRequest{
+				ID: "id",
+			},
+			serviceErr: services.ErrInvalidRestorePasskeyRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "NotFound",
+
+			request: &revocationsv1.RestoreServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.RestorePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+			},
+			serviceErr: dao.ErrPasskeyNotFound,
+
+			expectCode: codes.NotFound,
+		},
+		{
+			name: "NotRevoked",
+
+			request: &revocationsv1.RestoreServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.RestorePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+			},
+			serviceErr: dao.ErrPasskeyNotRevoked,
+
+			expectCode: codes.FailedPrecondition,
+		},
+		{
+			name: "RestoreWindowExpired",
+
+			request: &revocationsv1.RestoreServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.RestorePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+			},
+			serviceErr: dao.ErrRestoreWindowExpired,
+
+			expectCode: codes.FailedPrecondition,
+		},
+		{
+			name: "InternalError",
+
+			request: &revocationsv1.RestoreServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.RestorePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+			},
+			serviceErr: errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := servicesmocks.NewMockRestorePasskey(t)
+			logger := adaptersmocks.NewMockGRPC(t)
+
+			service.
+				On("Exec", context.Background(), testCase.callServiceWith).
+				Return(testCase.serviceResp, testCase.serviceErr)
+
+			logger.On("Report", handlers.RestorePasskeyServiceName, mock.Anything)
+
+			handler := handlers.NewRestorePasskey(service, logger)
+			resp, err := handler.Exec(context.Background(), testCase.request)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expect, resp)
+
+			service.AssertExpectations(t)
+			logger.AssertExpectations(t)
+		})
+	}
+}
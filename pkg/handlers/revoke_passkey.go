@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const RevokePasskeyServiceName = "revoke_passkey"
+
+type RevokePasskey interface {
+	revocationsv1.RevokeServiceServer
+}
+
+type revokePasskeyImpl struct {
+	service services.RevokePasskey
+}
+
+var handleRevokePasskeyError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidRevokePasskeyRequest, codes.InvalidArgument).
+	Is(dao.ErrPasskeyNotFound, codes.NotFound).
+	Handle
+
+func (handler *revokePasskeyImpl) Exec(
+	ctx context.Context, request *revocationsv1.RevokeServiceExecRequest,
+) (*revocationsv1.RevokeServiceExecResponse, error) {
+	// Authenticated callers are recorded as themselves, so they cannot revoke passkeys in the name of someone else.
+	// The value sent in the request is only trusted when the server does not authenticate its callers.
+	revokedBy, authenticated := CallerFromContext(ctx)
+	if !authenticated {
+		revokedBy = request.GetRevokedBy()
+	}
+
+	res, err := handler.service.Exec(ctx, &services.RevokePasskeyRequest{
+		ID:        request.GetId(),
+		Namespace: request.GetNamespace(),
+		RevokedBy: revokedBy,
+		Reason:    request.GetReason(),
+	})
+	if err != nil {
+		return nil, handleRevokePasskeyError(err)
+	}
+
+	reward, err := grpc.StructOptional(res.Reward)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "convert reward: %v", err)
+	}
+
+	SendVersion(ctx, res.Version)
+
+	return &revocationsv1.RevokeServiceExecResponse{
+		Passkey: &revocationsv1.Passkey{
+			Id:               res.ID,
+			Namespace:        res.Namespace,
+			Reward:           reward,
+			ExpiresAt:        grpc.TimestampOptional(res.ExpiresAt),
+			CreatedAt:        timestamppb.New(res.CreatedAt),
+			UpdatedAt:        grpc.TimestampOptional(res.UpdatedAt),
+			RevokedAt:        timestamppb.New(res.RevokedAt),
+			RevokedBy:        res.RevokedBy,
+			RevocationReason: res.RevocationReason,
+			Version:          res.Version,
+		},
+	}, nil
+}
+
+func NewRevokePasskey(service services.RevokePasskey, logger adapters.GRPC) RevokePasskey {
+	handler := &revokePasskeyImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		RevokePasskeyServiceName, lib.ServiceWithTracing("handlers.RevokePasskey", handler), logger,
+	)
+}
@@ -0,0 +1,252 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	revocationsv1 "github.com/a-novel/uservice-passkeys/pkg/proto/revocations/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestRevokePasskey(t *testing.T) {
+	reward, err := structpb.NewStruct(map[string]interface{}{"type": "reward"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+
+		request *revocationsv1.RevokeServiceExecRequest
+		subject string
+		caller  string
+
+		callServiceWith *services.RevokePasskeyRequest
+		serviceResp     *services.RevokePasskeyResponse
+		serviceErr      error
+
+		expect     *revocationsv1.RevokeServiceExecResponse
+		expectCode codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &revocationsv1.RevokeServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+
+			callServiceWith: &services.RevokePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+			serviceResp: &services.RevokePasskeyResponse{
+				ID:               "id",
+				Namespace:        "namespace",
+				Reward:           map[string]interface{}{"type": "reward"},
+				CreatedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				RevokedAt:        time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				RevokedBy:        "admin",
+				RevocationReason: "leaked",
+				Version:          2,
+			},
+
+			expect: &revocationsv1.RevokeServiceExecResponse{
+				Passkey: &revocationsv1.Passkey{
+					Id:               "id",
+					Namespace:        "namespace",
+					Reward:           reward,
+					CreatedAt:        timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					RevokedAt:        timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+					RevokedBy:        "admin",
+					RevocationReason: "leaked",
+					Version:          2,
+				},
+			},
+		},
+		{
+			name: "OK/Subject",
+
+			request: &revocationsv1.RevokeServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+			subject: "user-1",
+
+			callServiceWith: &services.RevokePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+				RevokedBy: "user-1",
+				Reason:    "leaked",
+			},
+			serviceResp: &services.RevokePasskeyResponse{
+				ID:               "id",
+				Namespace:        "namespace",
+				CreatedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:          2,
+				RevokedAt:        time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				RevokedBy:        "user-1",
+				RevocationReason: "leaked",
+			},
+
+			expect: &revocationsv1.RevokeServiceExecResponse{
+				Passkey: &revocationsv1.Passkey{
+					Id:               "id",
+					Namespace:        "namespace",
+					CreatedAt:        timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Version:          2,
+					RevokedAt:        timestamppb.New(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+					RevokedBy:        "user-1",
+					RevocationReason: "leaked",
+				},
+			},
+		},
+		{
+			name: "OK/Certificate",
+
+			request: &revocationsv1.RevokeServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+			caller: "spiffe://cluster.local/ns/default/sa/support",
+
+			callServiceWith: &services.RevokePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+				RevokedBy: "spiffe://cluster.local/ns/default/sa/support",
+				Reason:    "leaked",
+			},
+			serviceResp: &services.RevokePasskeyResponse{
+				ID:               "id",
+				Namespace:        "namespace",
+				CreatedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:          2,
+				RevokedAt:        time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				RevokedBy:        "spiffe://cluster.local/ns/default/sa/support",
+				RevocationReason: "leaked",
+			},
+
+			expect: &revocationsv1.RevokeServiceExecResponse{
+				Passkey: &revocationsv1.Passkey{
+					Id:               "id",
+					Namespace:        "namespace",
+					CreatedAt:        timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Version:          2,
+					RevokedAt:        timestamppb.New(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+					RevokedBy:        "spiffe://cluster.local/ns/default/sa/support",
+					RevocationReason: "leaked",
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &revocationsv1.RevokeServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.RevokePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+			},
+			serviceErr: services.ErrInvalidRevokePasskeyRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "NotFound",
+
+			request: &revocationsv1.RevokeServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+
+			callServiceWith: &services.RevokePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+			serviceErr: dao.ErrPasskeyNotFound,
+
+			expectCode: codes.NotFound,
+		},
+		{
+			name: "InternalError",
+
+			request: &revocationsv1.RevokeServiceExecRequest{
+				Id:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+
+			callServiceWith: &services.RevokePasskeyRequest{
+				ID:        "id",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+			serviceErr: errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := servicesmocks.NewMockRevokePasskey(t)
+			logger := adaptersmocks.NewMockGRPC(t)
+
+			ctx := context.Background()
+			if testCase.subject != "" {
+				ctx = handlers.WithClaims(ctx, &handlers.Claims{
+					RegisteredClaims: jwt.RegisteredClaims{Subject: testCase.subject},
+				})
+			}
+
+			if testCase.caller != "" {
+				ctx = handlers.WithCaller(ctx, testCase.caller)
+			}
+
+			service.
+				On("Exec", ctx, testCase.callServiceWith).
+				Return(testCase.serviceResp, testCase.serviceErr)
+
+			logger.On("Report", handlers.RevokePasskeyServiceName, mock.Anything)
+
+			handler := handlers.NewRevokePasskey(service, logger)
+			resp, err := handler.Exec(ctx, testCase.request)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expect, resp)
+
+			service.AssertExpectations(t)
+			logger.AssertExpectations(t)
+		})
+	}
+}
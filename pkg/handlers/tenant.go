@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+)
+
+// TenantHeader is the metadata that carries the tenant of a request.
+const TenantHeader = "tenant"
+
+// MaxTenantLength is the maximum length of a tenant identifier.
+const MaxTenantLength = 256
+
+var ErrInvalidTenant = errors.New("invalid tenant")
+
+// TenantResolver returns the tenant of the caller of a request. Requests without a tenant use dao.DefaultTenant.
+type TenantResolver func(ctx context.Context) (string, error)
+
+// TenantFromMetadata trusts the tenant sent in the request metadata. The service must then run behind a gateway that
+// authenticates callers, and overrides this metadata with their tenant.
+func TenantFromMetadata(ctx context.Context) (string, error) {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return dao.DefaultTenant, nil
+	}
+
+	tenantRaw := incoming.Get(TenantHeader)
+	if len(tenantRaw) == 0 {
+		return dao.DefaultTenant, nil
+	}
+
+	if len(tenantRaw[0]) > MaxTenantLength {
+		return "", ErrInvalidTenant
+	}
+
+	return tenantRaw[0], nil
+}
+
+// NewTenantInterceptor returns a server interceptor that restricts the queries of a request to the rows of its tenant.
+// It must run before any interceptor that reaches the database.
+func NewTenantInterceptor(resolve TenantResolver) googlegrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, _ *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+	) (any, error) {
+		tenant, err := resolve(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "resolve tenant: %v", err)
+		}
+
+		return handler(dao.WithTenant(ctx, tenant), req)
+	}
+}
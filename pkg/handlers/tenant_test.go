@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+)
+
+func TestTenantInterceptor(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		metadata map[string]string
+		resolver handlers.TenantResolver
+
+		expectTenant string
+		expectCode   codes.Code
+	}{
+		{
+			name: "OK",
+
+			metadata: map[string]string{"tenant": "tenant-a"},
+			resolver: handlers.TenantFromMetadata,
+
+			expectTenant: "tenant-a",
+		},
+		{
+			name: "OK/Default",
+
+			resolver: handlers.TenantFromMetadata,
+
+			expectTenant: dao.DefaultTenant,
+		},
+		{
+			name: "TenantTooLong",
+
+			metadata: map[string]string{"tenant": strings.Repeat("a", handlers.MaxTenantLength+1)},
+			resolver: handlers.TenantFromMetadata,
+
+			expectCode: codes.Unauthenticated,
+		},
+		{
+			name: "ResolverError",
+
+			resolver: func(_ context.Context) (string, error) {
+				return "", errors.New("uwups")
+			},
+
+			expectCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(testCase.metadata))
+
+			var tenant *string
+
+			handler := func(ctx context.Context, _ any) (any, error) {
+				tenant = new(string)
+				*tenant = dao.TenantFromContext(ctx)
+
+				return "response", nil
+			}
+
+			interceptor := handlers.NewTenantInterceptor(testCase.resolver)
+			resp, err := interceptor(ctx, "request", &googlegrpc.UnaryServerInfo{}, handler)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+
+			if testCase.expectCode == codes.OK {
+				require.Equal(t, "response", resp)
+				require.NotNil(t, tenant)
+				require.Equal(t, testCase.expectTenant, *tenant)
+			} else {
+				require.Nil(t, tenant)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/a-novel/golib/grpc"
+	"github.com/a-novel/golib/loggers/adapters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+const UpdateNamespaceServiceName = "update_namespace"
+
+type UpdateNamespace interface {
+	namespacesv1.UpdateServiceServer
+}
+
+type updateNamespaceImpl struct {
+	service services.UpdateNamespace
+}
+
+var handleUpdateNamespaceError = grpc.HandleError(codes.Internal).
+	Is(services.ErrInvalidUpdateNamespaceRequest, codes.InvalidArgument).
+	Is(dao.ErrNamespaceNotFound, codes.NotFound).
+	Handle
+
+func (handler *updateNamespaceImpl) Exec(
+	ctx context.Context, request *namespacesv1.UpdateServiceExecRequest,
+) (*namespacesv1.UpdateServiceExecResponse, error) {
+	res, err := handler.service.Exec(ctx, &services.UpdateNamespaceRequest{
+		Name:   request.GetName(),
+		Policy: namespacePolicyFromProto(request.GetPolicy()),
+	})
+	if err != nil {
+		return nil, handleUpdateNamespaceError(err)
+	}
+
+	return &namespacesv1.UpdateServiceExecResponse{Namespace: namespaceToProto(res)}, nil
+}
+
+func NewUpdateNamespace(service services.UpdateNamespace, logger adapters.GRPC) UpdateNamespace {
+	handler := &updateNamespaceImpl{service: service}
+	return grpc.ServiceWithMetrics(
+		UpdateNamespaceServiceName, lib.ServiceWithTracing("handlers.UpdateNamespace", handler), logger,
+	)
+}
@@ -0,0 +1,139 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
+	"github.com/a-novel/golib/testutils"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/handlers"
+	namespacesv1 "github.com/a-novel/uservice-passkeys/pkg/proto/namespaces/v1"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestUpdateNamespace(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *namespacesv1.UpdateServiceExecRequest
+
+		callServiceWith *services.UpdateNamespaceRequest
+		serviceResp     *services.Namespace
+		serviceErr      error
+
+		expect     *namespacesv1.UpdateServiceExecResponse
+		expectCode codes.Code
+	}{
+		{
+			name: "OK",
+
+			request: &namespacesv1.UpdateServiceExecRequest{
+				Name:   "namespace",
+				Policy: &namespacesv1.Policy{MaxTtl: durationpb.New(time.Hour)},
+			},
+
+			callServiceWith: &services.UpdateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{MaxTTL: lo.ToPtr(time.Hour)},
+			},
+			serviceResp: &services.Namespace{
+				Name:      "namespace",
+				Policy:    &services.NamespacePolicy{MaxTTL: lo.ToPtr(time.Hour), HashAlgorithm: "argon2id"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &namespacesv1.UpdateServiceExecResponse{
+				Namespace: &namespacesv1.Namespace{
+					Name:      "namespace",
+					Policy:    &namespacesv1.Policy{MaxTtl: durationpb.New(time.Hour), HashAlgorithm: "argon2id"},
+					CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					UpdatedAt: timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &namespacesv1.UpdateServiceExecRequest{
+				Name: "namespace",
+			},
+
+			callServiceWith: &services.UpdateNamespaceRequest{
+				Name: "namespace",
+			},
+
+			serviceErr: services.ErrInvalidUpdateNamespaceRequest,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "NotFound",
+
+			request: &namespacesv1.UpdateServiceExecRequest{
+				Name:   "namespace",
+				Policy: &namespacesv1.Policy{},
+			},
+
+			callServiceWith: &services.UpdateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{},
+			},
+
+			serviceErr: dao.ErrNamespaceNotFound,
+
+			expectCode: codes.NotFound,
+		},
+		{
+			name: "InternalError",
+
+			request: &namespacesv1.UpdateServiceExecRequest{
+				Name:   "namespace",
+				Policy: &namespacesv1.Policy{},
+			},
+
+			callServiceWith: &services.UpdateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{},
+			},
+
+			serviceErr: errors.New("uwups"),
+
+			expectCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := servicesmocks.NewMockUpdateNamespace(t)
+			logger := adaptersmocks.NewMockGRPC(t)
+
+			service.
+				On("Exec", context.Background(), testCase.callServiceWith).
+				Return(testCase.serviceResp, testCase.serviceErr)
+
+			logger.On("Report", handlers.UpdateNamespaceServiceName, mock.Anything)
+
+			handler := handlers.NewUpdateNamespace(service, logger)
+			resp, err := handler.Exec(context.Background(), testCase.request)
+
+			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
+			require.Equal(t, testCase.expect, resp)
+
+			service.AssertExpectations(t)
+			logger.AssertExpectations(t)
+		})
+	}
+}
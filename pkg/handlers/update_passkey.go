@@ -13,6 +13,8 @@ import (
 	"github.com/a-novel/golib/grpc"
 	"github.com/a-novel/golib/loggers/adapters"
 
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
 )
 
@@ -28,17 +30,37 @@ type updatePasskeyImpl struct {
 
 var handleUpdatePasskeyError = grpc.HandleError(codes.Internal).
 	Is(services.ErrInvalidUpdatePasskeyRequest, codes.InvalidArgument).
+	Is(services.ErrNamespaceNotRegistered, codes.FailedPrecondition).
+	Is(services.ErrPolicyViolation, codes.InvalidArgument).
+	Is(dao.ErrSecretReused, codes.InvalidArgument).
+	Test(handleInvalidPasskey).
+	Is(dao.ErrVersionMismatch, codes.Aborted).
+	Is(dao.ErrPasskeyNotFound, codes.NotFound).
+	Is(dao.ErrPasskeyRevoked, codes.FailedPrecondition).
 	Handle
 
 func (handler *updatePasskeyImpl) Exec(
 	ctx context.Context, request *passkeysv1.UpdateServiceExecRequest,
 ) (*passkeysv1.UpdateServiceExecResponse, error) {
+	currentPasskey, validate := ExtractCurrentPasskey(ctx)
+
+	expectedVersion, err := ExtractExpectedVersion(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "expected-version metadata: %v", err)
+	}
+
 	res, err := handler.service.Exec(ctx, &services.UpdatePasskeyRequest{
 		ID:        request.GetId(),
 		Namespace: request.GetNamespace(),
 		Passkey:   ExtractPasskey(ctx),
 		Reward:    grpc.StructOptionalProto(request.GetReward()),
 		ExpiresIn: grpc.DurationOptionalProto(request.GetExpiresIn()),
+
+		UpdateMask:     ExtractUpdateMask(ctx),
+		CurrentPasskey: currentPasskey,
+		Validate:       validate,
+
+		ExpectedVersion: expectedVersion,
 	})
 	if err != nil {
 		return nil, handleUpdatePasskeyError(err)
@@ -49,6 +71,8 @@ func (handler *updatePasskeyImpl) Exec(
 		return nil, status.Errorf(codes.Internal, "convert reward: %v", err)
 	}
 
+	SendVersion(ctx, res.Version)
+
 	return &passkeysv1.UpdateServiceExecResponse{
 		Id:        res.ID,
 		Namespace: res.Namespace,
@@ -61,5 +85,7 @@ func (handler *updatePasskeyImpl) Exec(
 
 func NewUpdatePasskey(service services.UpdatePasskey, logger adapters.GRPC) UpdatePasskey {
 	handler := &updatePasskeyImpl{service: service}
-	return grpc.ServiceWithMetrics(UpdatePasskeyServiceName, handler, logger)
+	return grpc.ServiceWithMetrics(
+		UpdatePasskeyServiceName, lib.ServiceWithTracing("handlers.UpdatePasskey", handler), logger,
+	)
 }
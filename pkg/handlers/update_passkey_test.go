@@ -3,14 +3,18 @@ package handlers_test
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	googlegrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -20,11 +24,23 @@ import (
 	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
 	"github.com/a-novel/golib/testutils"
 
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
 	"github.com/a-novel/uservice-passkeys/pkg/handlers"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
 	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
 )
 
+// headerStream records the headers set by a handler.
+type headerStream struct {
+	googlegrpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (stream *headerStream) SetHeader(md metadata.MD) error {
+	stream.header = metadata.Join(stream.header, md)
+	return nil
+}
+
 func TestUpdatePasskey(t *testing.T) {
 	reward, err := structpb.NewStruct(map[string]interface{}{"type": "reward"})
 	require.NoError(t, err)
@@ -39,8 +55,9 @@ func TestUpdatePasskey(t *testing.T) {
 		serviceResp     *services.UpdatePasskeyResponse
 		serviceErr      error
 
-		expect     *passkeysv1.UpdateServiceExecResponse
-		expectCode codes.Code
+		expect       *passkeysv1.UpdateServiceExecResponse
+		expectCode   codes.Code
+		expectReason string
 	}{
 		{
 			name: "OK",
@@ -67,6 +84,7 @@ func TestUpdatePasskey(t *testing.T) {
 				ExpiresAt: lo.ToPtr(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
 				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				Version:   2,
 			},
 
 			expect: &passkeysv1.UpdateServiceExecResponse{
@@ -104,6 +122,155 @@ func TestUpdatePasskey(t *testing.T) {
 				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "OK/UpdateMask",
+
+			metadata: map[string]string{
+				"update-mask": "reward, expires_in",
+			},
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+				Reward:    reward,
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace:  "namespace",
+				Reward:     map[string]interface{}{"type": "reward"},
+				UpdateMask: []string{"reward", "expires_in"},
+			},
+			serviceResp: &services.UpdatePasskeyResponse{
+				ID:        "id",
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"type": "reward"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &passkeysv1.UpdateServiceExecResponse{
+				Id:        "id",
+				Namespace: "namespace",
+				Reward:    reward,
+				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+				UpdatedAt: timestamppb.New(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "OK/Validate",
+
+			metadata: map[string]string{
+				"password":         "new-passkey",
+				"current-password": "passkey",
+				"update-mask":      "passkey",
+			},
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace:      "namespace",
+				Passkey:        "new-passkey",
+				UpdateMask:     []string{"passkey"},
+				CurrentPasskey: "passkey",
+				Validate:       true,
+			},
+			serviceResp: &services.UpdatePasskeyResponse{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &passkeysv1.UpdateServiceExecResponse{
+				Id:        "id",
+				Namespace: "namespace",
+				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "InvalidCurrentPasskey",
+
+			metadata: map[string]string{
+				"password":         "new-passkey",
+				"current-password": "passkey",
+			},
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace:      "namespace",
+				Passkey:        "new-passkey",
+				CurrentPasskey: "passkey",
+				Validate:       true,
+			},
+
+			serviceErr: dao.ErrInvalidPasskey,
+
+			expectCode:   codes.PermissionDenied,
+			expectReason: handlers.ReasonInvalidPasskey,
+		},
+		{
+			name: "OK/ExpectedVersion",
+
+			metadata: map[string]string{
+				"update-mask":      "reward",
+				"expected-version": "2",
+			},
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace:       "namespace",
+				UpdateMask:      []string{"reward"},
+				ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+			serviceResp: &services.UpdatePasskeyResponse{
+				ID:        "id",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				Version:   3,
+			},
+
+			expect: &passkeysv1.UpdateServiceExecResponse{
+				Id:        "id",
+				Namespace: "namespace",
+				CreatedAt: timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "VersionMismatch",
+
+			metadata: map[string]string{
+				"update-mask":      "reward",
+				"expected-version": "2",
+			},
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace:       "namespace",
+				UpdateMask:      []string{"reward"},
+				ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+
+			serviceErr: dao.ErrVersionMismatch,
+
+			expectCode: codes.Aborted,
+		},
+		{
+			name: "InvalidExpectedVersion",
+
+			metadata: map[string]string{
+				"password":         "passkey",
+				"expected-version": "latest",
+			},
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			expectCode: codes.InvalidArgument,
+		},
 		{
 			name: "InvalidRequest",
 
@@ -124,6 +291,66 @@ func TestUpdatePasskey(t *testing.T) {
 
 			expectCode: codes.InvalidArgument,
 		},
+		{
+			name: "SecretReused",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: dao.ErrSecretReused,
+
+			expectCode: codes.InvalidArgument,
+		},
+		{
+			name: "NotFound",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: dao.ErrPasskeyNotFound,
+
+			expectCode: codes.NotFound,
+		},
+		{
+			name: "Revoked",
+
+			metadata: map[string]string{
+				"password": "passkey",
+			},
+
+			request: &passkeysv1.UpdateServiceExecRequest{
+				Namespace: "namespace",
+			},
+
+			callServiceWith: &services.UpdatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			serviceErr: dao.ErrPasskeyRevoked,
+
+			expectCode: codes.FailedPrecondition,
+		},
 		{
 			name: "InternalError",
 
@@ -151,11 +378,17 @@ func TestUpdatePasskey(t *testing.T) {
 			service := servicesmocks.NewMockUpdatePasskey(t)
 			logger := adaptersmocks.NewMockGRPC(t)
 
-			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(testCase.metadata))
+			stream := &headerStream{}
+			ctx := googlegrpc.NewContextWithServerTransportStream(
+				metadata.NewIncomingContext(context.Background(), metadata.New(testCase.metadata)),
+				stream,
+			)
 
-			service.
-				On("Exec", ctx, testCase.callServiceWith).
-				Return(testCase.serviceResp, testCase.serviceErr)
+			if testCase.callServiceWith != nil {
+				service.
+					On("Exec", ctx, testCase.callServiceWith).
+					Return(testCase.serviceResp, testCase.serviceErr)
+			}
 
 			logger.On("Report", handlers.UpdatePasskeyServiceName, mock.Anything)
 
@@ -165,6 +398,24 @@ func TestUpdatePasskey(t *testing.T) {
 			testutils.RequireGRPCCodesEqual(t, err, testCase.expectCode)
 			require.Equal(t, testCase.expect, resp)
 
+			if testCase.expectReason != "" {
+				details := status.Convert(err).Details()
+				require.Len(t, details, 1)
+
+				errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+				require.True(t, ok)
+				require.Equal(t, testCase.expectReason, errorInfo.GetReason())
+				require.Equal(t, handlers.ErrorDomain, errorInfo.GetDomain())
+			}
+
+			if testCase.expect != nil {
+				require.Equal(
+					t,
+					[]string{strconv.FormatInt(testCase.serviceResp.Version, 10)},
+					stream.header.Get(handlers.VersionHeader),
+				)
+			}
+
 			service.AssertExpectations(t)
 			logger.AssertExpectations(t)
 		})
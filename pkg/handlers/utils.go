@@ -2,10 +2,17 @@ package handlers
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
+	googlegrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
+// VersionHeader carries the version of the passkey returned by a service, in the response headers.
+const VersionHeader = "version"
+
 func ExtractPasskey(ctx context.Context) string {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -19,3 +26,99 @@ func ExtractPasskey(ctx context.Context) string {
 
 	return passwordRaw[0]
 }
+
+// ExtractPasskeyID reads the ID requested for a new passkey from the "passkey-id" metadata.
+func ExtractPasskeyID(ctx context.Context) string {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	idRaw := incoming.Get("passkey-id")
+	if len(idRaw) == 0 {
+		return ""
+	}
+
+	return idRaw[0]
+}
+
+// ExtractUpdateMask reads the fields to update from the "update-mask" metadata, as a comma-separated list.
+func ExtractUpdateMask(ctx context.Context) []string {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var fields []string
+
+	for _, value := range incoming.Get("update-mask") {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	return fields
+}
+
+// ExtractCurrentPasskey reads the current secret of a passkey from the "current-password" metadata. The second value
+// reports whether the metadata was sent at all.
+func ExtractCurrentPasskey(ctx context.Context) (string, bool) {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	passwordRaw := incoming.Get("current-password")
+	if len(passwordRaw) == 0 {
+		return "", false
+	}
+
+	return passwordRaw[0], true
+}
+
+// ExtractExpectedVersion reads the version a passkey is expected to have from the "expected-version" metadata. It
+// returns nil if the metadata is missing.
+func ExtractExpectedVersion(ctx context.Context) (*int64, error) {
+	incoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	versionRaw := incoming.Get("expected-version")
+	if len(versionRaw) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	version, err := strconv.ParseInt(versionRaw[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse version: %w", err)
+	}
+
+	return &version, nil
+}
+
+type sentVersionContextKey struct{}
+
+// sentVersion holds the version sent with SendVersion, if any.
+type sentVersion struct {
+	version *int64
+}
+
+// withSentVersion records the version sent with SendVersion on the returned context, so it can be replayed later.
+func withSentVersion(ctx context.Context) (context.Context, *sentVersion) {
+	sent := new(sentVersion)
+
+	return context.WithValue(ctx, sentVersionContextKey{}, sent), sent
+}
+
+// SendVersion sets the version of a passkey in the response headers.
+func SendVersion(ctx context.Context, version int64) {
+	if sent, ok := ctx.Value(sentVersionContextKey{}).(*sentVersion); ok {
+		sent.version = &version
+	}
+
+	// This only fails when the context does not come from a gRPC server, so there is no one to send the header to.
+	_ = googlegrpc.SetHeader(ctx, metadata.Pairs(VersionHeader, strconv.FormatInt(version, 10)))
+}
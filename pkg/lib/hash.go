@@ -96,6 +96,16 @@ func ComparePasswordAndHash(password, encodedHash string) (bool, error) {
 	return false, nil
 }
 
+// ValidateHash ensures the encoded hash uses the format produced by GenerateFromPassword. It is useful when
+// importing hashes generated by another system.
+func ValidateHash(encodedHash string) error {
+	if _, _, _, err := decodeHash(encodedHash); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func decodeHash(encodedHash string) (*GenerateParams, []byte, []byte, error) {
 	values := strings.Split(encodedHash, "$")
 	if len(values) != 6 {
@@ -58,3 +58,12 @@ func TestPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateHash(t *testing.T) {
+	encrypted, err := lib.GenerateFromPassword("password", lib.DefaultGenerateParams)
+	require.NoError(t, err)
+
+	require.NoError(t, lib.ValidateHash(encrypted))
+	require.ErrorIs(t, lib.ValidateHash("malformed$"), lib.ErrInvalidHash)
+	require.ErrorIs(t, lib.ValidateHash("password"), lib.ErrInvalidHash)
+}
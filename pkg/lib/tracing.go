@@ -0,0 +1,87 @@
+package lib
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies the spans created by the service.
+const TracerName = "github.com/a-novel/uservice-passkeys"
+
+// Tracer returns the tracer of the service, from the global provider. Spans are discarded until a provider is
+// registered.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan starts a span under the current one. Spans of the service are always children of a request, so nothing is
+// started outside of a sampled trace: the context is returned as is, with the current non-recording span.
+func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return ctx, parent
+	}
+
+	return Tracer().Start(ctx, name) //nolint:spancheck
+}
+
+// EndSpan marks the span as failed when err is not nil, then ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// ExecService is the interface of the handlers and services that return a result.
+type ExecService[In any, Out any] interface {
+	Exec(ctx context.Context, data In) (Out, error)
+}
+
+// ExecCommand is the interface of the services that only return an error.
+type ExecCommand[In any] interface {
+	Exec(ctx context.Context, data In) error
+}
+
+type tracedService[In any, Out any] struct {
+	name    string
+	service ExecService[In, Out]
+}
+
+func (traced *tracedService[In, Out]) Exec(ctx context.Context, data In) (Out, error) {
+	ctx, span := StartSpan(ctx, traced.name)
+
+	res, err := traced.service.Exec(ctx, data)
+	EndSpan(span, err)
+
+	return res, err //nolint:wrapcheck
+}
+
+type tracedCommand[In any] struct {
+	name    string
+	command ExecCommand[In]
+}
+
+func (traced *tracedCommand[In]) Exec(ctx context.Context, data In) error {
+	ctx, span := StartSpan(ctx, traced.name)
+
+	err := traced.command.Exec(ctx, data)
+	EndSpan(span, err)
+
+	return err //nolint:wrapcheck
+}
+
+// ServiceWithTracing runs every call of the service in a span of the given name.
+func ServiceWithTracing[In any, Out any](name string, service ExecService[In, Out]) ExecService[In, Out] {
+	return &tracedService[In, Out]{name: name, service: service}
+}
+
+// CommandWithTracing runs every call of the command in a span of the given name.
+func CommandWithTracing[In any](name string, command ExecCommand[In]) ExecCommand[In] {
+	return &tracedCommand[In]{name: name, command: command}
+}
@@ -0,0 +1,76 @@
+package lib_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var errTraced = errors.New("traced error")
+
+type fakeService struct {
+	err error
+	ctx context.Context
+}
+
+func (service *fakeService) Exec(ctx context.Context, data string) (string, error) {
+	service.ctx = ctx
+
+	return data, service.err
+}
+
+func TestServiceWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	t.Run("OK", func(t *testing.T) {
+		ctx, parent := lib.Tracer().Start(context.Background(), "parent")
+		defer parent.End()
+
+		res, err := lib.ServiceWithTracing[string, string]("service", &fakeService{}).Exec(ctx, "data")
+		require.NoError(t, err)
+		require.Equal(t, "data", res)
+
+		span := recorder.Ended()[len(recorder.Ended())-1]
+		require.Equal(t, "service", span.Name())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ctx, parent := lib.Tracer().Start(context.Background(), "parent")
+		defer parent.End()
+
+		_, err := lib.ServiceWithTracing[string, string]("service", &fakeService{err: errTraced}).Exec(ctx, "data")
+		require.ErrorIs(t, err, errTraced)
+
+		span := recorder.Ended()[len(recorder.Ended())-1]
+		require.Equal(t, codes.Error, span.Status().Code)
+		require.Equal(t, errTraced.Error(), span.Status().Description)
+	})
+
+	t.Run("NoParent", func(t *testing.T) {
+		ended := len(recorder.Ended())
+		service := &fakeService{}
+
+		ctx := context.Background()
+
+		_, err := lib.ServiceWithTracing[string, string]("service", service).Exec(ctx, "data")
+		require.NoError(t, err)
+
+		// Spans are not started outside of a trace, and the context is passed as is.
+		require.Len(t, recorder.Ended(), ended)
+		require.Equal(t, ctx, service.ctx)
+	})
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prefix is prepended to the name of every metric of the service.
+const Prefix = "passkeys"
+
+// OtherNamespace is the label of the namespaces that are not in the allowlist.
+const OtherNamespace = "other"
+
+// Results of a passkey validation.
+const (
+	ValidationSuccess  = "success"
+	ValidationInvalid  = "invalid"
+	ValidationNotFound = "not_found"
+	ValidationRevoked  = "revoked"
+	ValidationError    = "error"
+)
+
+// Reasons of a lockout. Quota lockouts use the name of the quota.
+const (
+	LockoutRevoked = "revoked"
+)
+
+// Hash operations.
+const (
+	HashGenerate = "generate"
+	HashCompare  = "compare"
+)
+
+// Results of a purge run.
+const (
+	PurgeSuccess = "success"
+	PurgeFailure = "failure"
+	PurgeSkipped = "skipped"
+)
+
+// Kinds of rows deleted by the purge.
+const (
+	PurgedPasskeys        = "passkeys"
+	PurgedIdempotencyKeys = "idempotency_keys"
+)
+
+var (
+	Validations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Prefix,
+		Name:      "validations_total",
+		Help:      "Passkeys checked against a secret, by result.",
+	}, []string{"namespace", "result"})
+
+	Lockouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Prefix,
+		Name:      "lockouts_total",
+		Help:      "Requests refused regardless of the secret, because the passkey is revoked or a quota is exceeded.",
+	}, []string{"namespace", "reason"})
+
+	Redemptions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Prefix,
+		Name:      "redemptions_total",
+		Help:      "Single-use passkeys redeemed.",
+	}, []string{"namespace"})
+
+	HashDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Prefix,
+		Name:      "hash_duration_seconds",
+		Help:      "Time spent hashing secrets with Argon2id, or comparing them with a hash.",
+		// From 5ms to about 10s.
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"operation"})
+
+	PurgeRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Prefix,
+		Name:      "purge_runs_total",
+		Help:      "Runs of the purge worker, by result.",
+	}, []string{"result"})
+
+	PurgeDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Prefix,
+		Name:      "purge_deleted_total",
+		Help:      "Rows deleted by the purge worker.",
+	}, []string{"kind"})
+
+	PurgeDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Prefix,
+		Name:      "purge_duration_seconds",
+		Help:      "Duration of the runs of the purge worker.",
+		Buckets:   prometheus.DefBuckets,
+	})
+)
+
+// Collectors returns the metrics of the service, to be registered by the server.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		Validations, Lockouts, Redemptions, HashDuration, PurgeRuns, PurgeDeleted, PurgeDuration,
+	}
+}
+
+var (
+	namespacesMu sync.RWMutex
+	namespaces   []string
+)
+
+// SetNamespaces sets the namespaces reported in the namespace label. Every other namespace is reported as
+// OtherNamespace, so the number of series stays bounded.
+func SetNamespaces(allowed []string) {
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+
+	namespaces = slices.Clone(allowed)
+}
+
+// NamespaceLabel returns the value of the namespace label for a namespace.
+func NamespaceLabel(namespace string) string {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+
+	if slices.Contains(namespaces, namespace) {
+		return namespace
+	}
+
+	return OtherNamespace
+}
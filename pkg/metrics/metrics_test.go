@@ -0,0 +1,21 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+func TestNamespaceLabel(t *testing.T) {
+	t.Cleanup(func() { metrics.SetNamespaces(nil) })
+
+	require.Equal(t, metrics.OtherNamespace, metrics.NamespaceLabel("app"))
+
+	metrics.SetNamespaces([]string{"app", "admin"})
+
+	require.Equal(t, "app", metrics.NamespaceLabel("app"))
+	require.Equal(t, "admin", metrics.NamespaceLabel("admin"))
+	require.Equal(t, metrics.OtherNamespace, metrics.NamespaceLabel("unknown"))
+}
@@ -0,0 +1,234 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: listings/v1/list.proto
+
+package listingsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListServiceExecRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Restricts the results to a single namespace. Every namespace is listed when empty.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Limit     int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Resumes the listing after the given passkey, usually the last one of the previous page. Both fields must be set
+	// together.
+	AfterNamespace string `protobuf:"bytes,3,opt,name=after_namespace,json=afterNamespace,proto3" json:"after_namespace,omitempty"`
+	AfterId        string `protobuf:"bytes,4,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+}
+
+func (x *ListServiceExecRequest) Reset() {
+	*x = ListServiceExecRequest{}
+	mi := &file_listings_v1_list_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceExecRequest) ProtoMessage() {}
+
+func (x *ListServiceExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listings_v1_list_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceExecRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceExecRequest) Descriptor() ([]byte, []int) {
+	return file_listings_v1_list_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListServiceExecRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListServiceExecRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListServiceExecRequest) GetAfterNamespace() string {
+	if x != nil {
+		return x.AfterNamespace
+	}
+	return ""
+}
+
+func (x *ListServiceExecRequest) GetAfterId() string {
+	if x != nil {
+		return x.AfterId
+	}
+	return ""
+}
+
+type ListServiceExecResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Passkeys []*Passkey `protobuf:"bytes,1,rep,name=passkeys,proto3" json:"passkeys,omitempty"`
+}
+
+func (x *ListServiceExecResponse) Reset() {
+	*x = ListServiceExecResponse{}
+	mi := &file_listings_v1_list_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceExecResponse) ProtoMessage() {}
+
+func (x *ListServiceExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listings_v1_list_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceExecResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceExecResponse) Descriptor() ([]byte, []int) {
+	return file_listings_v1_list_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListServiceExecResponse) GetPasskeys() []*Passkey {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
+var File_listings_v1_list_proto protoreflect.FileDescriptor
+
+var file_listings_v1_list_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x69,
+	0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f,
+	0x76, 0x31, 0x2f, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x90, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x27, 0x0a, 0x0f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x66, 0x74, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30,
+	0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73,
+	0x32, 0x60, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x51, 0x0a, 0x04, 0x45, 0x78, 0x65, 0x63, 0x12, 0x23, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6c,
+	0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0xb0, 0x01, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x61, 0x2d, 0x6e, 0x6f, 0x76, 0x65, 0x6c, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2d, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x76, 0x31, 0x3b,
+	0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x4c, 0x58, 0x58,
+	0xaa, 0x02, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x56, 0x31, 0xca, 0x02,
+	0x0b, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x17, 0x4c,
+	0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_listings_v1_list_proto_rawDescOnce sync.Once
+	file_listings_v1_list_proto_rawDescData = file_listings_v1_list_proto_rawDesc
+)
+
+func file_listings_v1_list_proto_rawDescGZIP() []byte {
+	file_listings_v1_list_proto_rawDescOnce.Do(func() {
+		file_listings_v1_list_proto_rawDescData = protoimpl.X.CompressGZIP(file_listings_v1_list_proto_rawDescData)
+	})
+	return file_listings_v1_list_proto_rawDescData
+}
+
+var file_listings_v1_list_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_listings_v1_list_proto_goTypes = []any{
+	(*ListServiceExecRequest)(nil),  // 0: listings.v1.ListServiceExecRequest
+	(*ListServiceExecResponse)(nil), // 1: listings.v1.ListServiceExecResponse
+	(*Passkey)(nil),                 // 2: listings.v1.Passkey
+}
+var file_listings_v1_list_proto_depIdxs = []int32{
+	2, // 0: listings.v1.ListServiceExecResponse.passkeys:type_name -> listings.v1.Passkey
+	0, // 1: listings.v1.ListService.Exec:input_type -> listings.v1.ListServiceExecRequest
+	1, // 2: listings.v1.ListService.Exec:output_type -> listings.v1.ListServiceExecResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_listings_v1_list_proto_init() }
+func file_listings_v1_list_proto_init() {
+	if File_listings_v1_list_proto != nil {
+		return
+	}
+	file_listings_v1_passkey_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_listings_v1_list_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_listings_v1_list_proto_goTypes,
+		DependencyIndexes: file_listings_v1_list_proto_depIdxs,
+		MessageInfos:      file_listings_v1_list_proto_msgTypes,
+	}.Build()
+	File_listings_v1_list_proto = out.File
+	file_listings_v1_list_proto_rawDesc = nil
+	file_listings_v1_list_proto_goTypes = nil
+	file_listings_v1_list_proto_depIdxs = nil
+}
@@ -0,0 +1,119 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: namespaces/v1/create.proto
+
+package namespacesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CreateService_Exec_FullMethodName = "/namespaces.v1.CreateService/Exec"
+)
+
+// CreateServiceClient is the client API for CreateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CreateServiceClient interface {
+	Exec(ctx context.Context, in *CreateServiceExecRequest, opts ...grpc.CallOption) (*CreateServiceExecResponse, error)
+}
+
+type createServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCreateServiceClient(cc grpc.ClientConnInterface) CreateServiceClient {
+	return &createServiceClient{cc}
+}
+
+func (c *createServiceClient) Exec(ctx context.Context, in *CreateServiceExecRequest, opts ...grpc.CallOption) (*CreateServiceExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateServiceExecResponse)
+	err := c.cc.Invoke(ctx, CreateService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateServiceServer is the server API for CreateService service.
+// All implementations should embed UnimplementedCreateServiceServer
+// for forward compatibility.
+type CreateServiceServer interface {
+	Exec(context.Context, *CreateServiceExecRequest) (*CreateServiceExecResponse, error)
+}
+
+// UnimplementedCreateServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCreateServiceServer struct{}
+
+func (UnimplementedCreateServiceServer) Exec(context.Context, *CreateServiceExecRequest) (*CreateServiceExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedCreateServiceServer) testEmbeddedByValue() {}
+
+// UnsafeCreateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CreateServiceServer will
+// result in compilation errors.
+type UnsafeCreateServiceServer interface {
+	mustEmbedUnimplementedCreateServiceServer()
+}
+
+func RegisterCreateServiceServer(s grpc.ServiceRegistrar, srv CreateServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCreateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CreateService_ServiceDesc, srv)
+}
+
+func _CreateService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CreateServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CreateService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CreateServiceServer).Exec(ctx, req.(*CreateServiceExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CreateService_ServiceDesc is the grpc.ServiceDesc for CreateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CreateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "namespaces.v1.CreateService",
+	HandlerType: (*CreateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _CreateService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "namespaces/v1/create.proto",
+}
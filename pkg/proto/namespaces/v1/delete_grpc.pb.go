@@ -0,0 +1,119 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: namespaces/v1/delete.proto
+
+package namespacesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DeleteService_Exec_FullMethodName = "/namespaces.v1.DeleteService/Exec"
+)
+
+// DeleteServiceClient is the client API for DeleteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DeleteServiceClient interface {
+	Exec(ctx context.Context, in *DeleteServiceExecRequest, opts ...grpc.CallOption) (*DeleteServiceExecResponse, error)
+}
+
+type deleteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeleteServiceClient(cc grpc.ClientConnInterface) DeleteServiceClient {
+	return &deleteServiceClient{cc}
+}
+
+func (c *deleteServiceClient) Exec(ctx context.Context, in *DeleteServiceExecRequest, opts ...grpc.CallOption) (*DeleteServiceExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteServiceExecResponse)
+	err := c.cc.Invoke(ctx, DeleteService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteServiceServer is the server API for DeleteService service.
+// All implementations should embed UnimplementedDeleteServiceServer
+// for forward compatibility.
+type DeleteServiceServer interface {
+	Exec(context.Context, *DeleteServiceExecRequest) (*DeleteServiceExecResponse, error)
+}
+
+// UnimplementedDeleteServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDeleteServiceServer struct{}
+
+func (UnimplementedDeleteServiceServer) Exec(context.Context, *DeleteServiceExecRequest) (*DeleteServiceExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedDeleteServiceServer) testEmbeddedByValue() {}
+
+// UnsafeDeleteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeleteServiceServer will
+// result in compilation errors.
+type UnsafeDeleteServiceServer interface {
+	mustEmbedUnimplementedDeleteServiceServer()
+}
+
+func RegisterDeleteServiceServer(s grpc.ServiceRegistrar, srv DeleteServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDeleteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DeleteService_ServiceDesc, srv)
+}
+
+func _DeleteService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeleteServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeleteService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeleteServiceServer).Exec(ctx, req.(*DeleteServiceExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeleteService_ServiceDesc is the grpc.ServiceDesc for DeleteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeleteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "namespaces.v1.DeleteService",
+	HandlerType: (*DeleteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _DeleteService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "namespaces/v1/delete.proto",
+}
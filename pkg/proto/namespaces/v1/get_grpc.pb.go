@@ -0,0 +1,119 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: namespaces/v1/get.proto
+
+package namespacesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GetService_Exec_FullMethodName = "/namespaces.v1.GetService/Exec"
+)
+
+// GetServiceClient is the client API for GetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GetServiceClient interface {
+	Exec(ctx context.Context, in *GetServiceExecRequest, opts ...grpc.CallOption) (*GetServiceExecResponse, error)
+}
+
+type getServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGetServiceClient(cc grpc.ClientConnInterface) GetServiceClient {
+	return &getServiceClient{cc}
+}
+
+func (c *getServiceClient) Exec(ctx context.Context, in *GetServiceExecRequest, opts ...grpc.CallOption) (*GetServiceExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServiceExecResponse)
+	err := c.cc.Invoke(ctx, GetService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetServiceServer is the server API for GetService service.
+// All implementations should embed UnimplementedGetServiceServer
+// for forward compatibility.
+type GetServiceServer interface {
+	Exec(context.Context, *GetServiceExecRequest) (*GetServiceExecResponse, error)
+}
+
+// UnimplementedGetServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGetServiceServer struct{}
+
+func (UnimplementedGetServiceServer) Exec(context.Context, *GetServiceExecRequest) (*GetServiceExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedGetServiceServer) testEmbeddedByValue() {}
+
+// UnsafeGetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GetServiceServer will
+// result in compilation errors.
+type UnsafeGetServiceServer interface {
+	mustEmbedUnimplementedGetServiceServer()
+}
+
+func RegisterGetServiceServer(s grpc.ServiceRegistrar, srv GetServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GetService_ServiceDesc, srv)
+}
+
+func _GetService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GetServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GetService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GetServiceServer).Exec(ctx, req.(*GetServiceExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GetService_ServiceDesc is the grpc.ServiceDesc for GetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "namespaces.v1.GetService",
+	HandlerType: (*GetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _GetService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "namespaces/v1/get.proto",
+}
@@ -0,0 +1,119 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: namespaces/v1/list.proto
+
+package namespacesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ListService_Exec_FullMethodName = "/namespaces.v1.ListService/Exec"
+)
+
+// ListServiceClient is the client API for ListService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ListServiceClient interface {
+	Exec(ctx context.Context, in *ListServiceExecRequest, opts ...grpc.CallOption) (*ListServiceExecResponse, error)
+}
+
+type listServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewListServiceClient(cc grpc.ClientConnInterface) ListServiceClient {
+	return &listServiceClient{cc}
+}
+
+func (c *listServiceClient) Exec(ctx context.Context, in *ListServiceExecRequest, opts ...grpc.CallOption) (*ListServiceExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServiceExecResponse)
+	err := c.cc.Invoke(ctx, ListService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListServiceServer is the server API for ListService service.
+// All implementations should embed UnimplementedListServiceServer
+// for forward compatibility.
+type ListServiceServer interface {
+	Exec(context.Context, *ListServiceExecRequest) (*ListServiceExecResponse, error)
+}
+
+// UnimplementedListServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedListServiceServer struct{}
+
+func (UnimplementedListServiceServer) Exec(context.Context, *ListServiceExecRequest) (*ListServiceExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedListServiceServer) testEmbeddedByValue() {}
+
+// UnsafeListServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ListServiceServer will
+// result in compilation errors.
+type UnsafeListServiceServer interface {
+	mustEmbedUnimplementedListServiceServer()
+}
+
+func RegisterListServiceServer(s grpc.ServiceRegistrar, srv ListServiceServer) {
+	// If the following call pancis, it indicates UnimplementedListServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ListService_ServiceDesc, srv)
+}
+
+func _ListService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListServiceServer).Exec(ctx, req.(*ListServiceExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ListService_ServiceDesc is the grpc.ServiceDesc for ListService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ListService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "namespaces.v1.ListService",
+	HandlerType: (*ListServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _ListService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "namespaces/v1/list.proto",
+}
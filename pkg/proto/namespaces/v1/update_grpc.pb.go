@@ -0,0 +1,119 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: namespaces/v1/update.proto
+
+package namespacesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UpdateService_Exec_FullMethodName = "/namespaces.v1.UpdateService/Exec"
+)
+
+// UpdateServiceClient is the client API for UpdateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UpdateServiceClient interface {
+	Exec(ctx context.Context, in *UpdateServiceExecRequest, opts ...grpc.CallOption) (*UpdateServiceExecResponse, error)
+}
+
+type updateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUpdateServiceClient(cc grpc.ClientConnInterface) UpdateServiceClient {
+	return &updateServiceClient{cc}
+}
+
+func (c *updateServiceClient) Exec(ctx context.Context, in *UpdateServiceExecRequest, opts ...grpc.CallOption) (*UpdateServiceExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateServiceExecResponse)
+	err := c.cc.Invoke(ctx, UpdateService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateServiceServer is the server API for UpdateService service.
+// All implementations should embed UnimplementedUpdateServiceServer
+// for forward compatibility.
+type UpdateServiceServer interface {
+	Exec(context.Context, *UpdateServiceExecRequest) (*UpdateServiceExecResponse, error)
+}
+
+// UnimplementedUpdateServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUpdateServiceServer struct{}
+
+func (UnimplementedUpdateServiceServer) Exec(context.Context, *UpdateServiceExecRequest) (*UpdateServiceExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedUpdateServiceServer) testEmbeddedByValue() {}
+
+// UnsafeUpdateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UpdateServiceServer will
+// result in compilation errors.
+type UnsafeUpdateServiceServer interface {
+	mustEmbedUnimplementedUpdateServiceServer()
+}
+
+func RegisterUpdateServiceServer(s grpc.ServiceRegistrar, srv UpdateServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUpdateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UpdateService_ServiceDesc, srv)
+}
+
+func _UpdateService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpdateServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UpdateService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpdateServiceServer).Exec(ctx, req.(*UpdateServiceExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UpdateService_ServiceDesc is the grpc.ServiceDesc for UpdateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UpdateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "namespaces.v1.UpdateService",
+	HandlerType: (*UpdateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _UpdateService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "namespaces/v1/update.proto",
+}
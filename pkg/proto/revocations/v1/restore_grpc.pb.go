@@ -0,0 +1,123 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: revocations/v1/restore.proto
+
+package revocationsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RestoreService_Exec_FullMethodName = "/revocations.v1.RestoreService/Exec"
+)
+
+// RestoreServiceClient is the client API for RestoreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Reactivates a revoked passkey, if it was revoked within the retention window of the service.
+type RestoreServiceClient interface {
+	Exec(ctx context.Context, in *RestoreServiceExecRequest, opts ...grpc.CallOption) (*RestoreServiceExecResponse, error)
+}
+
+type restoreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRestoreServiceClient(cc grpc.ClientConnInterface) RestoreServiceClient {
+	return &restoreServiceClient{cc}
+}
+
+func (c *restoreServiceClient) Exec(ctx context.Context, in *RestoreServiceExecRequest, opts ...grpc.CallOption) (*RestoreServiceExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreServiceExecResponse)
+	err := c.cc.Invoke(ctx, RestoreService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RestoreServiceServer is the server API for RestoreService service.
+// All implementations should embed UnimplementedRestoreServiceServer
+// for forward compatibility.
+//
+// Reactivates a revoked passkey, if it was revoked within the retention window of the service.
+type RestoreServiceServer interface {
+	Exec(context.Context, *RestoreServiceExecRequest) (*RestoreServiceExecResponse, error)
+}
+
+// UnimplementedRestoreServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRestoreServiceServer struct{}
+
+func (UnimplementedRestoreServiceServer) Exec(context.Context, *RestoreServiceExecRequest) (*RestoreServiceExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedRestoreServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRestoreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RestoreServiceServer will
+// result in compilation errors.
+type UnsafeRestoreServiceServer interface {
+	mustEmbedUnimplementedRestoreServiceServer()
+}
+
+func RegisterRestoreServiceServer(s grpc.ServiceRegistrar, srv RestoreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRestoreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RestoreService_ServiceDesc, srv)
+}
+
+func _RestoreService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreServiceExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RestoreServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RestoreService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RestoreServiceServer).Exec(ctx, req.(*RestoreServiceExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RestoreService_ServiceDesc is the grpc.ServiceDesc for RestoreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RestoreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "revocations.v1.RestoreService",
+	HandlerType: (*RestoreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _RestoreService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "revocations/v1/restore.proto",
+}
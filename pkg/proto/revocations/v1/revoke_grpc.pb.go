@@ -0,0 +1,123 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: revocations/v1/revoke.proto
+
+package revocationsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RevokeService_Exec_FullMethodName = "/revocations.v1.RevokeService/Exec"
+)
+
+// RevokeServiceClient is the client API for RevokeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Revoked passkeys stop working, but are kept for history.
+type RevokeServiceClient interface {
+	Exec(ctx context.Context, in *RevokeServiceExecRequest, opts ...grpc.CallOption) (*RevokeServiceExecResponse, error)
+}
+
+type revokeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRevokeServiceClient(cc grpc.ClientConnInterface) RevokeServiceClient {
+	return &revokeServiceClient{cc}
+}
+
+func (c *revokeServiceClient) Exec(ctx context.Context, in *RevokeServiceExecRequest, opts ...grpc.CallOption) (*RevokeServiceExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeServiceExecResponse)
+	err := c.cc.Invoke(ctx, RevokeService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevokeServiceServer is the server API for RevokeService service.
+// All implementations should embed UnimplementedRevokeServiceServer
+// for forward compatibility.
+//
+// Revoked passkeys stop working, but are kept for history.
+type RevokeServiceServer interface {
+	Exec(context.Context, *RevokeServiceExecRequest) (*RevokeServiceExecResponse, error)
+}
+
+// UnimplementedRevokeServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRevokeServiceServer struct{}
+
+func (UnimplementedRevokeServiceServer) Exec(context.Context, *RevokeServiceExecRequest) (*RevokeServiceExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedRevokeServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRevokeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RevokeServiceServer will
+// result in compilation errors.
+type UnsafeRevokeServiceServer interface {
+	mustEmbedUnimplementedRevokeServiceServer()
+}
+
+func RegisterRevokeServiceServer(s grpc.ServiceRegistrar, srv RevokeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRevokeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RevokeService_ServiceDesc, srv)
+}
+
+func _RevokeService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeServiceExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RevokeServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RevokeService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RevokeServiceServer).Exec(ctx, req.(*RevokeServiceExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RevokeService_ServiceDesc is the grpc.ServiceDesc for RevokeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RevokeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "revocations.v1.RevokeService",
+	HandlerType: (*RevokeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _RevokeService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "revocations/v1/revoke.proto",
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+// DefaultIdempotencyTTL is used when no retention is configured for idempotency keys.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+var (
+	ErrInvalidClaimIdempotencyKeyRequest = errors.New("invalid claim idempotency key request")
+	ErrClaimIdempotencyKey               = errors.New("claim idempotency key")
+)
+
+var claimIdempotencyKeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ClaimIdempotencyKeyRequest struct {
+	Key         string `validate:"required,min=1,max=256"`
+	Service     string `validate:"required,min=1,max=256"`
+	Fingerprint string `validate:"required"`
+}
+
+type ClaimIdempotencyKeyResponse struct {
+	// Response of the original request, to replay. It is empty when the key was claimed by this request, which
+	// must then run.
+	Response []byte
+	// Version is the version header sent with the original response, if any.
+	Version *int64
+}
+
+type ClaimIdempotencyKey interface {
+	Exec(ctx context.Context, data *ClaimIdempotencyKeyRequest) (*ClaimIdempotencyKeyResponse, error)
+}
+
+type claimIdempotencyKeyImpl struct {
+	dao dao.ClaimIdempotencyKey
+	ttl time.Duration
+}
+
+func (service *claimIdempotencyKeyImpl) Exec(
+	ctx context.Context, data *ClaimIdempotencyKeyRequest,
+) (*ClaimIdempotencyKeyResponse, error) {
+	if err := claimIdempotencyKeyValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidClaimIdempotencyKeyRequest, err)
+	}
+
+	request := &dao.ClaimIdempotencyKeyRequest{
+		Key:         data.Key,
+		Service:     data.Service,
+		Fingerprint: data.Fingerprint,
+		TTL:         service.ttl,
+	}
+
+	res, err := service.dao.Exec(ctx, time.Now(), request)
+	if err != nil {
+		return nil, errors.Join(ErrClaimIdempotencyKey, err)
+	}
+
+	return &ClaimIdempotencyKeyResponse{Response: res.Response, Version: res.Version}, nil
+}
+
+// NewClaimIdempotencyKey creates a new claim service. The results of the requests are kept for ttl.
+func NewClaimIdempotencyKey(dao dao.ClaimIdempotencyKey, ttl time.Duration) ClaimIdempotencyKey {
+	return lib.ServiceWithTracing("services.ClaimIdempotencyKey", &claimIdempotencyKeyImpl{dao: dao, ttl: ttl})
+}
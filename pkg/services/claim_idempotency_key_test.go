@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestClaimIdempotencyKey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ClaimIdempotencyKeyRequest
+
+		shouldCallDAO bool
+		daoResp       *entities.IdempotencyKey
+		daoErr        error
+
+		expect    *services.ClaimIdempotencyKeyResponse
+		expectErr error
+	}{
+		{
+			name: "OK/Claimed",
+
+			request: &services.ClaimIdempotencyKeyRequest{
+				Key:         "key",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+			},
+
+			shouldCallDAO: true,
+			daoResp: &entities.IdempotencyKey{
+				Key:         "key",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+			},
+
+			expect: &services.ClaimIdempotencyKeyResponse{},
+		},
+		{
+			name: "OK/Replay",
+
+			request: &services.ClaimIdempotencyKeyRequest{
+				Key:         "key",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+			},
+
+			shouldCallDAO: true,
+			daoResp: &entities.IdempotencyKey{
+				Key:         "key",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+				Response:    []byte("response"),
+				Version:     lo.ToPtr(int64(2)),
+			},
+
+			expect: &services.ClaimIdempotencyKeyResponse{
+				Response: []byte("response"),
+				Version:  lo.ToPtr(int64(2)),
+			},
+		},
+		{
+			name: "Error/NoKey",
+
+			request: &services.ClaimIdempotencyKeyRequest{
+				Service:     "service",
+				Fingerprint: "fingerprint",
+			},
+
+			expectErr: services.ErrInvalidClaimIdempotencyKeyRequest,
+		},
+		{
+			name: "Error/KeyTooLong",
+
+			request: &services.ClaimIdempotencyKeyRequest{
+				Key:         string(make([]byte, 257)),
+				Service:     "service",
+				Fingerprint: "fingerprint",
+			},
+
+			expectErr: services.ErrInvalidClaimIdempotencyKeyRequest,
+		},
+		{
+			name: "DAO/Reused",
+
+			request: &services.ClaimIdempotencyKeyRequest{
+				Key:         "key",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+			},
+
+			shouldCallDAO: true,
+			daoErr:        dao.ErrIdempotencyKeyReused,
+
+			expectErr: dao.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ClaimIdempotencyKeyRequest{
+				Key:         "key",
+				Service:     "service",
+				Fingerprint: "fingerprint",
+			},
+
+			shouldCallDAO: true,
+			daoErr:        errors.New("uwups"),
+
+			expectErr: services.ErrClaimIdempotencyKey,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			claimIdempotencyKeyDAO := daomocks.NewMockClaimIdempotencyKey(t)
+
+			if testCase.shouldCallDAO {
+				claimIdempotencyKeyDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.ClaimIdempotencyKeyRequest{
+							Key:         testCase.request.Key,
+							Service:     testCase.request.Service,
+							Fingerprint: testCase.request.Fingerprint,
+							TTL:         time.Hour,
+						},
+					).
+					Return(testCase.daoResp, testCase.daoErr)
+			}
+
+			service := services.NewClaimIdempotencyKey(claimIdempotencyKeyDAO, time.Hour)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			claimIdempotencyKeyDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidCompleteIdempotencyKeyRequest = errors.New("invalid complete idempotency key request")
+	ErrCompleteIdempotencyKey               = errors.New("complete idempotency key")
+)
+
+var completeIdempotencyKeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type CompleteIdempotencyKeyRequest struct {
+	Key      string `validate:"required,min=1,max=256"`
+	Service  string `validate:"required,min=1,max=256"`
+	Response []byte `validate:"required"`
+	// Version is the version header sent with the response, if any.
+	Version *int64
+}
+
+type CompleteIdempotencyKey interface {
+	Exec(ctx context.Context, data *CompleteIdempotencyKeyRequest) error
+}
+
+type completeIdempotencyKeyImpl struct {
+	dao dao.CompleteIdempotencyKey
+}
+
+func (service *completeIdempotencyKeyImpl) Exec(ctx context.Context, data *CompleteIdempotencyKeyRequest) error {
+	if err := completeIdempotencyKeyValidate.Struct(data); err != nil {
+		return errors.Join(ErrInvalidCompleteIdempotencyKeyRequest, err)
+	}
+
+	request := &dao.CompleteIdempotencyKeyRequest{
+		Key:      data.Key,
+		Service:  data.Service,
+		Response: data.Response,
+		Version:  data.Version,
+	}
+
+	if err := service.dao.Exec(ctx, request); err != nil {
+		return errors.Join(ErrCompleteIdempotencyKey, err)
+	}
+
+	return nil
+}
+
+func NewCompleteIdempotencyKey(dao dao.CompleteIdempotencyKey) CompleteIdempotencyKey {
+	return lib.CommandWithTracing("services.CompleteIdempotencyKey", &completeIdempotencyKeyImpl{dao: dao})
+}
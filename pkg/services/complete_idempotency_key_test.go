@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestCompleteIdempotencyKey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.CompleteIdempotencyKeyRequest
+
+		shouldCallDAO bool
+		daoErr        error
+
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.CompleteIdempotencyKeyRequest{
+				Key:      "key",
+				Service:  "service",
+				Response: []byte("response"),
+				Version:  lo.ToPtr(int64(2)),
+			},
+
+			shouldCallDAO: true,
+		},
+		{
+			name: "Error/NoResponse",
+
+			request: &services.CompleteIdempotencyKeyRequest{
+				Key:     "key",
+				Service: "service",
+			},
+
+			expectErr: services.ErrInvalidCompleteIdempotencyKeyRequest,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.CompleteIdempotencyKeyRequest{
+				Key:      "key",
+				Service:  "service",
+				Response: []byte("response"),
+			},
+
+			shouldCallDAO: true,
+			daoErr:        errors.New("uwups"),
+
+			expectErr: services.ErrCompleteIdempotencyKey,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			completeIdempotencyKeyDAO := daomocks.NewMockCompleteIdempotencyKey(t)
+
+			if testCase.shouldCallDAO {
+				completeIdempotencyKeyDAO.
+					On("Exec", context.Background(), &dao.CompleteIdempotencyKeyRequest{
+						Key:      testCase.request.Key,
+						Service:  testCase.request.Service,
+						Response: testCase.request.Response,
+						Version:  testCase.request.Version,
+					}).
+					Return(testCase.daoErr)
+			}
+
+			service := services.NewCompleteIdempotencyKey(completeIdempotencyKeyDAO)
+			err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			completeIdempotencyKeyDAO.AssertExpectations(t)
+		})
+	}
+}
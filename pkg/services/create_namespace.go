@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidCreateNamespaceRequest = errors.New("invalid create namespace request")
+	ErrCreateNamespace               = errors.New("create namespace")
+)
+
+var createNamespaceValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type CreateNamespaceRequest struct {
+	Name   string           `validate:"required,min=1,max=256"`
+	Policy *NamespacePolicy `validate:"required"`
+}
+
+type CreateNamespace interface {
+	Exec(ctx context.Context, data *CreateNamespaceRequest) (*Namespace, error)
+}
+
+type createNamespaceImpl struct {
+	dao dao.CreateNamespace
+}
+
+func (service *createNamespaceImpl) Exec(ctx context.Context, data *CreateNamespaceRequest) (*Namespace, error) {
+	if err := createNamespaceValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidCreateNamespaceRequest, err)
+	}
+
+	if err := checkNamespacePolicy(data.Policy); err != nil {
+		return nil, errors.Join(ErrInvalidCreateNamespaceRequest, err)
+	}
+
+	res, err := service.dao.Exec(ctx, time.Now(), &dao.CreateNamespaceRequest{
+		Name:   data.Name,
+		Policy: namespacePolicyToEntity(data.Policy),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrCreateNamespace, err)
+	}
+
+	return namespaceFromEntity(res), nil
+}
+
+func NewCreateNamespace(dao dao.CreateNamespace) CreateNamespace {
+	return lib.ServiceWithTracing("services.CreateNamespace", &createNamespaceImpl{dao: dao})
+}
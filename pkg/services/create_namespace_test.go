@@ -0,0 +1,269 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestCreateNamespace(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.CreateNamespaceRequest
+
+		shouldCallDAO bool
+		daoRequest    *dao.CreateNamespaceRequest
+		daoResp       *entities.Namespace
+		daoErr        error
+
+		expect    *services.Namespace
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.CreateNamespaceRequest{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					DefaultTTL: lo.ToPtr(time.Hour),
+					MaxTTL:     lo.ToPtr(24 * time.Hour),
+					HashParams: &services.NamespaceHashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:      &services.NamespaceStrengthRules{MinLength: 8, RequireDigit: true},
+					SingleUseDefault:   true,
+					MaxActivePasskeys:  lo.ToPtr(10),
+					CreationRateLimit:  lo.ToPtr(5),
+					CreationRateWindow: lo.ToPtr(time.Minute),
+					SecretHistorySize:  5,
+				},
+			},
+
+			shouldCallDAO: true,
+			daoRequest: &dao.CreateNamespaceRequest{
+				Name: "namespace",
+				Policy: entities.NamespacePolicy{
+					DefaultTTL:    lo.ToPtr(time.Hour),
+					MaxTTL:        lo.ToPtr(24 * time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					HashParams: &entities.HashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:      &entities.StrengthRules{MinLength: 8, RequireDigit: true},
+					SingleUseDefault:   true,
+					MaxActivePasskeys:  lo.ToPtr(10),
+					CreationRateLimit:  lo.ToPtr(5),
+					CreationRateWindow: lo.ToPtr(time.Minute),
+					SecretHistorySize:  5,
+				},
+			},
+			daoResp: &entities.Namespace{
+				Name: "namespace",
+				NamespacePolicy: entities.NamespacePolicy{
+					DefaultTTL:    lo.ToPtr(time.Hour),
+					MaxTTL:        lo.ToPtr(24 * time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					HashParams: &entities.HashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:      &entities.StrengthRules{MinLength: 8, RequireDigit: true},
+					SingleUseDefault:   true,
+					MaxActivePasskeys:  lo.ToPtr(10),
+					CreationRateLimit:  lo.ToPtr(5),
+					CreationRateWindow: lo.ToPtr(time.Minute),
+					SecretHistorySize:  5,
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.Namespace{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					DefaultTTL:    lo.ToPtr(time.Hour),
+					MaxTTL:        lo.ToPtr(24 * time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					HashParams: &services.NamespaceHashParams{
+						SaltLength:  16,
+						Iterations:  2,
+						Memory:      32 * 1024,
+						Parallelism: 2,
+						KeyLength:   32,
+					},
+					StrengthRules:      &services.NamespaceStrengthRules{MinLength: 8, RequireDigit: true},
+					SingleUseDefault:   true,
+					MaxActivePasskeys:  lo.ToPtr(10),
+					CreationRateLimit:  lo.ToPtr(5),
+					CreationRateWindow: lo.ToPtr(time.Minute),
+					SecretHistorySize:  5,
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/Minimal",
+
+			request: &services.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{},
+			},
+
+			shouldCallDAO: true,
+			daoRequest: &dao.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			},
+			daoResp: &entities.Namespace{
+				Name:            "namespace",
+				NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.Namespace{
+				Name:      "namespace",
+				Policy:    &services.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{},
+			},
+
+			shouldCallDAO: true,
+			daoRequest: &dao.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			},
+			daoErr: errors.New("uwups"),
+
+			expectErr: services.ErrCreateNamespace,
+		},
+		{
+			name: "InvalidRequest/NoPolicy",
+
+			request: &services.CreateNamespaceRequest{
+				Name: "namespace",
+			},
+
+			expectErr: services.ErrInvalidCreateNamespaceRequest,
+		},
+		{
+			name: "InvalidRequest/HashAlgorithm",
+
+			request: &services.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{HashAlgorithm: "md5"},
+			},
+
+			expectErr: services.ErrInvalidCreateNamespaceRequest,
+		},
+		{
+			name: "InvalidRequest/HashParams",
+
+			request: &services.CreateNamespaceRequest{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					HashParams: &services.NamespaceHashParams{SaltLength: 16, Iterations: 1, Memory: 1, Parallelism: 1},
+				},
+			},
+
+			expectErr: services.ErrInvalidCreateNamespaceRequest,
+		},
+		{
+			name: "InvalidRequest/CreationRateWithoutWindow",
+
+			request: &services.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{CreationRateLimit: lo.ToPtr(5)},
+			},
+
+			expectErr: services.ErrInvalidCreateNamespaceRequest,
+		},
+		{
+			name: "InvalidRequest/SecretHistorySize",
+
+			request: &services.CreateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{SecretHistorySize: 100},
+			},
+
+			expectErr: services.ErrInvalidCreateNamespaceRequest,
+		},
+		{
+			name: "InvalidRequest/DefaultTTLExceedsMaxTTL",
+
+			request: &services.CreateNamespaceRequest{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					DefaultTTL: lo.ToPtr(2 * time.Hour),
+					MaxTTL:     lo.ToPtr(time.Hour),
+				},
+			},
+
+			expectErr: services.ErrInvalidCreateNamespaceRequest,
+		},
+		{
+			name: "InvalidRequest/MinLengthExceedsMaxLength",
+
+			request: &services.CreateNamespaceRequest{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					StrengthRules: &services.NamespaceStrengthRules{MinLength: 16, MaxLength: 8},
+				},
+			},
+
+			expectErr: services.ErrInvalidCreateNamespaceRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			createNamespaceDAO := daomocks.NewMockCreateNamespace(t)
+
+			if testCase.shouldCallDAO {
+				createNamespaceDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.daoRequest,
+					).
+					Return(testCase.daoResp, testCase.daoErr)
+			}
+
+			service := services.NewCreateNamespace(createNamespaceDAO)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			createNamespaceDAO.AssertExpectations(t)
+		})
+	}
+}
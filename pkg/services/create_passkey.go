@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 
 	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 )
 
 var (
@@ -19,6 +21,8 @@ var (
 var createPasskeyValidate = validator.New(validator.WithRequiredStructEnabled())
 
 type CreatePasskeyRequest struct {
+	// ID of the new passkey. A random one is generated when empty.
+	ID        string                 `validate:"omitempty,len=36"`
 	Namespace string                 `validate:"required,min=1,max=256"`
 	Passkey   string                 `validate:"required,min=4,max=4096"`
 	Reward    map[string]interface{} `validate:"omitempty"`
@@ -31,6 +35,7 @@ type CreatePasskeyResponse struct {
 	Reward    map[string]interface{}
 	ExpiresAt *time.Time
 	CreatedAt time.Time
+	Version   int64
 }
 
 type CreatePasskey interface {
@@ -38,7 +43,8 @@ type CreatePasskey interface {
 }
 
 type createPasskeyImpl struct {
-	dao dao.CreatePasskey
+	dao      dao.CreatePasskey
+	policies ResolveNamespacePolicy
 }
 
 func (service *createPasskeyImpl) Exec(
@@ -48,15 +54,45 @@ func (service *createPasskeyImpl) Exec(
 		return nil, errors.Join(ErrInvalidCreatePasskeyRequest, err)
 	}
 
+	passkeyID := uuid.New()
+	if data.ID != "" {
+		var err error
+		if passkeyID, err = uuid.Parse(data.ID); err != nil {
+			return nil, errors.Join(ErrInvalidCreatePasskeyRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+		}
+	}
+
+	policy, err := service.policies.Exec(ctx, data.Namespace)
+	if err != nil {
+		return nil, errors.Join(ErrCreatePasskey, err)
+	}
+
+	if err := CheckPasskeyStrength(policy, data.Passkey); err != nil {
+		return nil, errors.Join(ErrCreatePasskey, err)
+	}
+
+	expiresIn, err := ApplyExpiryPolicy(policy, data.ExpiresIn)
+	if err != nil {
+		return nil, errors.Join(ErrCreatePasskey, err)
+	}
+
 	request := &dao.CreatePasskeyRequest{
-		Namespace: data.Namespace,
-		Passkey:   data.Passkey,
-		Reward:    data.Reward,
-		ExpiresAt: ExpiresInToTime(data.ExpiresIn),
+		Namespace:  data.Namespace,
+		Passkey:    data.Passkey,
+		Reward:     data.Reward,
+		ExpiresAt:  ExpiresInToTime(expiresIn),
+		SingleUse:  policy.SingleUseDefault,
+		HashParams: HashParamsFromPolicy(policy),
+
+		MaxActivePasskeys:  policy.MaxActivePasskeys,
+		CreationRateLimit:  policy.CreationRateLimit,
+		CreationRateWindow: policy.CreationRateWindow,
 	}
 
-	res, err := service.dao.Exec(ctx, uuid.New(), time.Now(), request)
+	res, err := service.dao.Exec(ctx, passkeyID, time.Now(), request)
 	if err != nil {
+		recordQuotaLockout(err)
+
 		return nil, errors.Join(ErrCreatePasskey, err)
 	}
 
@@ -66,9 +102,10 @@ func (service *createPasskeyImpl) Exec(
 		Reward:    res.Reward,
 		ExpiresAt: res.ExpiresAt,
 		CreatedAt: res.CreatedAt,
+		Version:   res.Version,
 	}, nil
 }
 
-func NewCreatePasskey(dao dao.CreatePasskey) CreatePasskey {
-	return &createPasskeyImpl{dao: dao}
+func NewCreatePasskey(dao dao.CreatePasskey, policies ResolveNamespacePolicy) CreatePasskey {
+	return lib.ServiceWithTracing("services.CreatePasskey", &createPasskeyImpl{dao: dao, policies: policies})
 }
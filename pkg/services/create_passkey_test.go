@@ -16,6 +16,7 @@ import (
 	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
 	"github.com/a-novel/uservice-passkeys/pkg/entities"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
 )
 
 func TestCreatePasskey(t *testing.T) {
@@ -24,6 +25,10 @@ func TestCreatePasskey(t *testing.T) {
 
 		request *services.CreatePasskeyRequest
 
+		shouldResolvePolicy bool
+		policy              *entities.NamespacePolicy
+		policyErr           error
+
 		shouldCallCreatePasskeyDAO bool
 		passkeyDAOResp             *entities.Passkey
 		passkeyDAOErr              error
@@ -41,6 +46,9 @@ func TestCreatePasskey(t *testing.T) {
 				ExpiresIn: lo.ToPtr(time.Hour * 24),
 			},
 
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
 			shouldCallCreatePasskeyDAO: true,
 			passkeyDAOResp: &entities.Passkey{
 				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
@@ -66,6 +74,9 @@ func TestCreatePasskey(t *testing.T) {
 				Passkey:   "passkey",
 			},
 
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
 			shouldCallCreatePasskeyDAO: true,
 			passkeyDAOResp: &entities.Passkey{
 				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
@@ -79,6 +90,59 @@ func TestCreatePasskey(t *testing.T) {
 				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
 			},
 		},
+		{
+			name: "OK/ID",
+
+			request: &services.CreatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000003",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallCreatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000003",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.CreatePasskeyRequest{
+				ID:        "00000000x0000x0000x0000x000000000003",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			expectErr: services.ErrInvalidCreatePasskeyRequest,
+		},
+		{
+			name: "DAO/AlreadyExists",
+
+			request: &services.CreatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000003",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallCreatePasskeyDAO: true,
+			passkeyDAOErr:              dao.ErrPasskeyAlreadyExists,
+
+			expectErr: dao.ErrPasskeyAlreadyExists,
+		},
 		{
 			name: "DAO/Error",
 
@@ -89,12 +153,109 @@ func TestCreatePasskey(t *testing.T) {
 				ExpiresIn: lo.ToPtr(time.Hour * 24),
 			},
 
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
 			shouldCallCreatePasskeyDAO: true,
 
 			passkeyDAOErr: errors.New("uwups"),
 
 			expectErr: services.ErrCreatePasskey,
 		},
+		{
+			name: "Policy/DefaultTTL",
+
+			request: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				DefaultTTL:       lo.ToPtr(time.Hour),
+				MaxTTL:           lo.ToPtr(time.Hour * 24),
+				SingleUseDefault: true,
+			},
+
+			shouldCallCreatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 1, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 1, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Policy/Quotas",
+
+			request: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				MaxActivePasskeys:  lo.ToPtr(10),
+				CreationRateLimit:  lo.ToPtr(5),
+				CreationRateWindow: lo.ToPtr(time.Minute),
+			},
+
+			shouldCallCreatePasskeyDAO: true,
+			passkeyDAOErr:              &dao.QuotaExceededError{Namespace: "namespace", Quota: dao.QuotaCreationRate},
+
+			expectErr: dao.ErrQuotaExceeded,
+		},
+		{
+			name: "Policy/MaxTTL",
+
+			request: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+				ExpiresIn: lo.ToPtr(time.Hour * 48),
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				MaxTTL: lo.ToPtr(time.Hour * 24),
+			},
+
+			expectErr: services.ErrPolicyViolation,
+		},
+		{
+			name: "Policy/WeakPasskey",
+
+			request: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				StrengthRules: &entities.StrengthRules{MinLength: 4, RequireDigit: true},
+			},
+
+			expectErr: services.ErrPolicyViolation,
+		},
+		{
+			name: "Policy/NotRegistered",
+
+			request: &services.CreatePasskeyRequest{
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policyErr:           services.ErrNamespaceNotRegistered,
+
+			expectErr: services.ErrNamespaceNotRegistered,
+		},
 		{
 			name: "InvalidRequest",
 
@@ -109,39 +270,59 @@ func TestCreatePasskey(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			createPasskeyDAO := daomocks.NewMockCreatePasskey(t)
+			resolveNamespacePolicy := servicesmocks.NewMockResolveNamespacePolicy(t)
+
+			if testCase.shouldResolvePolicy {
+				resolveNamespacePolicy.
+					On("Exec", context.Background(), testCase.request.Namespace).
+					Return(testCase.policy, testCase.policyErr)
+			}
 
 			if testCase.shouldCallCreatePasskeyDAO {
 				createPasskeyDAO.
 					On(
 						"Exec",
 						context.Background(),
-						mock.MatchedBy(func(id uuid.UUID) bool { return id != uuid.Nil }),
+						mock.MatchedBy(func(id uuid.UUID) bool {
+							if testCase.request.ID != "" {
+								return id.String() == testCase.request.ID
+							}
+
+							return id != uuid.Nil
+						}),
 						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
 						mock.MatchedBy(func(data *dao.CreatePasskeyRequest) bool {
 							baseCHeck := data.Namespace == testCase.request.Namespace &&
 								data.Passkey == testCase.request.Passkey &&
-								reflect.DeepEqual(data.Reward, testCase.request.Reward)
+								data.SingleUse == testCase.policy.SingleUseDefault &&
+								reflect.DeepEqual(data.HashParams, services.HashParamsFromPolicy(testCase.policy)) &&
+								reflect.DeepEqual(data.Reward, testCase.request.Reward) &&
+								reflect.DeepEqual(data.MaxActivePasskeys, testCase.policy.MaxActivePasskeys) &&
+								reflect.DeepEqual(data.CreationRateLimit, testCase.policy.CreationRateLimit) &&
+								reflect.DeepEqual(data.CreationRateWindow, testCase.policy.CreationRateWindow)
 
-							if testCase.request.ExpiresIn == nil {
+							expiresIn := lo.CoalesceOrEmpty(testCase.request.ExpiresIn, testCase.policy.DefaultTTL)
+							if expiresIn == nil {
 								return baseCHeck && data.ExpiresAt == nil
 							}
 
 							return baseCHeck &&
 								data.ExpiresAt != nil &&
-								data.ExpiresAt.After(time.Now().Add(*testCase.request.ExpiresIn-time.Minute)) &&
-								data.ExpiresAt.Before(time.Now().Add(*testCase.request.ExpiresIn+time.Minute))
+								data.ExpiresAt.After(time.Now().Add(*expiresIn-time.Minute)) &&
+								data.ExpiresAt.Before(time.Now().Add(*expiresIn+time.Minute))
 						}),
 					).
 					Return(testCase.passkeyDAOResp, testCase.passkeyDAOErr)
 			}
 
-			service := services.NewCreatePasskey(createPasskeyDAO)
+			service := services.NewCreatePasskey(createPasskeyDAO, resolveNamespacePolicy)
 			response, err := service.Exec(context.Background(), testCase.request)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, response)
 
 			createPasskeyDAO.AssertExpectations(t)
+			resolveNamespacePolicy.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidDeleteNamespaceRequest = errors.New("invalid delete namespace request")
+	ErrDeleteNamespace               = errors.New("delete namespace")
+)
+
+var deleteNamespaceValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type DeleteNamespaceRequest struct {
+	Name string `validate:"required,min=1,max=256"`
+}
+
+type DeleteNamespace interface {
+	Exec(ctx context.Context, data *DeleteNamespaceRequest) (*Namespace, error)
+}
+
+type deleteNamespaceImpl struct {
+	dao dao.DeleteNamespace
+}
+
+func (service *deleteNamespaceImpl) Exec(ctx context.Context, data *DeleteNamespaceRequest) (*Namespace, error) {
+	if err := deleteNamespaceValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidDeleteNamespaceRequest, err)
+	}
+
+	res, err := service.dao.Exec(ctx, &dao.DeleteNamespaceRequest{Name: data.Name})
+	if err != nil {
+		return nil, errors.Join(ErrDeleteNamespace, err)
+	}
+
+	return namespaceFromEntity(res), nil
+}
+
+func NewDeleteNamespace(dao dao.DeleteNamespace) DeleteNamespace {
+	return lib.ServiceWithTracing("services.DeleteNamespace", &deleteNamespaceImpl{dao: dao})
+}
@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestDeleteNamespace(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.DeleteNamespaceRequest
+
+		shouldCallDAO bool
+		daoResp       *entities.Namespace
+		daoErr        error
+
+		expect    *services.Namespace
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.DeleteNamespaceRequest{
+				Name: "namespace",
+			},
+
+			shouldCallDAO: true,
+			daoResp: &entities.Namespace{
+				Name: "namespace",
+				NamespacePolicy: entities.NamespacePolicy{
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					StrengthRules: &entities.StrengthRules{MinLength: 8},
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.Namespace{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+					StrengthRules: &services.NamespaceStrengthRules{MinLength: 8},
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.DeleteNamespaceRequest{
+				Name: "namespace",
+			},
+
+			shouldCallDAO: true,
+			daoErr:        errors.New("uwups"),
+
+			expectErr: services.ErrDeleteNamespace,
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &services.DeleteNamespaceRequest{},
+
+			expectErr: services.ErrInvalidDeleteNamespaceRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			deleteNamespaceDAO := daomocks.NewMockDeleteNamespace(t)
+
+			if testCase.shouldCallDAO {
+				deleteNamespaceDAO.
+					On("Exec", context.Background(), &dao.DeleteNamespaceRequest{Name: testCase.request.Name}).
+					Return(testCase.daoResp, testCase.daoErr)
+			}
+
+			service := services.NewDeleteNamespace(deleteNamespaceDAO)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			deleteNamespaceDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 )
 
 var (
@@ -25,6 +26,8 @@ type DeletePasskeyRequest struct {
 	Namespace string `validate:"required,min=1,max=256"`
 	Passkey   string `validate:"required_if=Validate true,omitempty,min=4,max=4096"`
 	Validate  bool   `validate:"omitempty"`
+	// ExpectedVersion rejects the deletion if the passkey was modified since this version.
+	ExpectedVersion *int64 `validate:"omitempty,min=1"`
 }
 
 type DeletePasskeyResponse struct {
@@ -34,6 +37,7 @@ type DeletePasskeyResponse struct {
 	ExpiresAt *time.Time
 	CreatedAt time.Time
 	UpdatedAt *time.Time
+	Version   int64
 }
 
 type DeletePasskey interface {
@@ -60,9 +64,13 @@ func (service *deletePasskeyImpl) Exec(
 		ID:        passkeyID,
 		Namespace: data.Namespace,
 		RawKey:    lo.Ternary[*string](data.Validate, &data.Passkey, nil),
+
+		ExpectedVersion: data.ExpectedVersion,
 	}
 
 	res, err := service.dao.Exec(ctx, request)
+	recordValidation(data.Validate, data.Namespace, err)
+
 	if err != nil {
 		return nil, errors.Join(ErrDeletePasskey, err)
 	}
@@ -74,9 +82,10 @@ func (service *deletePasskeyImpl) Exec(
 		ExpiresAt: res.ExpiresAt,
 		CreatedAt: res.CreatedAt,
 		UpdatedAt: res.UpdatedAt,
+		Version:   res.Version,
 	}, nil
 }
 
 func NewDeletePasskey(dao dao.DeletePasskey) DeletePasskey {
-	return &deletePasskeyImpl{dao: dao}
+	return lib.ServiceWithTracing("services.DeletePasskey", &deletePasskeyImpl{dao: dao})
 }
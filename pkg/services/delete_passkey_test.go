@@ -57,6 +57,56 @@ func TestDeletePasskey(t *testing.T) {
 				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "OK/ExpectedVersion",
+
+			request: &services.DeletePasskeyRequest{
+				ID:              "00000000-0000-0000-0000-000000000001",
+				Namespace:       "namespace",
+				ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+
+			shouldCallDeletePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:    "namespace",
+				EncryptedKey: "encryptedKey",
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:      2,
+			},
+
+			expect: &services.DeletePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Version:   2,
+			},
+		},
+		{
+			name: "VersionMismatch",
+
+			request: &services.DeletePasskeyRequest{
+				ID:              "00000000-0000-0000-0000-000000000001",
+				Namespace:       "namespace",
+				ExpectedVersion: lo.ToPtr(int64(2)),
+			},
+
+			shouldCallDeletePasskeyDAO: true,
+			passkeyDAOErr:              dao.ErrVersionMismatch,
+
+			expectErr: dao.ErrVersionMismatch,
+		},
+		{
+			name: "InvalidExpectedVersion",
+
+			request: &services.DeletePasskeyRequest{
+				ID:              "00000000-0000-0000-0000-000000000001",
+				Namespace:       "namespace",
+				ExpectedVersion: lo.ToPtr(int64(0)),
+			},
+
+			expectErr: services.ErrInvalidDeletePasskeyRequest,
+		},
 		{
 			name: "OK/WithPassword",
 
@@ -136,6 +186,8 @@ func TestDeletePasskey(t *testing.T) {
 							ID:        uuid.MustParse(testCase.request.ID),
 							Namespace: testCase.request.Namespace,
 							RawKey:    lo.Ternary[*string](testCase.request.Validate, &testCase.request.Passkey, nil),
+
+							ExpectedVersion: testCase.request.ExpectedVersion,
 						},
 					).
 					Return(testCase.passkeyDAOResp, testCase.passkeyDAOErr)
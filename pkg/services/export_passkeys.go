@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidExportPasskeysRequest = errors.New("invalid export passkeys request")
+	ErrExportPasskeys               = errors.New("export passkeys")
+)
+
+var exportPasskeysValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ExportPasskeysRequest struct {
+	Namespace string `validate:"omitempty,max=256"`
+	// Resume the export after the given passkey. Both values must be provided together.
+	AfterNamespace string `validate:"required_with=AfterID,omitempty,max=256"`
+	AfterID        string `validate:"required_with=AfterNamespace,omitempty,len=36"`
+	Limit          int    `validate:"required,min=1,max=1000"`
+	// ActiveOnly leaves out the expired, redeemed and revoked passkeys.
+	ActiveOnly bool
+}
+
+// ExportedPasskey holds the metadata of a passkey, along with its hash. The plaintext value of a passkey is never
+// available after creation.
+type ExportedPasskey struct {
+	ID           string
+	Namespace    string
+	EncryptedKey string
+	Reward       map[string]interface{}
+	SingleUse    bool
+
+	RedeemedAt       *time.Time
+	RedeemedBy       *string
+	RevokedAt        *time.Time
+	RevokedBy        *string
+	RevocationReason *string
+
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type ExportPasskeysResponse struct {
+	Passkeys []*ExportedPasskey
+}
+
+type ExportPasskeys interface {
+	Exec(ctx context.Context, data *ExportPasskeysRequest) (*ExportPasskeysResponse, error)
+}
+
+type exportPasskeysImpl struct {
+	dao dao.ListPasskeys
+}
+
+func (service *exportPasskeysImpl) Exec(
+	ctx context.Context, data *ExportPasskeysRequest,
+) (*ExportPasskeysResponse, error) {
+	if err := exportPasskeysValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidExportPasskeysRequest, err)
+	}
+
+	request := &dao.ListPasskeysRequest{
+		Namespace:       data.Namespace,
+		Limit:           data.Limit,
+		IncludeInactive: !data.ActiveOnly,
+	}
+
+	if data.AfterID != "" {
+		afterID, err := uuid.Parse(data.AfterID)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidExportPasskeysRequest, fmt.Errorf("uuid value: '%s': %w", data.AfterID, err))
+		}
+
+		request.After = &dao.ListPasskeysCursor{Namespace: data.AfterNamespace, ID: afterID}
+	}
+
+	res, err := service.dao.Exec(ctx, request)
+	if err != nil {
+		return nil, errors.Join(ErrExportPasskeys, err)
+	}
+
+	passkeys := make([]*ExportedPasskey, len(res))
+	for i, passkey := range res {
+		passkeys[i] = &ExportedPasskey{
+			ID:           passkey.ID.String(),
+			Namespace:    passkey.Namespace,
+			EncryptedKey: passkey.EncryptedKey,
+			Reward:       passkey.Reward,
+			SingleUse:    passkey.SingleUse,
+
+			RedeemedAt:       passkey.RedeemedAt,
+			RedeemedBy:       passkey.RedeemedBy,
+			RevokedAt:        passkey.RevokedAt,
+			RevokedBy:        passkey.RevokedBy,
+			RevocationReason: passkey.RevocationReason,
+
+			ExpiresAt: passkey.ExpiresAt,
+			CreatedAt: passkey.CreatedAt,
+			UpdatedAt: passkey.UpdatedAt,
+		}
+	}
+
+	return &ExportPasskeysResponse{Passkeys: passkeys}, nil
+}
+
+func NewExportPasskeys(dao dao.ListPasskeys) ExportPasskeys {
+	return lib.ServiceWithTracing("services.ExportPasskeys", &exportPasskeysImpl{dao: dao})
+}
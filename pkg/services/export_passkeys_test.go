@@ -0,0 +1,151 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestExportPasskeys(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ExportPasskeysRequest
+
+		callDAOWith *dao.ListPasskeysRequest
+		daoResp     []*entities.Passkey
+		daoErr      error
+
+		expect    *services.ExportPasskeysResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ExportPasskeysRequest{
+				Namespace:      "namespace",
+				AfterNamespace: "namespace",
+				AfterID:        "00000000-0000-0000-0000-000000000001",
+				Limit:          10,
+			},
+
+			callDAOWith: &dao.ListPasskeysRequest{
+				Namespace: "namespace",
+				After: &dao.ListPasskeysCursor{
+					Namespace: "namespace",
+					ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				},
+				Limit:           10,
+				IncludeInactive: true,
+			},
+			daoResp: []*entities.Passkey{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "namespace",
+					EncryptedKey: "encryptedKey",
+					Reward:       map[string]interface{}{"key": "value"},
+					SingleUse:    true,
+					RevokedAt:    lo.ToPtr(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+					RevokedBy:    lo.ToPtr("admin"),
+					ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+
+			expect: &services.ExportPasskeysResponse{
+				Passkeys: []*services.ExportedPasskey{
+					{
+						ID:           "00000000-0000-0000-0000-000000000002",
+						Namespace:    "namespace",
+						EncryptedKey: "encryptedKey",
+						Reward:       map[string]interface{}{"key": "value"},
+						SingleUse:    true,
+						RevokedAt:    lo.ToPtr(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+						RevokedBy:    lo.ToPtr("admin"),
+						ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+						CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+						UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Empty",
+
+			request: &services.ExportPasskeysRequest{Limit: 10},
+
+			callDAOWith: &dao.ListPasskeysRequest{Limit: 10, IncludeInactive: true},
+			daoResp:     []*entities.Passkey{},
+
+			expect: &services.ExportPasskeysResponse{Passkeys: []*services.ExportedPasskey{}},
+		},
+		{
+			name: "OK/ActiveOnly",
+
+			request: &services.ExportPasskeysRequest{Limit: 10, ActiveOnly: true},
+
+			callDAOWith: &dao.ListPasskeysRequest{Limit: 10},
+			daoResp:     []*entities.Passkey{},
+
+			expect: &services.ExportPasskeysResponse{Passkeys: []*services.ExportedPasskey{}},
+		},
+		{
+			name: "Error/IncompleteCursor",
+
+			request: &services.ExportPasskeysRequest{
+				AfterID: "00000000-0000-0000-0000-000000000001",
+				Limit:   10,
+			},
+
+			expectErr: services.ErrInvalidExportPasskeysRequest,
+		},
+		{
+			name: "Error/NoLimit",
+
+			request: &services.ExportPasskeysRequest{},
+
+			expectErr: services.ErrInvalidExportPasskeysRequest,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ExportPasskeysRequest{Limit: 10},
+
+			callDAOWith: &dao.ListPasskeysRequest{Limit: 10, IncludeInactive: true},
+			daoErr:      errors.New("uwups"),
+
+			expectErr: services.ErrExportPasskeys,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listPasskeysDAO := daomocks.NewMockListPasskeys(t)
+
+			if testCase.callDAOWith != nil {
+				listPasskeysDAO.
+					On("Exec", context.Background(), testCase.callDAOWith).
+					Return(testCase.daoResp, testCase.daoErr)
+			}
+
+			service := services.NewExportPasskeys(listPasskeysDAO)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			listPasskeysDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidGetNamespaceRequest = errors.New("invalid get namespace request")
+	ErrGetNamespace               = errors.New("get namespace")
+)
+
+var getNamespaceValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type GetNamespaceRequest struct {
+	Name string `validate:"required,min=1,max=256"`
+}
+
+type GetNamespace interface {
+	Exec(ctx context.Context, data *GetNamespaceRequest) (*Namespace, error)
+}
+
+type getNamespaceImpl struct {
+	dao dao.GetNamespace
+}
+
+func (service *getNamespaceImpl) Exec(ctx context.Context, data *GetNamespaceRequest) (*Namespace, error) {
+	if err := getNamespaceValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidGetNamespaceRequest, err)
+	}
+
+	res, err := service.dao.Exec(ctx, &dao.GetNamespaceRequest{Name: data.Name})
+	if err != nil {
+		return nil, errors.Join(ErrGetNamespace, err)
+	}
+
+	return namespaceFromEntity(res), nil
+}
+
+func NewGetNamespace(dao dao.GetNamespace) GetNamespace {
+	return lib.ServiceWithTracing("services.GetNamespace", &getNamespaceImpl{dao: dao})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 )
 
 var (
@@ -25,6 +26,8 @@ type GetPasskeyRequest struct {
 	Namespace string `validate:"required,min=1,max=256"`
 	Passkey   string `validate:"required_if=Validate true,omitempty,min=4,max=4096"`
 	Validate  bool   `validate:"omitempty"`
+	// RedeemedBy identifies the caller, and is recorded when the request redeems a single-use passkey.
+	RedeemedBy string `validate:"omitempty,max=256"`
 }
 
 type GetPasskeyResponse struct {
@@ -34,6 +37,7 @@ type GetPasskeyResponse struct {
 	ExpiresAt *time.Time
 	CreatedAt time.Time
 	UpdatedAt *time.Time
+	Version   int64
 }
 
 type GetPasskey interface {
@@ -57,12 +61,15 @@ func (service *getPasskeyImpl) Exec(
 	}
 
 	request := &dao.GetPasskeyRequest{
-		ID:        passkeyID,
-		Namespace: data.Namespace,
-		RawKey:    lo.Ternary[*string](data.Validate, &data.Passkey, nil),
+		ID:         passkeyID,
+		Namespace:  data.Namespace,
+		RawKey:     lo.Ternary[*string](data.Validate, &data.Passkey, nil),
+		RedeemedBy: lo.EmptyableToPtr(data.RedeemedBy),
 	}
 
 	res, err := service.dao.Exec(ctx, request)
+	recordValidation(data.Validate, data.Namespace, err)
+
 	if err != nil {
 		return nil, errors.Join(ErrGetPasskey, err)
 	}
@@ -74,9 +81,10 @@ func (service *getPasskeyImpl) Exec(
 		ExpiresAt: res.ExpiresAt,
 		CreatedAt: res.CreatedAt,
 		UpdatedAt: res.UpdatedAt,
+		Version:   res.Version,
 	}, nil
 }
 
 func NewGetPasskey(dao dao.GetPasskey) GetPasskey {
-	return &getPasskeyImpl{dao: dao}
+	return lib.ServiceWithTracing("services.GetPasskey", &getPasskeyImpl{dao: dao})
 }
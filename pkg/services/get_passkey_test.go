@@ -87,6 +87,34 @@ func TestGetPasskey(t *testing.T) {
 				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "OK/RedeemedBy",
+
+			request: &services.GetPasskeyRequest{
+				ID:         "00000000-0000-0000-0000-000000000001",
+				Namespace:  "namespace",
+				Passkey:    "passkey",
+				Validate:   true,
+				RedeemedBy: "user-1",
+			},
+
+			shouldCallDeletePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:    "namespace",
+				EncryptedKey: "encryptedKey",
+				SingleUse:    true,
+				RedeemedAt:   lo.ToPtr(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+				RedeemedBy:   lo.ToPtr("user-1"),
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.GetPasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
 		{
 			name: "Error/WithPassword/NoPassword",
 
@@ -133,9 +161,10 @@ func TestGetPasskey(t *testing.T) {
 						"Exec",
 						context.Background(),
 						&dao.GetPasskeyRequest{
-							ID:        uuid.MustParse(testCase.request.ID),
-							Namespace: testCase.request.Namespace,
-							RawKey:    lo.Ternary[*string](testCase.request.Validate, &testCase.request.Passkey, nil),
+							ID:         uuid.MustParse(testCase.request.ID),
+							Namespace:  testCase.request.Namespace,
+							RawKey:     lo.Ternary[*string](testCase.request.Validate, &testCase.request.Passkey, nil),
+							RedeemedBy: lo.EmptyableToPtr(testCase.request.RedeemedBy),
 						},
 					).
 					Return(testCase.passkeyDAOResp, testCase.passkeyDAOErr)
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidImportPasskeysRequest = errors.New("invalid import passkeys request")
+	ErrInvalidImportPasskeyRecord   = errors.New("invalid import passkey record")
+	ErrImportPasskeys               = errors.New("import passkeys")
+)
+
+var importPasskeysValidate = validator.New(validator.WithRequiredStructEnabled())
+
+// ImportPasskeyRecord describes a single passkey to import. Exactly one of Passkey (plaintext) or EncryptedKey
+// (an argon2id hash, as produced by lib.GenerateFromPassword) must be set.
+type ImportPasskeyRecord struct {
+	ID           string                 `validate:"omitempty,len=36"`
+	Namespace    string                 `validate:"required,min=1,max=256"`
+	Passkey      string                 `validate:"required_without=EncryptedKey,excluded_with=EncryptedKey,omitempty,min=4,max=4096"` //nolint:lll
+	EncryptedKey string                 `validate:"required_without=Passkey,omitempty,max=1024"`
+	Reward       map[string]interface{} `validate:"omitempty"`
+	// SingleUse defaults to the policy of the namespace when empty.
+	SingleUse *bool `validate:"omitempty"`
+	// Redeemed and revoked passkeys are imported as inactive.
+	RedeemedAt       *time.Time `validate:"omitempty"`
+	RedeemedBy       string     `validate:"omitempty,max=256"`
+	RevokedAt        *time.Time `validate:"required_with=RevokedBy RevocationReason"`
+	RevokedBy        string     `validate:"omitempty,max=256"`
+	RevocationReason string     `validate:"omitempty,max=1024"`
+	ExpiresAt        *time.Time `validate:"omitempty"`
+	CreatedAt        *time.Time `validate:"omitempty"`
+}
+
+type ImportPasskeysRequest struct {
+	Records []*ImportPasskeyRecord `validate:"required,min=1,max=1000"`
+	// When set, records are validated, but nothing is written to the database.
+	DryRun bool `validate:"omitempty"`
+}
+
+// ImportPasskeyFailure reports a record that could not be imported. Index refers to the position of the record in
+// ImportPasskeysRequest.Records.
+type ImportPasskeyFailure struct {
+	Index int
+	Err   error
+}
+
+type ImportPasskeysResponse struct {
+	Imported int
+	Failures []*ImportPasskeyFailure
+}
+
+type ImportPasskeys interface {
+	Exec(ctx context.Context, data *ImportPasskeysRequest) (*ImportPasskeysResponse, error)
+}
+
+type importPasskeysImpl struct {
+	dao      dao.InsertPasskeys
+	policies ResolveNamespacePolicy
+}
+
+// importPolicies resolves the policy of each namespace once per request.
+type importPolicies struct {
+	resolver ResolveNamespacePolicy
+	policies map[string]*entities.NamespacePolicy
+	errs     map[string]error
+}
+
+func (cache *importPolicies) get(ctx context.Context, namespace string) (*entities.NamespacePolicy, error) {
+	if err, ok := cache.errs[namespace]; ok {
+		return nil, err
+	}
+
+	if policy, ok := cache.policies[namespace]; ok {
+		return policy, nil
+	}
+
+	policy, err := cache.resolver.Exec(ctx, namespace)
+	if err != nil {
+		cache.errs[namespace] = fmt.Errorf("resolve namespace policy: %w", err)
+		return nil, cache.errs[namespace]
+	}
+
+	cache.policies[namespace] = policy
+
+	return policy, nil
+}
+
+// quotas returns the quotas of the namespaces that have some.
+func (cache *importPolicies) quotas() map[string]*dao.PasskeyQuotas {
+	output := make(map[string]*dao.PasskeyQuotas)
+
+	for namespace, policy := range cache.policies {
+		if policy.MaxActivePasskeys == nil && policy.CreationRateLimit == nil {
+			continue
+		}
+
+		output[namespace] = &dao.PasskeyQuotas{
+			MaxActivePasskeys:  policy.MaxActivePasskeys,
+			CreationRateLimit:  policy.CreationRateLimit,
+			CreationRateWindow: policy.CreationRateWindow,
+		}
+	}
+
+	return output
+}
+
+// importExpiresAt applies the expiry policy of the namespace, as if the passkey was created now with the remaining
+// lifetime of the record.
+func importExpiresAt(policy *entities.NamespacePolicy, expiresAt *time.Time, now time.Time) (*time.Time, error) {
+	var expiresIn *time.Duration
+	if expiresAt != nil {
+		expiresIn = lo.ToPtr(expiresAt.Sub(now))
+	}
+
+	expiresIn, err := ApplyExpiryPolicy(policy, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt == nil && expiresIn != nil {
+		return lo.ToPtr(now.Add(*expiresIn)), nil
+	}
+
+	return expiresAt, nil
+}
+
+// encryptRecord returns the hash of the record, or an empty string in dry-run mode, as hashing is expensive and the
+// result would be discarded. The strength of hashed secrets cannot be checked.
+func (service *importPasskeysImpl) encryptRecord(
+	ctx context.Context, record *ImportPasskeyRecord, policy *entities.NamespacePolicy, dryRun bool,
+) (string, error) {
+	if record.EncryptedKey != "" {
+		if err := lib.ValidateHash(record.EncryptedKey); err != nil {
+			return "", errors.Join(ErrInvalidImportPasskeyRecord, fmt.Errorf("encrypted key: %w", err))
+		}
+
+		return record.EncryptedKey, nil
+	}
+
+	if err := CheckPasskeyStrength(policy, record.Passkey); err != nil {
+		return "", errors.Join(ErrInvalidImportPasskeyRecord, err)
+	}
+
+	if dryRun {
+		return "", nil
+	}
+
+	encrypted, err := dao.GenerateHash(ctx, record.Passkey, HashParamsFromPolicy(policy))
+	if err != nil {
+		return "", errors.Join(ErrImportPasskeys, fmt.Errorf("encrypt passkey: %w", err))
+	}
+
+	return encrypted, nil
+}
+
+func (service *importPasskeysImpl) prepareRecord(
+	ctx context.Context, record *ImportPasskeyRecord, policies *importPolicies, now time.Time, dryRun bool,
+) (*entities.Passkey, error) {
+	if err := importPasskeysValidate.Struct(record); err != nil {
+		return nil, errors.Join(ErrInvalidImportPasskeyRecord, err)
+	}
+
+	passkeyID := uuid.New()
+	if record.ID != "" {
+		var err error
+		if passkeyID, err = uuid.Parse(record.ID); err != nil {
+			return nil, errors.Join(ErrInvalidImportPasskeyRecord, fmt.Errorf("uuid value: '%s': %w", record.ID, err))
+		}
+	}
+
+	policy, err := policies.get(ctx, record.Namespace)
+	if err != nil {
+		return nil, errors.Join(ErrImportPasskeys, err)
+	}
+
+	expiresAt, err := importExpiresAt(policy, record.ExpiresAt, now)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidImportPasskeyRecord, err)
+	}
+
+	createdAt := now
+	if record.CreatedAt != nil {
+		createdAt = *record.CreatedAt
+	}
+
+	encrypted, err := service.encryptRecord(ctx, record, policy, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Passkey{
+		ID:           passkeyID,
+		Namespace:    record.Namespace,
+		EncryptedKey: encrypted,
+		Reward:       record.Reward,
+		SingleUse:    lo.FromPtrOr(record.SingleUse, policy.SingleUseDefault),
+
+		RedeemedAt:       record.RedeemedAt,
+		RedeemedBy:       lo.EmptyableToPtr(record.RedeemedBy),
+		RevokedAt:        record.RevokedAt,
+		RevokedBy:        lo.EmptyableToPtr(record.RevokedBy),
+		RevocationReason: lo.EmptyableToPtr(record.RevocationReason),
+
+		ExpiresAt: expiresAt,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func (service *importPasskeysImpl) Exec(
+	ctx context.Context, data *ImportPasskeysRequest,
+) (*ImportPasskeysResponse, error) {
+	if err := importPasskeysValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidImportPasskeysRequest, err)
+	}
+
+	now := time.Now()
+	response := &ImportPasskeysResponse{}
+	policies := &importPolicies{
+		resolver: service.policies,
+		policies: make(map[string]*entities.NamespacePolicy),
+		errs:     make(map[string]error),
+	}
+
+	passkeys := make([]*entities.Passkey, 0, len(data.Records))
+	indexes := make([]int, 0, len(data.Records))
+
+	for index, record := range data.Records {
+		passkey, err := service.prepareRecord(ctx, record, policies, now, data.DryRun)
+		if err != nil {
+			response.Failures = append(response.Failures, &ImportPasskeyFailure{Index: index, Err: err})
+			continue
+		}
+
+		passkeys = append(passkeys, passkey)
+		indexes = append(indexes, index)
+	}
+
+	// Quotas depend on the content of the database, so they are only checked when writing.
+	if data.DryRun {
+		response.Imported = len(passkeys)
+		return response, nil
+	}
+
+	quotas := policies.quotas()
+
+	if err := service.dao.Exec(ctx, now, &dao.InsertPasskeysRequest{Passkeys: passkeys, Quotas: quotas}); err == nil {
+		response.Imported = len(passkeys)
+		return response, nil
+	}
+
+	// The batch was rejected as a whole. Retry each passkey on its own, so a single faulty record (for example a
+	// duplicate ID, or a passkey over the quotas of its namespace) does not prevent the others from being imported.
+	for position, passkey := range passkeys {
+		request := &dao.InsertPasskeysRequest{Passkeys: []*entities.Passkey{passkey}, Quotas: quotas}
+		if err := service.dao.Exec(ctx, now, request); err != nil {
+			response.Failures = append(response.Failures, &ImportPasskeyFailure{
+				Index: indexes[position],
+				Err:   errors.Join(ErrImportPasskeys, err),
+			})
+
+			continue
+		}
+
+		response.Imported++
+	}
+
+	return response, nil
+}
+
+// NewImportPasskeys creates a new import service. Records are checked against the policies of their namespaces, as
+// resolved by policies, and count towards their quotas.
+func NewImportPasskeys(dao dao.InsertPasskeys, policies ResolveNamespacePolicy) ImportPasskeys {
+	return lib.ServiceWithTracing(
+		"services.ImportPasskeys", &importPasskeysImpl{dao: dao, policies: policies},
+	)
+}
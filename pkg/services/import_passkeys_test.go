@@ -0,0 +1,233 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
+)
+
+func TestImportPasskeys(t *testing.T) {
+	encrypted, err := lib.GenerateFromPassword("password", lib.DefaultGenerateParams)
+	require.NoError(t, err)
+
+	validRecords := []*services.ImportPasskeyRecord{
+		{
+			ID:        "00000000-0000-0000-0000-000000000001",
+			Namespace: "namespace",
+			Passkey:   "passkey",
+			Reward:    map[string]interface{}{"key": "value"},
+			ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			Namespace:    "namespace",
+			EncryptedKey: encrypted,
+			CreatedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *services.ImportPasskeysRequest
+
+		// Policies of the namespaces of the records. Namespaces without a policy are not registered.
+		policies map[string]*entities.NamespacePolicy
+
+		// Errors returned by successive calls to the DAO. The DAO is not expected to be called when nil.
+		daoErrs []error
+		// Quotas expected by the DAO.
+		expectQuotas map[string]*dao.PasskeyQuotas
+
+		expectImported int
+		expectFailures map[int]error
+		expectErr      error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ImportPasskeysRequest{Records: validRecords},
+
+			policies: map[string]*entities.NamespacePolicy{"namespace": services.DefaultNamespacePolicy()},
+
+			daoErrs: []error{nil},
+
+			expectImported: 2,
+		},
+		{
+			name: "InvalidRecords",
+
+			request: &services.ImportPasskeysRequest{
+				Records: append([]*services.ImportPasskeyRecord{
+					// Both passkey and encrypted key.
+					{Namespace: "namespace", Passkey: "passkey", EncryptedKey: encrypted},
+					// Neither passkey nor encrypted key.
+					{Namespace: "namespace"},
+					// Malformed hash.
+					{Namespace: "namespace", EncryptedKey: "not-a-hash"},
+					// Malformed ID.
+					{ID: "00000000x0000x0000x0000x000000000001", Namespace: "namespace", Passkey: "passkey"},
+				}, validRecords...),
+			},
+
+			policies: map[string]*entities.NamespacePolicy{"namespace": services.DefaultNamespacePolicy()},
+
+			daoErrs: []error{nil},
+
+			expectImported: 2,
+			expectFailures: map[int]error{
+				0: services.ErrInvalidImportPasskeyRecord,
+				1: services.ErrInvalidImportPasskeyRecord,
+				2: services.ErrInvalidImportPasskeyRecord,
+				3: services.ErrInvalidImportPasskeyRecord,
+			},
+		},
+		{
+			name: "DryRun",
+
+			request: &services.ImportPasskeysRequest{
+				Records: append([]*services.ImportPasskeyRecord{{Namespace: "namespace"}}, validRecords...),
+				DryRun:  true,
+			},
+
+			policies: map[string]*entities.NamespacePolicy{"namespace": services.DefaultNamespacePolicy()},
+
+			expectImported: 2,
+			expectFailures: map[int]error{
+				0: services.ErrInvalidImportPasskeyRecord,
+			},
+		},
+		{
+			name: "DAO/BatchError",
+
+			request: &services.ImportPasskeysRequest{Records: validRecords},
+
+			policies: map[string]*entities.NamespacePolicy{"namespace": services.DefaultNamespacePolicy()},
+
+			daoErrs: []error{errors.New("uwups"), errors.New("duplicate"), nil},
+
+			expectImported: 1,
+			expectFailures: map[int]error{
+				0: services.ErrImportPasskeys,
+			},
+		},
+		{
+			name: "Policy",
+
+			request: &services.ImportPasskeysRequest{
+				Records: []*services.ImportPasskeyRecord{
+					{Namespace: "namespace", Passkey: "passkey"},
+					{Namespace: "namespace", Passkey: "Passkey1"},
+					// Exceeds the max TTL.
+					{
+						Namespace: "namespace",
+						Passkey:   "Passkey1",
+						ExpiresAt: lo.ToPtr(time.Now().Add(48 * time.Hour)),
+					},
+					{Namespace: "unregistered", Passkey: "Passkey1"},
+				},
+			},
+
+			policies: map[string]*entities.NamespacePolicy{
+				"namespace": {
+					MaxTTL:            lo.ToPtr(24 * time.Hour),
+					StrengthRules:     &entities.StrengthRules{RequireUppercase: true, RequireDigit: true},
+					SingleUseDefault:  true,
+					MaxActivePasskeys: lo.ToPtr(10),
+				},
+			},
+
+			daoErrs: []error{nil},
+			expectQuotas: map[string]*dao.PasskeyQuotas{
+				"namespace": {MaxActivePasskeys: lo.ToPtr(10)},
+			},
+
+			expectImported: 1,
+			expectFailures: map[int]error{
+				0: services.ErrPolicyViolation,
+				2: services.ErrPolicyViolation,
+				3: services.ErrNamespaceNotRegistered,
+			},
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &services.ImportPasskeysRequest{},
+
+			expectErr: services.ErrInvalidImportPasskeysRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			insertPasskeysDAO := daomocks.NewMockInsertPasskeys(t)
+			resolveNamespacePolicy := servicesmocks.NewMockResolveNamespacePolicy(t)
+
+			resolveNamespacePolicy.
+				On("Exec", context.Background(), mock.Anything).
+				Return(func(_ context.Context, namespace string) (*entities.NamespacePolicy, error) {
+					policy, ok := testCase.policies[namespace]
+					if !ok {
+						return nil, services.ErrNamespaceNotRegistered
+					}
+
+					return policy, nil
+				}).
+				Maybe()
+
+			for _, daoErr := range testCase.daoErrs {
+				insertPasskeysDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(request *dao.InsertPasskeysRequest) bool {
+							for _, passkey := range request.Passkeys {
+								if passkey.ID == uuid.Nil || passkey.EncryptedKey == "" || passkey.CreatedAt.IsZero() {
+									return false
+								}
+							}
+
+							return len(request.Passkeys) > 0 &&
+								(len(request.Quotas) == 0 && len(testCase.expectQuotas) == 0 ||
+									reflect.DeepEqual(request.Quotas, testCase.expectQuotas)) &&
+								lo.EveryBy(request.Passkeys, func(item *entities.Passkey) bool {
+									return item.SingleUse == testCase.policies[item.Namespace].SingleUseDefault
+								})
+						}),
+					).
+					Return(daoErr).
+					Once()
+			}
+
+			service := services.NewImportPasskeys(insertPasskeysDAO, resolveNamespacePolicy)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expectErr == nil {
+				require.Equal(t, testCase.expectImported, resp.Imported)
+				require.Len(t, resp.Failures, len(testCase.expectFailures))
+
+				for _, failure := range resp.Failures {
+					require.ErrorIs(t, failure.Err, testCase.expectFailures[failure.Index])
+				}
+			}
+
+			insertPasskeysDAO.AssertExpectations(t)
+		})
+	}
+}
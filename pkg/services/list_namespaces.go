@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidListNamespacesRequest = errors.New("invalid list namespaces request")
+	ErrListNamespaces               = errors.New("list namespaces")
+)
+
+var listNamespacesValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ListNamespacesRequest struct {
+	Limit  int `validate:"required,min=1,max=100"`
+	Offset int `validate:"omitempty,min=0"`
+}
+
+type ListNamespacesResponse struct {
+	Namespaces []*Namespace
+}
+
+type ListNamespaces interface {
+	Exec(ctx context.Context, data *ListNamespacesRequest) (*ListNamespacesResponse, error)
+}
+
+type listNamespacesImpl struct {
+	dao dao.ListNamespaces
+}
+
+func (service *listNamespacesImpl) Exec(
+	ctx context.Context, data *ListNamespacesRequest,
+) (*ListNamespacesResponse, error) {
+	if err := listNamespacesValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListNamespacesRequest, err)
+	}
+
+	res, err := service.dao.Exec(ctx, &dao.ListNamespacesRequest{
+		Limit:  data.Limit,
+		Offset: data.Offset,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListNamespaces, err)
+	}
+
+	return &ListNamespacesResponse{
+		Namespaces: lo.Map(res, func(item *entities.Namespace, _ int) *Namespace {
+			return namespaceFromEntity(item)
+		}),
+	}, nil
+}
+
+func NewListNamespaces(dao dao.ListNamespaces) ListNamespaces {
+	return lib.ServiceWithTracing("services.ListNamespaces", &listNamespacesImpl{dao: dao})
+}
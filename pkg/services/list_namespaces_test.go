@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestListNamespaces(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListNamespacesRequest
+
+		shouldCallDAO bool
+		daoResp       []*entities.Namespace
+		daoErr        error
+
+		expect    *services.ListNamespacesResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListNamespacesRequest{
+				Limit:  10,
+				Offset: 5,
+			},
+
+			shouldCallDAO: true,
+			daoResp: []*entities.Namespace{
+				{
+					Name:            "namespace-1",
+					NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+					CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Name:            "namespace-2",
+					NamespacePolicy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+					CreatedAt:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expect: &services.ListNamespacesResponse{
+				Namespaces: []*services.Namespace{
+					{
+						Name:      "namespace-1",
+						Policy:    &services.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+						CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						Name:      "namespace-2",
+						Policy:    &services.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+						CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ListNamespacesRequest{
+				Limit: 10,
+			},
+
+			shouldCallDAO: true,
+			daoErr:        errors.New("uwups"),
+
+			expectErr: services.ErrListNamespaces,
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &services.ListNamespacesRequest{
+				Limit: 1000,
+			},
+
+			expectErr: services.ErrInvalidListNamespacesRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listNamespacesDAO := daomocks.NewMockListNamespaces(t)
+
+			if testCase.shouldCallDAO {
+				listNamespacesDAO.
+					On("Exec", context.Background(), &dao.ListNamespacesRequest{
+						Limit:  testCase.request.Limit,
+						Offset: testCase.request.Offset,
+					}).
+					Return(testCase.daoResp, testCase.daoErr)
+			}
+
+			service := services.NewListNamespaces(listNamespacesDAO)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			listNamespacesDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidListPasskeysRequest = errors.New("invalid list passkeys request")
+	ErrListPasskeys               = errors.New("list passkeys")
+)
+
+var listPasskeysValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ListPasskeysRequest struct {
+	Namespace string `validate:"omitempty,max=256"`
+	// Resume the listing after the given passkey. Both values must be provided together.
+	AfterNamespace string `validate:"required_with=AfterID,omitempty,max=256"`
+	AfterID        string `validate:"required_with=AfterNamespace,omitempty,len=36"`
+	Limit          int    `validate:"required,min=1,max=100"`
+}
+
+// ListedPasskey holds the metadata of an active passkey. Unlike exports, listings never contain the hash of the
+// passkey.
+type ListedPasskey struct {
+	ID        string
+	Namespace string
+	Reward    map[string]interface{}
+	SingleUse bool
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Version   int64
+}
+
+type ListPasskeysResponse struct {
+	Passkeys []*ListedPasskey
+}
+
+type ListPasskeys interface {
+	Exec(ctx context.Context, data *ListPasskeysRequest) (*ListPasskeysResponse, error)
+}
+
+type listPasskeysImpl struct {
+	dao dao.ListPasskeys
+}
+
+func (service *listPasskeysImpl) Exec(ctx context.Context, data *ListPasskeysRequest) (*ListPasskeysResponse, error) {
+	if err := listPasskeysValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListPasskeysRequest, err)
+	}
+
+	request := &dao.ListPasskeysRequest{
+		Namespace: data.Namespace,
+		Limit:     data.Limit,
+	}
+
+	if data.AfterID != "" {
+		afterID, err := uuid.Parse(data.AfterID)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidListPasskeysRequest, fmt.Errorf("uuid value: '%s': %w", data.AfterID, err))
+		}
+
+		request.After = &dao.ListPasskeysCursor{Namespace: data.AfterNamespace, ID: afterID}
+	}
+
+	res, err := service.dao.Exec(ctx, request)
+	if err != nil {
+		return nil, errors.Join(ErrListPasskeys, err)
+	}
+
+	return &ListPasskeysResponse{
+		Passkeys: lo.Map(res, func(item *entities.Passkey, _ int) *ListedPasskey {
+			return &ListedPasskey{
+				ID:        item.ID.String(),
+				Namespace: item.Namespace,
+				Reward:    item.Reward,
+				SingleUse: item.SingleUse,
+				ExpiresAt: item.ExpiresAt,
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
+				Version:   item.Version,
+			}
+		}),
+	}, nil
+}
+
+func NewListPasskeys(dao dao.ListPasskeys) ListPasskeys {
+	return lib.ServiceWithTracing("services.ListPasskeys", &listPasskeysImpl{dao: dao})
+}
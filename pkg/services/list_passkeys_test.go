@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestListPasskeys(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListPasskeysRequest
+
+		callDAOWith *dao.ListPasskeysRequest
+		daoResp     []*entities.Passkey
+		daoErr      error
+
+		expect    *services.ListPasskeysResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListPasskeysRequest{
+				Namespace:      "namespace",
+				AfterNamespace: "namespace",
+				AfterID:        "00000000-0000-0000-0000-000000000001",
+				Limit:          10,
+			},
+
+			callDAOWith: &dao.ListPasskeysRequest{
+				Namespace: "namespace",
+				After: &dao.ListPasskeysCursor{
+					Namespace: "namespace",
+					ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				},
+				Limit: 10,
+			},
+			daoResp: []*entities.Passkey{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Namespace:    "namespace",
+					EncryptedKey: "encryptedKey",
+					Reward:       map[string]interface{}{"key": "value"},
+					SingleUse:    true,
+					ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+					Version:      2,
+				},
+			},
+
+			expect: &services.ListPasskeysResponse{
+				Passkeys: []*services.ListedPasskey{
+					{
+						ID:        "00000000-0000-0000-0000-000000000002",
+						Namespace: "namespace",
+						Reward:    map[string]interface{}{"key": "value"},
+						SingleUse: true,
+						ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+						CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+						UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+						Version:   2,
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Empty",
+
+			request: &services.ListPasskeysRequest{Limit: 10},
+
+			callDAOWith: &dao.ListPasskeysRequest{Limit: 10},
+			daoResp:     []*entities.Passkey{},
+
+			expect: &services.ListPasskeysResponse{Passkeys: []*services.ListedPasskey{}},
+		},
+		{
+			name: "Error/IncompleteCursor",
+
+			request: &services.ListPasskeysRequest{
+				AfterID: "00000000-0000-0000-0000-000000000001",
+				Limit:   10,
+			},
+
+			expectErr: services.ErrInvalidListPasskeysRequest,
+		},
+		{
+			name: "Error/LimitTooHigh",
+
+			request: &services.ListPasskeysRequest{Limit: 1000},
+
+			expectErr: services.ErrInvalidListPasskeysRequest,
+		},
+		{
+			name: "Error/NoLimit",
+
+			request: &services.ListPasskeysRequest{},
+
+			expectErr: services.ErrInvalidListPasskeysRequest,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ListPasskeysRequest{Limit: 10},
+
+			callDAOWith: &dao.ListPasskeysRequest{Limit: 10},
+			daoErr:      errors.New("uwups"),
+
+			expectErr: services.ErrListPasskeys,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listPasskeysDAO := daomocks.NewMockListPasskeys(t)
+
+			if testCase.callDAOWith != nil {
+				listPasskeysDAO.
+					On("Exec", context.Background(), testCase.callDAOWith).
+					Return(testCase.daoResp, testCase.daoErr)
+			}
+
+			service := services.NewListPasskeys(listPasskeysDAO)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			listPasskeysDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+func validationResult(err error) string {
+	switch {
+	case err == nil:
+		return metrics.ValidationSuccess
+	case errors.Is(err, dao.ErrInvalidPasskey):
+		return metrics.ValidationInvalid
+	case errors.Is(err, dao.ErrPasskeyNotFound):
+		return metrics.ValidationNotFound
+	case errors.Is(err, dao.ErrPasskeyRevoked):
+		return metrics.ValidationRevoked
+	default:
+		return metrics.ValidationError
+	}
+}
+
+// recordValidation reports the outcome of a request that checked a passkey against a secret. Nothing is reported
+// when the request did not validate the passkey.
+func recordValidation(validate bool, namespace string, err error) {
+	if !validate {
+		return
+	}
+
+	label := metrics.NamespaceLabel(namespace)
+	result := validationResult(err)
+
+	metrics.Validations.WithLabelValues(label, result).Inc()
+
+	if result == metrics.ValidationRevoked {
+		metrics.Lockouts.WithLabelValues(label, metrics.LockoutRevoked).Inc()
+	}
+}
+
+// recordQuotaLockout reports requests refused because a quota of the namespace is exceeded.
+func recordQuotaLockout(err error) {
+	var quotaErr *dao.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		metrics.Lockouts.WithLabelValues(metrics.NamespaceLabel(quotaErr.Namespace), quotaErr.Quota).Inc()
+	}
+}
@@ -0,0 +1,91 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestValidationMetrics(t *testing.T) {
+	metrics.SetNamespaces([]string{"tracked"})
+	t.Cleanup(func() { metrics.SetNamespaces(nil) })
+
+	testCases := []struct {
+		name string
+
+		namespace string
+		validate  bool
+		daoErr    error
+
+		expectLabel   string
+		expectResult  string
+		expectCounted bool
+		expectLockout bool
+	}{
+		{
+			name: "Invalid",
+
+			namespace: "tracked",
+			validate:  true,
+			daoErr:    dao.ErrInvalidPasskey,
+
+			expectLabel:   "tracked",
+			expectResult:  metrics.ValidationInvalid,
+			expectCounted: true,
+		},
+		{
+			name: "Revoked",
+
+			namespace: "untracked",
+			validate:  true,
+			daoErr:    dao.ErrPasskeyRevoked,
+
+			expectLabel:   metrics.OtherNamespace,
+			expectResult:  metrics.ValidationRevoked,
+			expectCounted: true,
+			expectLockout: true,
+		},
+		{
+			name: "NoValidation",
+
+			namespace: "tracked",
+			daoErr:    dao.ErrPasskeyNotFound,
+
+			expectLabel:  "tracked",
+			expectResult: metrics.ValidationNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			validations := metrics.Validations.WithLabelValues(testCase.expectLabel, testCase.expectResult)
+			lockouts := metrics.Lockouts.WithLabelValues(testCase.expectLabel, metrics.LockoutRevoked)
+
+			validationsBefore := testutil.ToFloat64(validations)
+			lockoutsBefore := testutil.ToFloat64(lockouts)
+
+			getPasskeyDAO := daomocks.NewMockGetPasskey(t)
+			getPasskeyDAO.On("Exec", mock.Anything, mock.Anything).Return(nil, testCase.daoErr)
+
+			_, err := services.NewGetPasskey(getPasskeyDAO).Exec(context.Background(), &services.GetPasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: testCase.namespace,
+				Passkey:   "passkey",
+				Validate:  testCase.validate,
+			})
+			require.ErrorIs(t, err, testCase.daoErr)
+
+			require.Equal(t, validationsBefore+lo.Ternary(testCase.expectCounted, 1.0, 0.0), testutil.ToFloat64(validations))
+			require.Equal(t, lockoutsBefore+lo.Ternary(testCase.expectLockout, 1.0, 0.0), testutil.ToFloat64(lockouts))
+		})
+	}
+}
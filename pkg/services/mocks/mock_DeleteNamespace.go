@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-passkeys/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeleteNamespace is an autogenerated mock type for the DeleteNamespace type
+type MockDeleteNamespace struct {
+	mock.Mock
+}
+
+type MockDeleteNamespace_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeleteNamespace) EXPECT() *MockDeleteNamespace_Expecter {
+	return &MockDeleteNamespace_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockDeleteNamespace) Exec(ctx context.Context, data *services.DeleteNamespaceRequest) (*services.Namespace, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.DeleteNamespaceRequest) (*services.Namespace, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.DeleteNamespaceRequest) *services.Namespace); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.DeleteNamespaceRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeleteNamespace_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockDeleteNamespace_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.DeleteNamespaceRequest
+func (_e *MockDeleteNamespace_Expecter) Exec(ctx interface{}, data interface{}) *MockDeleteNamespace_Exec_Call {
+	return &MockDeleteNamespace_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockDeleteNamespace_Exec_Call) Run(run func(ctx context.Context, data *services.DeleteNamespaceRequest)) *MockDeleteNamespace_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.DeleteNamespaceRequest))
+	})
+	return _c
+}
+
+func (_c *MockDeleteNamespace_Exec_Call) Return(_a0 *services.Namespace, _a1 error) *MockDeleteNamespace_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeleteNamespace_Exec_Call) RunAndReturn(run func(context.Context, *services.DeleteNamespaceRequest) (*services.Namespace, error)) *MockDeleteNamespace_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeleteNamespace creates a new instance of MockDeleteNamespace. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeleteNamespace(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeleteNamespace {
+	mock := &MockDeleteNamespace{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-passkeys/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExportPasskeys is an autogenerated mock type for the ExportPasskeys type
+type MockExportPasskeys struct {
+	mock.Mock
+}
+
+type MockExportPasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportPasskeys) EXPECT() *MockExportPasskeys_Expecter {
+	return &MockExportPasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockExportPasskeys) Exec(ctx context.Context, data *services.ExportPasskeysRequest) (*services.ExportPasskeysResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.ExportPasskeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ExportPasskeysRequest) (*services.ExportPasskeysResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ExportPasskeysRequest) *services.ExportPasskeysResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.ExportPasskeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.ExportPasskeysRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExportPasskeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockExportPasskeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.ExportPasskeysRequest
+func (_e *MockExportPasskeys_Expecter) Exec(ctx interface{}, data interface{}) *MockExportPasskeys_Exec_Call {
+	return &MockExportPasskeys_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockExportPasskeys_Exec_Call) Run(run func(ctx context.Context, data *services.ExportPasskeysRequest)) *MockExportPasskeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.ExportPasskeysRequest))
+	})
+	return _c
+}
+
+func (_c *MockExportPasskeys_Exec_Call) Return(_a0 *services.ExportPasskeysResponse, _a1 error) *MockExportPasskeys_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExportPasskeys_Exec_Call) RunAndReturn(run func(context.Context, *services.ExportPasskeysRequest) (*services.ExportPasskeysResponse, error)) *MockExportPasskeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportPasskeys creates a new instance of MockExportPasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportPasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportPasskeys {
+	mock := &MockExportPasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
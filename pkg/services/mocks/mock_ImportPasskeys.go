@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-passkeys/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockImportPasskeys is an autogenerated mock type for the ImportPasskeys type
+type MockImportPasskeys struct {
+	mock.Mock
+}
+
+type MockImportPasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportPasskeys) EXPECT() *MockImportPasskeys_Expecter {
+	return &MockImportPasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockImportPasskeys) Exec(ctx context.Context, data *services.ImportPasskeysRequest) (*services.ImportPasskeysResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.ImportPasskeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ImportPasskeysRequest) (*services.ImportPasskeysResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ImportPasskeysRequest) *services.ImportPasskeysResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.ImportPasskeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.ImportPasskeysRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockImportPasskeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockImportPasskeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.ImportPasskeysRequest
+func (_e *MockImportPasskeys_Expecter) Exec(ctx interface{}, data interface{}) *MockImportPasskeys_Exec_Call {
+	return &MockImportPasskeys_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockImportPasskeys_Exec_Call) Run(run func(ctx context.Context, data *services.ImportPasskeysRequest)) *MockImportPasskeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.ImportPasskeysRequest))
+	})
+	return _c
+}
+
+func (_c *MockImportPasskeys_Exec_Call) Return(_a0 *services.ImportPasskeysResponse, _a1 error) *MockImportPasskeys_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockImportPasskeys_Exec_Call) RunAndReturn(run func(context.Context, *services.ImportPasskeysRequest) (*services.ImportPasskeysResponse, error)) *MockImportPasskeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportPasskeys creates a new instance of MockImportPasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportPasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportPasskeys {
+	mock := &MockImportPasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-passkeys/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListPasskeys is an autogenerated mock type for the ListPasskeys type
+type MockListPasskeys struct {
+	mock.Mock
+}
+
+type MockListPasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListPasskeys) EXPECT() *MockListPasskeys_Expecter {
+	return &MockListPasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockListPasskeys) Exec(ctx context.Context, data *services.ListPasskeysRequest) (*services.ListPasskeysResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.ListPasskeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ListPasskeysRequest) (*services.ListPasskeysResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ListPasskeysRequest) *services.ListPasskeysResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.ListPasskeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.ListPasskeysRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListPasskeys_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockListPasskeys_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.ListPasskeysRequest
+func (_e *MockListPasskeys_Expecter) Exec(ctx interface{}, data interface{}) *MockListPasskeys_Exec_Call {
+	return &MockListPasskeys_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockListPasskeys_Exec_Call) Run(run func(ctx context.Context, data *services.ListPasskeysRequest)) *MockListPasskeys_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.ListPasskeysRequest))
+	})
+	return _c
+}
+
+func (_c *MockListPasskeys_Exec_Call) Return(_a0 *services.ListPasskeysResponse, _a1 error) *MockListPasskeys_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListPasskeys_Exec_Call) RunAndReturn(run func(context.Context, *services.ListPasskeysRequest) (*services.ListPasskeysResponse, error)) *MockListPasskeys_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListPasskeys creates a new instance of MockListPasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListPasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListPasskeys {
+	mock := &MockListPasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	entities "github.com/a-novel/uservice-passkeys/pkg/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockResolveNamespacePolicy is an autogenerated mock type for the ResolveNamespacePolicy type
+type MockResolveNamespacePolicy struct {
+	mock.Mock
+}
+
+type MockResolveNamespacePolicy_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResolveNamespacePolicy) EXPECT() *MockResolveNamespacePolicy_Expecter {
+	return &MockResolveNamespacePolicy_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, namespace
+func (_m *MockResolveNamespacePolicy) Exec(ctx context.Context, namespace string) (*entities.NamespacePolicy, error) {
+	ret := _m.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.NamespacePolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.NamespacePolicy, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entities.NamespacePolicy); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.NamespacePolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockResolveNamespacePolicy_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockResolveNamespacePolicy_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+func (_e *MockResolveNamespacePolicy_Expecter) Exec(ctx interface{}, namespace interface{}) *MockResolveNamespacePolicy_Exec_Call {
+	return &MockResolveNamespacePolicy_Exec_Call{Call: _e.mock.On("Exec", ctx, namespace)}
+}
+
+func (_c *MockResolveNamespacePolicy_Exec_Call) Run(run func(ctx context.Context, namespace string)) *MockResolveNamespacePolicy_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockResolveNamespacePolicy_Exec_Call) Return(_a0 *entities.NamespacePolicy, _a1 error) *MockResolveNamespacePolicy_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockResolveNamespacePolicy_Exec_Call) RunAndReturn(run func(context.Context, string) (*entities.NamespacePolicy, error)) *MockResolveNamespacePolicy_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockResolveNamespacePolicy creates a new instance of MockResolveNamespacePolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResolveNamespacePolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResolveNamespacePolicy {
+	mock := &MockResolveNamespacePolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-passkeys/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRestorePasskey is an autogenerated mock type for the RestorePasskey type
+type MockRestorePasskey struct {
+	mock.Mock
+}
+
+type MockRestorePasskey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRestorePasskey) EXPECT() *MockRestorePasskey_Expecter {
+	return &MockRestorePasskey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockRestorePasskey) Exec(ctx context.Context, data *services.RestorePasskeyRequest) (*services.RestorePasskeyResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.RestorePasskeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RestorePasskeyRequest) (*services.RestorePasskeyResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RestorePasskeyRequest) *services.RestorePasskeyResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.RestorePasskeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.RestorePasskeyRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRestorePasskey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRestorePasskey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.RestorePasskeyRequest
+func (_e *MockRestorePasskey_Expecter) Exec(ctx interface{}, data interface{}) *MockRestorePasskey_Exec_Call {
+	return &MockRestorePasskey_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockRestorePasskey_Exec_Call) Run(run func(ctx context.Context, data *services.RestorePasskeyRequest)) *MockRestorePasskey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.RestorePasskeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockRestorePasskey_Exec_Call) Return(_a0 *services.RestorePasskeyResponse, _a1 error) *MockRestorePasskey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRestorePasskey_Exec_Call) RunAndReturn(run func(context.Context, *services.RestorePasskeyRequest) (*services.RestorePasskeyResponse, error)) *MockRestorePasskey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRestorePasskey creates a new instance of MockRestorePasskey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRestorePasskey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRestorePasskey {
+	mock := &MockRestorePasskey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-passkeys/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRevokePasskey is an autogenerated mock type for the RevokePasskey type
+type MockRevokePasskey struct {
+	mock.Mock
+}
+
+type MockRevokePasskey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevokePasskey) EXPECT() *MockRevokePasskey_Expecter {
+	return &MockRevokePasskey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockRevokePasskey) Exec(ctx context.Context, data *services.RevokePasskeyRequest) (*services.RevokePasskeyResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.RevokePasskeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RevokePasskeyRequest) (*services.RevokePasskeyResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RevokePasskeyRequest) *services.RevokePasskeyResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.RevokePasskeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.RevokePasskeyRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRevokePasskey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRevokePasskey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.RevokePasskeyRequest
+func (_e *MockRevokePasskey_Expecter) Exec(ctx interface{}, data interface{}) *MockRevokePasskey_Exec_Call {
+	return &MockRevokePasskey_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockRevokePasskey_Exec_Call) Run(run func(ctx context.Context, data *services.RevokePasskeyRequest)) *MockRevokePasskey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.RevokePasskeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockRevokePasskey_Exec_Call) Return(_a0 *services.RevokePasskeyResponse, _a1 error) *MockRevokePasskey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRevokePasskey_Exec_Call) RunAndReturn(run func(context.Context, *services.RevokePasskeyRequest) (*services.RevokePasskeyResponse, error)) *MockRevokePasskey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevokePasskey creates a new instance of MockRevokePasskey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevokePasskey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevokePasskey {
+	mock := &MockRevokePasskey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrNamespaceNotRegistered = errors.New("namespace is not registered")
+	ErrPolicyViolation        = errors.New("namespace policy violation")
+
+	errInvalidNamespacePolicy = errors.New("invalid namespace policy")
+)
+
+// DefaultNamespacePolicy applies to namespaces that are not registered, when those are allowed.
+func DefaultNamespacePolicy() *entities.NamespacePolicy {
+	return &entities.NamespacePolicy{
+		HashAlgorithm: entities.HashAlgorithmArgon2id,
+		HashParams: &entities.HashParams{
+			SaltLength:  lib.DefaultGenerateParams.SaltLength,
+			Iterations:  lib.DefaultGenerateParams.Iterations,
+			Memory:      lib.DefaultGenerateParams.Memory,
+			Parallelism: lib.DefaultGenerateParams.Parallelism,
+			KeyLength:   lib.DefaultGenerateParams.KeyLength,
+		},
+		StrengthRules: &entities.StrengthRules{MinLength: 4, MaxLength: 4096},
+	}
+}
+
+// ResolveNamespacePolicy returns the policy passkey services must enforce for a given namespace.
+type ResolveNamespacePolicy interface {
+	Exec(ctx context.Context, namespace string) (*entities.NamespacePolicy, error)
+}
+
+type resolveNamespacePolicyImpl struct {
+	dao               dao.GetNamespace
+	requireRegistered bool
+}
+
+func (service *resolveNamespacePolicyImpl) Exec(
+	ctx context.Context, namespace string,
+) (*entities.NamespacePolicy, error) {
+	res, err := service.dao.Exec(ctx, &dao.GetNamespaceRequest{Name: namespace})
+	if errors.Is(err, dao.ErrNamespaceNotFound) {
+		if service.requireRegistered {
+			return nil, errors.Join(ErrNamespaceNotRegistered, err)
+		}
+
+		return DefaultNamespacePolicy(), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get namespace: %w", err)
+	}
+
+	return &res.NamespacePolicy, nil
+}
+
+// NewResolveNamespacePolicy creates a new policy resolver. When requireRegistered is false, unknown namespaces
+// use the DefaultNamespacePolicy.
+func NewResolveNamespacePolicy(dao dao.GetNamespace, requireRegistered bool) ResolveNamespacePolicy {
+	return lib.ServiceWithTracing(
+		"services.ResolveNamespacePolicy",
+		&resolveNamespacePolicyImpl{dao: dao, requireRegistered: requireRegistered},
+	)
+}
+
+// ApplyExpiryPolicy computes the lifetime of a passkey. The default TTL of the namespace is used when no
+// expiration is requested, or the max TTL if the namespace has no default.
+func ApplyExpiryPolicy(policy *entities.NamespacePolicy, expiresIn *time.Duration) (*time.Duration, error) {
+	if expiresIn == nil {
+		expiresIn = lo.CoalesceOrEmpty(policy.DefaultTTL, policy.MaxTTL)
+	}
+
+	if policy.MaxTTL != nil && expiresIn != nil && *expiresIn > *policy.MaxTTL {
+		return nil, fmt.Errorf("%w: expiration exceeds the max ttl of %s", ErrPolicyViolation, *policy.MaxTTL)
+	}
+
+	return expiresIn, nil
+}
+
+// CheckPasskeyStrength ensures a secret matches the strength rules of a namespace.
+func CheckPasskeyStrength(policy *entities.NamespacePolicy, passkey string) error {
+	rules := policy.StrengthRules
+	if rules == nil {
+		return nil
+	}
+
+	length := len([]rune(passkey))
+	if rules.MinLength > 0 && length < rules.MinLength {
+		return fmt.Errorf("%w: passkey must be at least %d characters long", ErrPolicyViolation, rules.MinLength)
+	}
+
+	if rules.MaxLength > 0 && length > rules.MaxLength {
+		return fmt.Errorf("%w: passkey must be at most %d characters long", ErrPolicyViolation, rules.MaxLength)
+	}
+
+	checks := []struct {
+		required bool
+		match    func(rune) bool
+		name     string
+	}{
+		{rules.RequireLowercase, unicode.IsLower, "a lowercase letter"},
+		{rules.RequireUppercase, unicode.IsUpper, "an uppercase letter"},
+		{rules.RequireDigit, unicode.IsDigit, "a digit"},
+		{rules.RequireSymbol, isSymbol, "a symbol"},
+	}
+
+	for _, check := range checks {
+		if check.required && !lo.ContainsBy([]rune(passkey), check.match) {
+			return fmt.Errorf("%w: passkey must contain %s", ErrPolicyViolation, check.name)
+		}
+	}
+
+	return nil
+}
+
+// HashParamsFromPolicy returns the hash configuration of a namespace. Empty values use lib.DefaultGenerateParams.
+func HashParamsFromPolicy(policy *entities.NamespacePolicy) *lib.GenerateParams {
+	if policy.HashParams == nil {
+		return lib.DefaultGenerateParams
+	}
+
+	return &lib.GenerateParams{
+		SaltLength:  policy.HashParams.SaltLength,
+		Iterations:  policy.HashParams.Iterations,
+		Memory:      policy.HashParams.Memory,
+		Parallelism: policy.HashParams.Parallelism,
+		KeyLength:   policy.HashParams.KeyLength,
+	}
+}
+
+func isSymbol(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// NamespaceHashParams configures argon2id. Memory is expressed in KiB.
+type NamespaceHashParams struct {
+	SaltLength  uint   `validate:"required,min=8,max=1024"`
+	Iterations  uint32 `validate:"required,min=1,max=64"`
+	Memory      uint32 `validate:"required,min=8192,max=4194304"`
+	Parallelism uint8  `validate:"required,min=1"`
+	KeyLength   uint32 `validate:"required,min=16,max=1024"`
+}
+
+type NamespaceStrengthRules struct {
+	MinLength        int `validate:"omitempty,min=4,max=4096"`
+	MaxLength        int `validate:"omitempty,min=4,max=4096"`
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+}
+
+type NamespacePolicy struct {
+	DefaultTTL *time.Duration `validate:"omitempty,gt=0"`
+	MaxTTL     *time.Duration `validate:"omitempty,gt=0"`
+	// HashAlgorithm defaults to argon2id, which is the only supported algorithm for now.
+	HashAlgorithm     string                  `validate:"omitempty,oneof=argon2id"`
+	HashParams        *NamespaceHashParams    `validate:"omitempty"`
+	StrengthRules     *NamespaceStrengthRules `validate:"omitempty"`
+	SingleUseDefault  bool
+	MaxActivePasskeys *int `validate:"omitempty,min=1"`
+	// CreationRateLimit caps the number of passkeys created over CreationRateWindow. Both must be set together.
+	CreationRateLimit  *int           `validate:"required_with=CreationRateWindow,omitempty,min=1"`
+	CreationRateWindow *time.Duration `validate:"required_with=CreationRateLimit,omitempty,gt=0"`
+	// SecretHistorySize is the number of previous secrets a passkey cannot rotate back to on update.
+	SecretHistorySize int `validate:"min=0,max=24"`
+}
+
+type Namespace struct {
+	Name      string
+	Policy    *NamespacePolicy
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// checkNamespacePolicy runs the checks that involve multiple fields, and cannot be expressed with validation tags.
+func checkNamespacePolicy(policy *NamespacePolicy) error {
+	if policy.DefaultTTL != nil && policy.MaxTTL != nil && *policy.DefaultTTL > *policy.MaxTTL {
+		return fmt.Errorf("%w: default ttl exceeds max ttl", errInvalidNamespacePolicy)
+	}
+
+	if policy.StrengthRules != nil &&
+		policy.StrengthRules.MaxLength > 0 &&
+		policy.StrengthRules.MinLength > policy.StrengthRules.MaxLength {
+		return fmt.Errorf("%w: strength rules min length exceeds max length", errInvalidNamespacePolicy)
+	}
+
+	return nil
+}
+
+func namespacePolicyToEntity(policy *NamespacePolicy) entities.NamespacePolicy {
+	output := entities.NamespacePolicy{
+		DefaultTTL:        policy.DefaultTTL,
+		MaxTTL:            policy.MaxTTL,
+		HashAlgorithm:     lo.CoalesceOrEmpty(policy.HashAlgorithm, entities.HashAlgorithmArgon2id),
+		SingleUseDefault:  policy.SingleUseDefault,
+		MaxActivePasskeys: policy.MaxActivePasskeys,
+
+		CreationRateLimit:  policy.CreationRateLimit,
+		CreationRateWindow: policy.CreationRateWindow,
+		SecretHistorySize:  policy.SecretHistorySize,
+	}
+
+	if policy.HashParams != nil {
+		output.HashParams = &entities.HashParams{
+			SaltLength:  policy.HashParams.SaltLength,
+			Iterations:  policy.HashParams.Iterations,
+			Memory:      policy.HashParams.Memory,
+			Parallelism: policy.HashParams.Parallelism,
+			KeyLength:   policy.HashParams.KeyLength,
+		}
+	}
+
+	if policy.StrengthRules != nil {
+		output.StrengthRules = &entities.StrengthRules{
+			MinLength:        policy.StrengthRules.MinLength,
+			MaxLength:        policy.StrengthRules.MaxLength,
+			RequireLowercase: policy.StrengthRules.RequireLowercase,
+			RequireUppercase: policy.StrengthRules.RequireUppercase,
+			RequireDigit:     policy.StrengthRules.RequireDigit,
+			RequireSymbol:    policy.StrengthRules.RequireSymbol,
+		}
+	}
+
+	return output
+}
+
+func namespaceFromEntity(namespace *entities.Namespace) *Namespace {
+	policy := &NamespacePolicy{
+		DefaultTTL:        namespace.DefaultTTL,
+		MaxTTL:            namespace.MaxTTL,
+		HashAlgorithm:     namespace.HashAlgorithm,
+		SingleUseDefault:  namespace.SingleUseDefault,
+		MaxActivePasskeys: namespace.MaxActivePasskeys,
+
+		CreationRateLimit:  namespace.CreationRateLimit,
+		CreationRateWindow: namespace.CreationRateWindow,
+		SecretHistorySize:  namespace.SecretHistorySize,
+	}
+
+	if namespace.HashParams != nil {
+		policy.HashParams = &NamespaceHashParams{
+			SaltLength:  namespace.HashParams.SaltLength,
+			Iterations:  namespace.HashParams.Iterations,
+			Memory:      namespace.HashParams.Memory,
+			Parallelism: namespace.HashParams.Parallelism,
+			KeyLength:   namespace.HashParams.KeyLength,
+		}
+	}
+
+	if namespace.StrengthRules != nil {
+		policy.StrengthRules = &NamespaceStrengthRules{
+			MinLength:        namespace.StrengthRules.MinLength,
+			MaxLength:        namespace.StrengthRules.MaxLength,
+			RequireLowercase: namespace.StrengthRules.RequireLowercase,
+			RequireUppercase: namespace.StrengthRules.RequireUppercase,
+			RequireDigit:     namespace.StrengthRules.RequireDigit,
+			RequireSymbol:    namespace.StrengthRules.RequireSymbol,
+		}
+	}
+
+	return &Namespace{
+		Name:      namespace.Name,
+		Policy:    policy,
+		CreatedAt: namespace.CreatedAt,
+		UpdatedAt: namespace.UpdatedAt,
+	}
+}
@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestResolveNamespacePolicy(t *testing.T) {
+	errUwups := errors.New("uwups")
+
+	testCases := []struct {
+		name string
+
+		requireRegistered bool
+
+		daoResp *entities.Namespace
+		daoErr  error
+
+		expect    *entities.NamespacePolicy
+		expectErr error
+	}{
+		{
+			name: "Registered",
+
+			daoResp: &entities.Namespace{
+				Name: "namespace",
+				NamespacePolicy: entities.NamespacePolicy{
+					MaxTTL:           lo.ToPtr(time.Hour),
+					HashAlgorithm:    entities.HashAlgorithmArgon2id,
+					SingleUseDefault: true,
+				},
+			},
+
+			expect: &entities.NamespacePolicy{
+				MaxTTL:           lo.ToPtr(time.Hour),
+				HashAlgorithm:    entities.HashAlgorithmArgon2id,
+				SingleUseDefault: true,
+			},
+		},
+		{
+			name: "Unknown",
+
+			daoErr: dao.ErrNamespaceNotFound,
+
+			expect: services.DefaultNamespacePolicy(),
+		},
+		{
+			name: "Unknown/RequireRegistered",
+
+			requireRegistered: true,
+
+			daoErr: dao.ErrNamespaceNotFound,
+
+			expectErr: services.ErrNamespaceNotRegistered,
+		},
+		{
+			name: "DAO/Error",
+
+			daoErr: errUwups,
+
+			expectErr: errUwups,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			getNamespaceDAO := daomocks.NewMockGetNamespace(t)
+
+			getNamespaceDAO.
+				On("Exec", context.Background(), &dao.GetNamespaceRequest{Name: "namespace"}).
+				Return(testCase.daoResp, testCase.daoErr)
+
+			service := services.NewResolveNamespacePolicy(getNamespaceDAO, testCase.requireRegistered)
+			resp, err := service.Exec(context.Background(), "namespace")
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			getNamespaceDAO.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApplyExpiryPolicy(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		policy    *entities.NamespacePolicy
+		expiresIn *time.Duration
+
+		expect    *time.Duration
+		expectErr error
+	}{
+		{
+			name:   "NoPolicy",
+			policy: &entities.NamespacePolicy{},
+		},
+		{
+			name:      "NoPolicy/Requested",
+			policy:    &entities.NamespacePolicy{},
+			expiresIn: lo.ToPtr(time.Hour),
+			expect:    lo.ToPtr(time.Hour),
+		},
+		{
+			name:   "DefaultTTL",
+			policy: &entities.NamespacePolicy{DefaultTTL: lo.ToPtr(time.Hour), MaxTTL: lo.ToPtr(2 * time.Hour)},
+			expect: lo.ToPtr(time.Hour),
+		},
+		{
+			name:   "MaxTTL",
+			policy: &entities.NamespacePolicy{MaxTTL: lo.ToPtr(2 * time.Hour)},
+			expect: lo.ToPtr(2 * time.Hour),
+		},
+		{
+			name:      "MaxTTL/Exceeded",
+			policy:    &entities.NamespacePolicy{MaxTTL: lo.ToPtr(2 * time.Hour)},
+			expiresIn: lo.ToPtr(3 * time.Hour),
+			expectErr: services.ErrPolicyViolation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := services.ApplyExpiryPolicy(testCase.policy, testCase.expiresIn)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+		})
+	}
+}
+
+func TestCheckPasskeyStrength(t *testing.T) {
+	rules := &entities.NamespacePolicy{
+		StrengthRules: &entities.StrengthRules{
+			MinLength:        8,
+			MaxLength:        16,
+			RequireLowercase: true,
+			RequireUppercase: true,
+			RequireDigit:     true,
+			RequireSymbol:    true,
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		policy  *entities.NamespacePolicy
+		passkey string
+
+		expectErr error
+	}{
+		{name: "NoRules", policy: &entities.NamespacePolicy{}, passkey: "a"},
+		{name: "OK", policy: rules, passkey: "Passw0rd!"},
+		{name: "TooShort", policy: rules, passkey: "Pa0!", expectErr: services.ErrPolicyViolation},
+		{name: "TooLong", policy: rules, passkey: "Passw0rd!Passw0rd!", expectErr: services.ErrPolicyViolation},
+		{name: "NoLowercase", policy: rules, passkey: "PASSW0RD!", expectErr: services.ErrPolicyViolation},
+		{name: "NoUppercase", policy: rules, passkey: "passw0rd!", expectErr: services.ErrPolicyViolation},
+		{name: "NoDigit", policy: rules, passkey: "Password!", expectErr: services.ErrPolicyViolation},
+		{name: "NoSymbol", policy: rules, passkey: "Passw0rd", expectErr: services.ErrPolicyViolation},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.ErrorIs(t, services.CheckPasskeyStrength(testCase.policy, testCase.passkey), testCase.expectErr)
+		})
+	}
+}
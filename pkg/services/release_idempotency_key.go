@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidReleaseIdempotencyKeyRequest = errors.New("invalid release idempotency key request")
+	ErrReleaseIdempotencyKey               = errors.New("release idempotency key")
+)
+
+var releaseIdempotencyKeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ReleaseIdempotencyKeyRequest struct {
+	Key     string `validate:"required,min=1,max=256"`
+	Service string `validate:"required,min=1,max=256"`
+}
+
+type ReleaseIdempotencyKey interface {
+	Exec(ctx context.Context, data *ReleaseIdempotencyKeyRequest) error
+}
+
+type releaseIdempotencyKeyImpl struct {
+	dao dao.ReleaseIdempotencyKey
+}
+
+func (service *releaseIdempotencyKeyImpl) Exec(ctx context.Context, data *ReleaseIdempotencyKeyRequest) error {
+	if err := releaseIdempotencyKeyValidate.Struct(data); err != nil {
+		return errors.Join(ErrInvalidReleaseIdempotencyKeyRequest, err)
+	}
+
+	request := &dao.ReleaseIdempotencyKeyRequest{
+		Key:     data.Key,
+		Service: data.Service,
+	}
+
+	if err := service.dao.Exec(ctx, request); err != nil {
+		return errors.Join(ErrReleaseIdempotencyKey, err)
+	}
+
+	return nil
+}
+
+func NewReleaseIdempotencyKey(dao dao.ReleaseIdempotencyKey) ReleaseIdempotencyKey {
+	return lib.CommandWithTracing("services.ReleaseIdempotencyKey", &releaseIdempotencyKeyImpl{dao: dao})
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestReleaseIdempotencyKey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ReleaseIdempotencyKeyRequest
+
+		shouldCallDAO bool
+		daoErr        error
+
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ReleaseIdempotencyKeyRequest{
+				Key:     "key",
+				Service: "service",
+			},
+
+			shouldCallDAO: true,
+		},
+		{
+			name: "Error/NoService",
+
+			request: &services.ReleaseIdempotencyKeyRequest{
+				Key: "key",
+			},
+
+			expectErr: services.ErrInvalidReleaseIdempotencyKeyRequest,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ReleaseIdempotencyKeyRequest{
+				Key:     "key",
+				Service: "service",
+			},
+
+			shouldCallDAO: true,
+			daoErr:        errors.New("uwups"),
+
+			expectErr: services.ErrReleaseIdempotencyKey,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			releaseIdempotencyKeyDAO := daomocks.NewMockReleaseIdempotencyKey(t)
+
+			if testCase.shouldCallDAO {
+				releaseIdempotencyKeyDAO.
+					On("Exec", context.Background(), &dao.ReleaseIdempotencyKeyRequest{
+						Key:     testCase.request.Key,
+						Service: testCase.request.Service,
+					}).
+					Return(testCase.daoErr)
+			}
+
+			service := services.NewReleaseIdempotencyKey(releaseIdempotencyKeyDAO)
+			err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			releaseIdempotencyKeyDAO.AssertExpectations(t)
+		})
+	}
+}
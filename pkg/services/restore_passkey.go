@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+// DefaultRestoreWindow is used when no retention window is configured for revoked passkeys.
+const DefaultRestoreWindow = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidRestorePasskeyRequest = errors.New("invalid restore passkey request")
+	ErrRestorePasskey               = errors.New("restore passkey")
+)
+
+var restorePasskeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RestorePasskeyRequest struct {
+	ID        string `validate:"required,len=36"`
+	Namespace string `validate:"required,min=1,max=256"`
+}
+
+type RestorePasskeyResponse struct {
+	ID        string
+	Namespace string
+	Reward    map[string]interface{}
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Version   int64
+}
+
+type RestorePasskey interface {
+	Exec(ctx context.Context, data *RestorePasskeyRequest) (*RestorePasskeyResponse, error)
+}
+
+type restorePasskeyImpl struct {
+	dao    dao.RestorePasskey
+	window time.Duration
+}
+
+func (service *restorePasskeyImpl) Exec(
+	ctx context.Context, data *RestorePasskeyRequest,
+) (*RestorePasskeyResponse, error) {
+	if err := restorePasskeyValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRestorePasskeyRequest, err)
+	}
+
+	passkeyID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidRestorePasskeyRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	now := time.Now()
+
+	request := &dao.RestorePasskeyRequest{
+		ID:           passkeyID,
+		Namespace:    data.Namespace,
+		RevokedAfter: now.Add(-service.window),
+	}
+
+	res, err := service.dao.Exec(ctx, now, request)
+	if err != nil {
+		return nil, errors.Join(ErrRestorePasskey, err)
+	}
+
+	return &RestorePasskeyResponse{
+		ID:        res.ID.String(),
+		Namespace: res.Namespace,
+		Reward:    res.Reward,
+		ExpiresAt: res.ExpiresAt,
+		CreatedAt: res.CreatedAt,
+		UpdatedAt: res.UpdatedAt,
+		Version:   res.Version,
+	}, nil
+}
+
+// NewRestorePasskey creates a new restore service. Passkeys revoked for longer than window cannot be restored.
+func NewRestorePasskey(dao dao.RestorePasskey, window time.Duration) RestorePasskey {
+	return lib.ServiceWithTracing("services.RestorePasskey", &restorePasskeyImpl{dao: dao, window: window})
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestRestorePasskey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RestorePasskeyRequest
+
+		shouldCallRestorePasskeyDAO bool
+		passkeyDAOResp              *entities.Passkey
+		passkeyDAOErr               error
+
+		expect    *services.RestorePasskeyResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RestorePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+			},
+
+			shouldCallRestorePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:    "namespace",
+				EncryptedKey: "encryptedKey",
+				Reward:       map[string]interface{}{"key": "value"},
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.RestorePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Error/InvalidID",
+
+			request: &services.RestorePasskeyRequest{
+				ID:        "00000000x0000x0000x0000x000000000001",
+				Namespace: "namespace",
+			},
+
+			expectErr: services.ErrInvalidRestorePasskeyRequest,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.RestorePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+			},
+
+			shouldCallRestorePasskeyDAO: true,
+			passkeyDAOErr:               errors.New("uwups"),
+
+			expectErr: services.ErrRestorePasskey,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			restorePasskeyDAO := daomocks.NewMockRestorePasskey(t)
+
+			if testCase.shouldCallRestorePasskeyDAO {
+				restorePasskeyDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						mock.MatchedBy(func(data *dao.RestorePasskeyRequest) bool {
+							window := time.Since(data.RevokedAfter)
+
+							return data.ID == uuid.MustParse(testCase.request.ID) &&
+								data.Namespace == testCase.request.Namespace &&
+								window > services.DefaultRestoreWindow-time.Minute &&
+								window < services.DefaultRestoreWindow+time.Minute
+						}),
+					).
+					Return(testCase.passkeyDAOResp, testCase.passkeyDAOErr)
+			}
+
+			service := services.NewRestorePasskey(restorePasskeyDAO, services.DefaultRestoreWindow)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			restorePasskeyDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidRevokePasskeyRequest = errors.New("invalid revoke passkey request")
+	ErrRevokePasskey               = errors.New("revoke passkey")
+)
+
+var revokePasskeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RevokePasskeyRequest struct {
+	ID        string `validate:"required,len=36"`
+	Namespace string `validate:"required,min=1,max=256"`
+	RevokedBy string `validate:"required,min=1,max=256"`
+	Reason    string `validate:"required,min=1,max=1024"`
+}
+
+type RevokePasskeyResponse struct {
+	ID               string
+	Namespace        string
+	Reward           map[string]interface{}
+	ExpiresAt        *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	Version          int64
+	RevokedAt        time.Time
+	RevokedBy        string
+	RevocationReason string
+}
+
+type RevokePasskey interface {
+	Exec(ctx context.Context, data *RevokePasskeyRequest) (*RevokePasskeyResponse, error)
+}
+
+type revokePasskeyImpl struct {
+	dao dao.RevokePasskey
+}
+
+func (service *revokePasskeyImpl) Exec(
+	ctx context.Context, data *RevokePasskeyRequest,
+) (*RevokePasskeyResponse, error) {
+	if err := revokePasskeyValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRevokePasskeyRequest, err)
+	}
+
+	passkeyID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidRevokePasskeyRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	request := &dao.RevokePasskeyRequest{
+		ID:        passkeyID,
+		Namespace: data.Namespace,
+		RevokedBy: data.RevokedBy,
+		Reason:    data.Reason,
+	}
+
+	res, err := service.dao.Exec(ctx, time.Now(), request)
+	if err != nil {
+		return nil, errors.Join(ErrRevokePasskey, err)
+	}
+
+	return &RevokePasskeyResponse{
+		ID:               res.ID.String(),
+		Namespace:        res.Namespace,
+		Reward:           res.Reward,
+		ExpiresAt:        res.ExpiresAt,
+		CreatedAt:        res.CreatedAt,
+		UpdatedAt:        res.UpdatedAt,
+		Version:          res.Version,
+		RevokedAt:        *res.RevokedAt,
+		RevokedBy:        *res.RevokedBy,
+		RevocationReason: *res.RevocationReason,
+	}, nil
+}
+
+func NewRevokePasskey(dao dao.RevokePasskey) RevokePasskey {
+	return lib.ServiceWithTracing("services.RevokePasskey", &revokePasskeyImpl{dao: dao})
+}
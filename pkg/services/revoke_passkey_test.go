@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestRevokePasskey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RevokePasskeyRequest
+
+		shouldCallRevokePasskeyDAO bool
+		passkeyDAOResp             *entities.Passkey
+		passkeyDAOErr              error
+
+		expect    *services.RevokePasskeyResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RevokePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+
+			shouldCallRevokePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:               uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Namespace:        "namespace",
+				EncryptedKey:     "encryptedKey",
+				Reward:           map[string]interface{}{"key": "value"},
+				ExpiresAt:        lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:        time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				RevokedAt:        lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				RevokedBy:        lo.ToPtr("admin"),
+				RevocationReason: lo.ToPtr("leaked"),
+			},
+
+			expect: &services.RevokePasskeyResponse{
+				ID:               "00000000-0000-0000-0000-000000000001",
+				Namespace:        "namespace",
+				Reward:           map[string]interface{}{"key": "value"},
+				ExpiresAt:        lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:        time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				RevokedAt:        time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC),
+				RevokedBy:        "admin",
+				RevocationReason: "leaked",
+			},
+		},
+		{
+			name: "Error/NoReason",
+
+			request: &services.RevokePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+			},
+
+			expectErr: services.ErrInvalidRevokePasskeyRequest,
+		},
+		{
+			name: "Error/InvalidID",
+
+			request: &services.RevokePasskeyRequest{
+				ID:        "00000000x0000x0000x0000x000000000001",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+
+			expectErr: services.ErrInvalidRevokePasskeyRequest,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.RevokePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Namespace: "namespace",
+				RevokedBy: "admin",
+				Reason:    "leaked",
+			},
+
+			shouldCallRevokePasskeyDAO: true,
+			passkeyDAOErr:              errors.New("uwups"),
+
+			expectErr: services.ErrRevokePasskey,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			revokePasskeyDAO := daomocks.NewMockRevokePasskey(t)
+
+			if testCase.shouldCallRevokePasskeyDAO {
+				revokePasskeyDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.RevokePasskeyRequest{
+							ID:        uuid.MustParse(testCase.request.ID),
+							Namespace: testCase.request.Namespace,
+							RevokedBy: testCase.request.RevokedBy,
+							Reason:    testCase.request.Reason,
+						},
+					).
+					Return(testCase.passkeyDAOResp, testCase.passkeyDAOErr)
+			}
+
+			service := services.NewRevokePasskey(revokePasskeyDAO)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			revokePasskeyDAO.AssertExpectations(t)
+		})
+	}
+}
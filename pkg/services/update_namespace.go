@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
+)
+
+var (
+	ErrInvalidUpdateNamespaceRequest = errors.New("invalid update namespace request")
+	ErrUpdateNamespace               = errors.New("update namespace")
+)
+
+var updateNamespaceValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type UpdateNamespaceRequest struct {
+	Name   string           `validate:"required,min=1,max=256"`
+	Policy *NamespacePolicy `validate:"required"`
+}
+
+type UpdateNamespace interface {
+	Exec(ctx context.Context, data *UpdateNamespaceRequest) (*Namespace, error)
+}
+
+type updateNamespaceImpl struct {
+	dao dao.UpdateNamespace
+}
+
+func (service *updateNamespaceImpl) Exec(ctx context.Context, data *UpdateNamespaceRequest) (*Namespace, error) {
+	if err := updateNamespaceValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidUpdateNamespaceRequest, err)
+	}
+
+	if err := checkNamespacePolicy(data.Policy); err != nil {
+		return nil, errors.Join(ErrInvalidUpdateNamespaceRequest, err)
+	}
+
+	res, err := service.dao.Exec(ctx, time.Now(), &dao.UpdateNamespaceRequest{
+		Name:   data.Name,
+		Policy: namespacePolicyToEntity(data.Policy),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrUpdateNamespace, err)
+	}
+
+	return namespaceFromEntity(res), nil
+}
+
+func NewUpdateNamespace(dao dao.UpdateNamespace) UpdateNamespace {
+	return lib.ServiceWithTracing("services.UpdateNamespace", &updateNamespaceImpl{dao: dao})
+}
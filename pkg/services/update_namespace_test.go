@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/entities"
+	"github.com/a-novel/uservice-passkeys/pkg/services"
+)
+
+func TestUpdateNamespace(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.UpdateNamespaceRequest
+
+		shouldCallDAO bool
+		daoRequest    *dao.UpdateNamespaceRequest
+		daoResp       *entities.Namespace
+		daoErr        error
+
+		expect    *services.Namespace
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.UpdateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{MaxTTL: lo.ToPtr(time.Hour)},
+			},
+
+			shouldCallDAO: true,
+			daoRequest: &dao.UpdateNamespaceRequest{
+				Name: "namespace",
+				Policy: entities.NamespacePolicy{
+					MaxTTL:        lo.ToPtr(time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+				},
+			},
+			daoResp: &entities.Namespace{
+				Name: "namespace",
+				NamespacePolicy: entities.NamespacePolicy{
+					MaxTTL:        lo.ToPtr(time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.Namespace{
+				Name: "namespace",
+				Policy: &services.NamespacePolicy{
+					MaxTTL:        lo.ToPtr(time.Hour),
+					HashAlgorithm: entities.HashAlgorithmArgon2id,
+				},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.UpdateNamespaceRequest{
+				Name:   "namespace",
+				Policy: &services.NamespacePolicy{},
+			},
+
+			shouldCallDAO: true,
+			daoRequest: &dao.UpdateNamespaceRequest{
+				Name:   "namespace",
+				Policy: entities.NamespacePolicy{HashAlgorithm: entities.HashAlgorithmArgon2id},
+			},
+			daoErr: errors.New("uwups"),
+
+			expectErr: services.ErrUpdateNamespace,
+		},
+		{
+			name: "InvalidRequest",
+
+			request: &services.UpdateNamespaceRequest{
+				Policy: &services.NamespacePolicy{},
+			},
+
+			expectErr: services.ErrInvalidUpdateNamespaceRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			updateNamespaceDAO := daomocks.NewMockUpdateNamespace(t)
+
+			if testCase.shouldCallDAO {
+				updateNamespaceDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.daoRequest,
+					).
+					Return(testCase.daoResp, testCase.daoErr)
+			}
+
+			service := services.NewUpdateNamespace(updateNamespaceDAO)
+			resp, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, resp)
+
+			updateNamespaceDAO.AssertExpectations(t)
+		})
+	}
+}
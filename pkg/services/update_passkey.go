@@ -8,23 +8,50 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 
 	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/lib"
 )
 
 var (
 	ErrInvalidUpdatePasskeyRequest = errors.New("invalid update passkey request")
 	ErrUpdatePasskey               = errors.New("update passkey")
+	ErrMissingUpdatedPasskey       = errors.New("passkey is required to update the secret")
 )
 
+// Fields that can be listed in the update mask of UpdatePasskeyRequest.
+const (
+	UpdateMaskPasskey   = "passkey"
+	UpdateMaskReward    = "reward"
+	UpdateMaskExpiresIn = "expires_in"
+)
+
+var updateMaskAll = []string{UpdateMaskPasskey, UpdateMaskReward, UpdateMaskExpiresIn}
+
+var updateMaskToDAO = map[string]dao.UpdatePasskeyField{
+	UpdateMaskPasskey:   dao.UpdatePasskeyFieldPasskey,
+	UpdateMaskReward:    dao.UpdatePasskeyFieldReward,
+	UpdateMaskExpiresIn: dao.UpdatePasskeyFieldExpiresAt,
+}
+
 var updatePasskeyValidate = validator.New(validator.WithRequiredStructEnabled())
 
 type UpdatePasskeyRequest struct {
 	ID        string                 `validate:"required,len=36"`
 	Namespace string                 `validate:"required,min=1,max=256"`
-	Passkey   string                 `validate:"required,min=4,max=4096"`
+	Passkey   string                 `validate:"omitempty,min=4,max=4096"`
 	Reward    map[string]interface{} `validate:"omitempty"`
 	ExpiresIn *time.Duration         `validate:"omitempty"`
+	// UpdateMask lists the fields to update. A field outside the mask keeps its current value, so a nil ExpiresIn
+	// within the mask clears the expiration. Every field is updated when the mask is empty, in which case the passkey
+	// is required.
+	UpdateMask []string `validate:"omitempty,max=3,unique,dive,oneof=passkey reward expires_in"`
+	// CurrentPasskey must match the current secret of the passkey when Validate is set.
+	CurrentPasskey string `validate:"required_if=Validate true,omitempty,min=4,max=4096"`
+	Validate       bool   `validate:"omitempty"`
+	// ExpectedVersion rejects the update if the passkey was modified since this version.
+	ExpectedVersion *int64 `validate:"omitempty,min=1"`
 }
 
 type UpdatePasskeyResponse struct {
@@ -34,6 +61,7 @@ type UpdatePasskeyResponse struct {
 	ExpiresAt *time.Time
 	CreatedAt time.Time
 	UpdatedAt *time.Time
+	Version   int64
 }
 
 type UpdatePasskey interface {
@@ -41,7 +69,8 @@ type UpdatePasskey interface {
 }
 
 type updatePasskeyImpl struct {
-	dao dao.UpdatePasskey
+	dao      dao.UpdatePasskey
+	policies ResolveNamespacePolicy
 }
 
 func (service *updatePasskeyImpl) Exec(
@@ -56,14 +85,48 @@ func (service *updatePasskeyImpl) Exec(
 		return nil, errors.Join(ErrInvalidUpdatePasskeyRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
 	}
 
+	mask, err := updatePasskeyMask(data)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidUpdatePasskeyRequest, err)
+	}
+
+	policy, err := service.policies.Exec(ctx, data.Namespace)
+	if err != nil {
+		return nil, errors.Join(ErrUpdatePasskey, err)
+	}
+
 	request := &dao.UpdatePasskeyRequest{
-		Namespace: data.Namespace,
-		Passkey:   data.Passkey,
-		Reward:    data.Reward,
-		ExpiresAt: ExpiresInToTime(data.ExpiresIn),
+		Mask:       mask,
+		Namespace:  data.Namespace,
+		Reward:     data.Reward,
+		CurrentKey: lo.Ternary[*string](data.Validate, &data.CurrentPasskey, nil),
+		HashParams: HashParamsFromPolicy(policy),
+
+		ExpectedVersion: data.ExpectedVersion,
+
+		HistorySize: policy.SecretHistorySize,
+	}
+
+	if lo.Contains(mask, dao.UpdatePasskeyFieldPasskey) {
+		if err := CheckPasskeyStrength(policy, data.Passkey); err != nil {
+			return nil, errors.Join(ErrUpdatePasskey, err)
+		}
+
+		request.Passkey = data.Passkey
+	}
+
+	if lo.Contains(mask, dao.UpdatePasskeyFieldExpiresAt) {
+		expiresIn, err := ApplyExpiryPolicy(policy, data.ExpiresIn)
+		if err != nil {
+			return nil, errors.Join(ErrUpdatePasskey, err)
+		}
+
+		request.ExpiresAt = ExpiresInToTime(expiresIn)
 	}
 
 	res, err := service.dao.Exec(ctx, passkeyID, time.Now(), request)
+	recordValidation(data.Validate, data.Namespace, err)
+
 	if err != nil {
 		return nil, errors.Join(ErrUpdatePasskey, err)
 	}
@@ -75,9 +138,25 @@ func (service *updatePasskeyImpl) Exec(
 		ExpiresAt: res.ExpiresAt,
 		CreatedAt: res.CreatedAt,
 		UpdatedAt: res.UpdatedAt,
+		Version:   res.Version,
 	}, nil
 }
 
-func NewUpdatePasskey(dao dao.UpdatePasskey) UpdatePasskey {
-	return &updatePasskeyImpl{dao: dao}
+// updatePasskeyMask converts the update mask of a request to the fields of the DAO, and makes sure the passkey is
+// set whenever the secret is updated.
+func updatePasskeyMask(data *UpdatePasskeyRequest) ([]dao.UpdatePasskeyField, error) {
+	mask := lo.Map(
+		lo.Ternary(len(data.UpdateMask) == 0, updateMaskAll, data.UpdateMask),
+		func(item string, _ int) dao.UpdatePasskeyField { return updateMaskToDAO[item] },
+	)
+
+	if lo.Contains(mask, dao.UpdatePasskeyFieldPasskey) && data.Passkey == "" {
+		return nil, ErrMissingUpdatedPasskey
+	}
+
+	return mask, nil
+}
+
+func NewUpdatePasskey(dao dao.UpdatePasskey, policies ResolveNamespacePolicy) UpdatePasskey {
+	return lib.ServiceWithTracing("services.UpdatePasskey", &updatePasskeyImpl{dao: dao, policies: policies})
 }
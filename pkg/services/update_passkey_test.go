@@ -16,6 +16,7 @@ import (
 	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
 	"github.com/a-novel/uservice-passkeys/pkg/entities"
 	"github.com/a-novel/uservice-passkeys/pkg/services"
+	servicesmocks "github.com/a-novel/uservice-passkeys/pkg/services/mocks"
 )
 
 func TestUpdatePasskey(t *testing.T) {
@@ -24,6 +25,10 @@ func TestUpdatePasskey(t *testing.T) {
 
 		request *services.UpdatePasskeyRequest
 
+		shouldResolvePolicy bool
+		policy              *entities.NamespacePolicy
+		policyErr           error
+
 		shouldCallUpdatePasskeyDAO bool
 		passkeyDAOResp             *entities.Passkey
 		passkeyDAOErr              error
@@ -42,6 +47,9 @@ func TestUpdatePasskey(t *testing.T) {
 				ExpiresIn: lo.ToPtr(time.Hour * 24),
 			},
 
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
 			shouldCallUpdatePasskeyDAO: true,
 			passkeyDAOResp: &entities.Passkey{
 				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
@@ -70,6 +78,153 @@ func TestUpdatePasskey(t *testing.T) {
 				Passkey:   "passkey",
 			},
 
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "UpdateMask/Reward",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:         "00000000-0000-0000-0000-000000000002",
+				Namespace:  "namespace",
+				Reward:     map[string]interface{}{"key": "value"},
+				UpdateMask: []string{services.UpdateMaskReward},
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				// Ignored, since the expiration is not updated.
+				DefaultTTL: lo.ToPtr(time.Hour),
+				// Ignored, since the secret is not updated.
+				StrengthRules: &entities.StrengthRules{MinLength: 16},
+			},
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "UpdateMask/ClearExpiration",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:         "00000000-0000-0000-0000-000000000002",
+				Namespace:  "namespace",
+				UpdateMask: []string{services.UpdateMaskExpiresIn},
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "UpdateMask/Passkey",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:         "00000000-0000-0000-0000-000000000002",
+				Namespace:  "namespace",
+				Passkey:    "passkey",
+				UpdateMask: []string{services.UpdateMaskPasskey},
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "UpdateMask/MissingPasskey",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:         "00000000-0000-0000-0000-000000000002",
+				Namespace:  "namespace",
+				UpdateMask: []string{services.UpdateMaskPasskey, services.UpdateMaskReward},
+			},
+
+			expectErr: services.ErrInvalidUpdatePasskeyRequest,
+		},
+		{
+			name: "UpdateMask/UnknownField",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:         "00000000-0000-0000-0000-000000000002",
+				Namespace:  "namespace",
+				UpdateMask: []string{"created_at"},
+			},
+
+			expectErr: services.ErrInvalidUpdatePasskeyRequest,
+		},
+		{
+			name: "Validate",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:             "00000000-0000-0000-0000-000000000002",
+				Namespace:      "namespace",
+				Passkey:        "new-passkey",
+				UpdateMask:     []string{services.UpdateMaskPasskey},
+				CurrentPasskey: "passkey",
+				Validate:       true,
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
 			shouldCallUpdatePasskeyDAO: true,
 			passkeyDAOResp: &entities.Passkey{
 				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
@@ -85,6 +240,91 @@ func TestUpdatePasskey(t *testing.T) {
 				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "Validate/InvalidPasskey",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:             "00000000-0000-0000-0000-000000000002",
+				Namespace:      "namespace",
+				Passkey:        "new-passkey",
+				UpdateMask:     []string{services.UpdateMaskPasskey},
+				CurrentPasskey: "passkey",
+				Validate:       true,
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOErr:              dao.ErrInvalidPasskey,
+
+			expectErr: dao.ErrInvalidPasskey,
+		},
+		{
+			name: "Validate/MissingCurrentPasskey",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:         "00000000-0000-0000-0000-000000000002",
+				Namespace:  "namespace",
+				Passkey:    "new-passkey",
+				UpdateMask: []string{services.UpdateMaskPasskey},
+				Validate:   true,
+			},
+
+			expectErr: services.ErrInvalidUpdatePasskeyRequest,
+		},
+		{
+			name: "ExpectedVersion",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:              "00000000-0000-0000-0000-000000000002",
+				Namespace:       "namespace",
+				Reward:          map[string]interface{}{"key": "value"},
+				UpdateMask:      []string{services.UpdateMaskReward},
+				ExpectedVersion: lo.ToPtr(int64(4)),
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				Version:   5,
+			},
+
+			expect: &services.UpdatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				Reward:    map[string]interface{}{"key": "value"},
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				Version:   5,
+			},
+		},
+		{
+			name: "ExpectedVersion/Mismatch",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:              "00000000-0000-0000-0000-000000000002",
+				Namespace:       "namespace",
+				Reward:          map[string]interface{}{"key": "value"},
+				UpdateMask:      []string{services.UpdateMaskReward},
+				ExpectedVersion: lo.ToPtr(int64(4)),
+			},
+
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOErr:              dao.ErrVersionMismatch,
+
+			expectErr: dao.ErrVersionMismatch,
+		},
 		{
 			name: "DAO/Error",
 
@@ -96,12 +336,113 @@ func TestUpdatePasskey(t *testing.T) {
 				ExpiresIn: lo.ToPtr(time.Hour * 24),
 			},
 
+			shouldResolvePolicy: true,
+			policy:              services.DefaultNamespacePolicy(),
+
 			shouldCallUpdatePasskeyDAO: true,
 
 			passkeyDAOErr: errors.New("uwups"),
 
 			expectErr: services.ErrUpdatePasskey,
 		},
+		{
+			name: "Policy/DefaultTTL",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				DefaultTTL: lo.ToPtr(time.Hour),
+				MaxTTL:     lo.ToPtr(time.Hour * 24),
+			},
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOResp: &entities.Passkey{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Namespace: "namespace",
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 1, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdatePasskeyResponse{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 1, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Policy/SecretReused",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				SecretHistorySize: 3,
+			},
+
+			shouldCallUpdatePasskeyDAO: true,
+			passkeyDAOErr:              dao.ErrSecretReused,
+
+			expectErr: dao.ErrSecretReused,
+		},
+		{
+			name: "Policy/MaxTTL",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+				ExpiresIn: lo.ToPtr(time.Hour * 48),
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				MaxTTL: lo.ToPtr(time.Hour * 24),
+			},
+
+			expectErr: services.ErrPolicyViolation,
+		},
+		{
+			name: "Policy/WeakPasskey",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policy: &entities.NamespacePolicy{
+				StrengthRules: &entities.StrengthRules{MinLength: 4, RequireDigit: true},
+			},
+
+			expectErr: services.ErrPolicyViolation,
+		},
+		{
+			name: "Policy/NotRegistered",
+
+			request: &services.UpdatePasskeyRequest{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				Namespace: "namespace",
+				Passkey:   "passkey",
+			},
+
+			shouldResolvePolicy: true,
+			policyErr:           services.ErrNamespaceNotRegistered,
+
+			expectErr: services.ErrNamespaceNotRegistered,
+		},
 		{
 			name: "InvalidRequest",
 
@@ -127,6 +468,13 @@ func TestUpdatePasskey(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			updatePasskeyDAO := daomocks.NewMockUpdatePasskey(t)
+			resolveNamespacePolicy := servicesmocks.NewMockResolveNamespacePolicy(t)
+
+			if testCase.shouldResolvePolicy {
+				resolveNamespacePolicy.
+					On("Exec", context.Background(), testCase.request.Namespace).
+					Return(testCase.policy, testCase.policyErr)
+			}
 
 			if testCase.shouldCallUpdatePasskeyDAO {
 				updatePasskeyDAO.
@@ -136,30 +484,48 @@ func TestUpdatePasskey(t *testing.T) {
 						uuid.MustParse(testCase.request.ID),
 						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
 						mock.MatchedBy(func(data *dao.UpdatePasskeyRequest) bool {
+							mask := lo.Ternary(len(testCase.request.UpdateMask) > 0, testCase.request.UpdateMask, []string{
+								services.UpdateMaskPasskey, services.UpdateMaskReward, services.UpdateMaskExpiresIn,
+							})
+
 							baseCHeck := data.Namespace == testCase.request.Namespace &&
+								len(data.Mask) == len(mask) &&
 								data.Passkey == testCase.request.Passkey &&
-								reflect.DeepEqual(data.Reward, testCase.request.Reward)
+								reflect.DeepEqual(data.HashParams, services.HashParamsFromPolicy(testCase.policy)) &&
+								reflect.DeepEqual(data.Reward, testCase.request.Reward) &&
+								reflect.DeepEqual(
+									data.CurrentKey,
+									lo.Ternary(testCase.request.Validate, &testCase.request.CurrentPasskey, nil),
+								) &&
+								reflect.DeepEqual(data.ExpectedVersion, testCase.request.ExpectedVersion) &&
+								data.HistorySize == testCase.policy.SecretHistorySize
+
+							if !lo.Contains(mask, services.UpdateMaskExpiresIn) {
+								return baseCHeck && data.ExpiresAt == nil
+							}
 
-							if testCase.request.ExpiresIn == nil {
+							expiresIn := lo.CoalesceOrEmpty(testCase.request.ExpiresIn, testCase.policy.DefaultTTL)
+							if expiresIn == nil {
 								return baseCHeck && data.ExpiresAt == nil
 							}
 
 							return baseCHeck &&
 								data.ExpiresAt != nil &&
-								data.ExpiresAt.After(time.Now().Add(*testCase.request.ExpiresIn-time.Minute)) &&
-								data.ExpiresAt.Before(time.Now().Add(*testCase.request.ExpiresIn+time.Minute))
+								data.ExpiresAt.After(time.Now().Add(*expiresIn-time.Minute)) &&
+								data.ExpiresAt.Before(time.Now().Add(*expiresIn+time.Minute))
 						}),
 					).
 					Return(testCase.passkeyDAOResp, testCase.passkeyDAOErr)
 			}
 
-			service := services.NewUpdatePasskey(updatePasskeyDAO)
+			service := services.NewUpdatePasskey(updatePasskeyDAO, resolveNamespacePolicy)
 			resp, err := service.Exec(context.Background(), testCase.request)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, resp)
 
 			updatePasskeyDAO.AssertExpectations(t)
+			resolveNamespacePolicy.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,279 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	columnID           = "id"
+	columnNamespace    = "namespace"
+	columnPasskey      = "passkey"
+	columnEncryptedKey = "encrypted_key"
+	columnReward       = "reward"
+	columnSingleUse    = "single_use"
+	columnRedeemedAt   = "redeemed_at"
+	columnRedeemedBy   = "redeemed_by"
+	columnRevokedAt    = "revoked_at"
+	columnRevokedBy    = "revoked_by"
+	columnReason       = "revocation_reason"
+	columnExpiresAt    = "expires_at"
+	columnCreatedAt    = "created_at"
+	columnUpdatedAt    = "updated_at"
+)
+
+var readableColumns = []string{
+	columnID,
+	columnNamespace,
+	columnPasskey,
+	columnEncryptedKey,
+	columnReward,
+	columnSingleUse,
+	columnRedeemedAt,
+	columnRedeemedBy,
+	columnRevokedAt,
+	columnRevokedBy,
+	columnReason,
+	columnExpiresAt,
+	columnCreatedAt,
+	columnUpdatedAt,
+}
+
+// Plaintext passkeys are never exported.
+var writableColumns = []string{
+	columnID,
+	columnNamespace,
+	columnEncryptedKey,
+	columnReward,
+	columnSingleUse,
+	columnRedeemedAt,
+	columnRedeemedBy,
+	columnRevokedAt,
+	columnRevokedBy,
+	columnReason,
+	columnExpiresAt,
+	columnCreatedAt,
+	columnUpdatedAt,
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+func newCSVReader(src io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(src)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	for _, column := range header {
+		if !lo.Contains(readableColumns, column) {
+			return nil, fmt.Errorf("%w: unknown column '%s'", ErrInvalidRecord, column)
+		}
+	}
+
+	if !lo.Contains(header, columnNamespace) {
+		return nil, fmt.Errorf("%w: missing column '%s'", ErrInvalidRecord, columnNamespace)
+	}
+
+	return &csvReader{reader: reader, columns: header}, nil
+}
+
+func parseCSVTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("parse time: %w", err)
+	}
+
+	return &parsed, nil
+}
+
+func parseCSVBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool: %w", err)
+	}
+
+	return &parsed, nil
+}
+
+// parseCSVTextColumn sets the text fields of a record. It returns false for other columns.
+func parseCSVTextColumn(record *Record, column, value string) bool {
+	switch column {
+	case columnID:
+		record.ID = value
+	case columnNamespace:
+		record.Namespace = value
+	case columnPasskey:
+		record.Passkey = value
+	case columnEncryptedKey:
+		record.EncryptedKey = value
+	case columnRedeemedBy:
+		record.RedeemedBy = value
+	case columnRevokedBy:
+		record.RevokedBy = value
+	case columnReason:
+		record.RevocationReason = value
+	default:
+		return false
+	}
+
+	return true
+}
+
+func parseCSVColumn(record *Record, column, value string) error {
+	if parseCSVTextColumn(record, column, value) {
+		return nil
+	}
+
+	var err error
+
+	switch column {
+	case columnReward:
+		if value != "" {
+			err = json.Unmarshal([]byte(value), &record.Reward)
+		}
+	case columnSingleUse:
+		record.SingleUse, err = parseCSVBool(value)
+	case columnRedeemedAt:
+		record.RedeemedAt, err = parseCSVTime(value)
+	case columnRevokedAt:
+		record.RevokedAt, err = parseCSVTime(value)
+	case columnExpiresAt:
+		record.ExpiresAt, err = parseCSVTime(value)
+	case columnCreatedAt:
+		record.CreatedAt, err = parseCSVTime(value)
+	case columnUpdatedAt:
+		record.UpdatedAt, err = parseCSVTime(value)
+	}
+
+	return err
+}
+
+func (reader *csvReader) parse(row []string) (*Record, error) {
+	record := &Record{}
+
+	for i, column := range reader.columns {
+		if err := parseCSVColumn(record, column, row[i]); err != nil {
+			return nil, fmt.Errorf("%w: column '%s': %w", ErrInvalidRecord, column, err)
+		}
+	}
+
+	return record, nil
+}
+
+func (reader *csvReader) Read() (*Record, int, error) {
+	row, err := reader.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, 0, io.EOF
+	}
+
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, parseErr.StartLine, &LineError{Line: parseErr.StartLine, Err: err}
+		}
+
+		return nil, 0, fmt.Errorf("read row: %w", err)
+	}
+
+	line, _ := reader.reader.FieldPos(0)
+
+	record, err := reader.parse(row)
+	if err != nil {
+		return nil, line, &LineError{Line: line, Err: err}
+	}
+
+	return record, line, nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(dst io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(dst)
+	if err := writer.Write(writableColumns); err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+
+	return &csvWriter{writer: writer}, nil
+}
+
+func formatCSVTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.Format(time.RFC3339Nano)
+}
+
+func formatCSVBool(value *bool) string {
+	if value == nil {
+		return ""
+	}
+
+	return strconv.FormatBool(*value)
+}
+
+func (writer *csvWriter) Write(record *Record) error {
+	reward := ""
+	if record.Reward != nil {
+		rewardRaw, err := json.Marshal(record.Reward)
+		if err != nil {
+			return fmt.Errorf("marshal reward: %w", err)
+		}
+
+		reward = string(rewardRaw)
+	}
+
+	row := []string{
+		record.ID,
+		record.Namespace,
+		record.EncryptedKey,
+		reward,
+		formatCSVBool(record.SingleUse),
+		formatCSVTime(record.RedeemedAt),
+		record.RedeemedBy,
+		formatCSVTime(record.RevokedAt),
+		record.RevokedBy,
+		record.RevocationReason,
+		formatCSVTime(record.ExpiresAt),
+		formatCSVTime(record.CreatedAt),
+		formatCSVTime(record.UpdatedAt),
+	}
+
+	if err := writer.writer.Write(row); err != nil {
+		return fmt.Errorf("write row: %w", err)
+	}
+
+	return nil
+}
+
+func (writer *csvWriter) Flush() error {
+	writer.writer.Flush()
+
+	if err := writer.writer.Error(); err != nil {
+		return fmt.Errorf("flush writer: %w", err)
+	}
+
+	return nil
+}
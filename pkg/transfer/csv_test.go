@@ -0,0 +1,147 @@
+package transfer_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/transfer"
+)
+
+func TestCSVReader(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		content string
+
+		expect          []*transfer.Record
+		expectLineErrs  []int
+		expectHeaderErr error
+	}{
+		{
+			name: "OK",
+
+			content: "id,namespace,passkey,encrypted_key,reward,expires_at,single_use,redeemed_at,redeemed_by\n" +
+				"00000000-0000-0000-0000-000000000001,namespace,secret,,\"{\"\"key\"\":\"\"value\"\"}\"," +
+				"2021-03-01T00:00:00Z,,,\n" +
+				",namespace-2,,$argon2id$hash,,,true,2021-02-01T00:00:00Z,user\n",
+
+			expect: []*transfer.Record{
+				{
+					ID:        "00000000-0000-0000-0000-000000000001",
+					Namespace: "namespace",
+					Passkey:   "secret",
+					Reward:    map[string]interface{}{"key": "value"},
+					ExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					Namespace:    "namespace-2",
+					EncryptedKey: "$argon2id$hash",
+					SingleUse:    lo.ToPtr(true),
+					RedeemedAt:   lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+					RedeemedBy:   "user",
+				},
+			},
+		},
+		{
+			name: "MalformedLines",
+
+			content: "namespace,passkey,expires_at\n" +
+				"namespace,secret,yesterday\n" +
+				"namespace,secret\n" +
+				"namespace,secret,\n",
+
+			expect: []*transfer.Record{
+				{Namespace: "namespace", Passkey: "secret"},
+			},
+			expectLineErrs: []int{2, 3},
+		},
+		{
+			name: "UnknownColumn",
+
+			content: "namespace,password\n",
+
+			expectHeaderErr: transfer.ErrInvalidRecord,
+		},
+		{
+			name: "MissingNamespace",
+
+			content: "id,passkey\n",
+
+			expectHeaderErr: transfer.ErrInvalidRecord,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reader, err := transfer.NewReader(transfer.FormatCSV, strings.NewReader(testCase.content))
+			require.ErrorIs(t, err, testCase.expectHeaderErr)
+
+			if testCase.expectHeaderErr != nil {
+				return
+			}
+
+			var (
+				records  []*transfer.Record
+				lineErrs []int
+			)
+
+			for {
+				record, _, err := reader.Read()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				var lineErr *transfer.LineError
+				if errors.As(err, &lineErr) {
+					lineErrs = append(lineErrs, lineErr.Line)
+					continue
+				}
+
+				require.NoError(t, err)
+				records = append(records, record)
+			}
+
+			require.Equal(t, testCase.expect, records)
+			require.Equal(t, testCase.expectLineErrs, lineErrs)
+		})
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	buffer := new(bytes.Buffer)
+
+	writer, err := transfer.NewWriter(transfer.FormatCSV, buffer)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write(&transfer.Record{
+		ID:               "00000000-0000-0000-0000-000000000001",
+		Namespace:        "namespace",
+		Passkey:          "secret",
+		EncryptedKey:     "$argon2id$hash",
+		Reward:           map[string]interface{}{"key": "value"},
+		SingleUse:        lo.ToPtr(false),
+		RevokedAt:        lo.ToPtr(time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC)),
+		RevokedBy:        "admin",
+		RevocationReason: "leaked",
+		ExpiresAt:        lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+		CreatedAt:        lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+	}))
+	require.NoError(t, writer.Flush())
+
+	require.Equal(
+		t,
+		"id,namespace,encrypted_key,reward,single_use,redeemed_at,redeemed_by,revoked_at,revoked_by,revocation_reason,"+
+			"expires_at,created_at,updated_at\n"+
+			"00000000-0000-0000-0000-000000000001,namespace,$argon2id$hash,\"{\"\"key\"\":\"\"value\"\"}\",false,,,"+
+			"2021-02-15T00:00:00Z,admin,leaked,2021-03-01T00:00:00Z,2021-02-01T00:00:00Z,\n",
+		buffer.String(),
+	)
+	require.NotContains(t, buffer.String(), "secret")
+}
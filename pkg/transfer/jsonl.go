@@ -0,0 +1,112 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Reward objects may be large, so allow lines well above the default bufio limit.
+const maxJSONLLineSize = 1024 * 1024
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newJSONLReader(src io.Reader) *jsonlReader {
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxJSONLLineSize)
+
+	return &jsonlReader{scanner: scanner}
+}
+
+func (reader *jsonlReader) Read() (*Record, int, error) {
+	for reader.scanner.Scan() {
+		reader.line++
+
+		content := bytes.TrimSpace(reader.scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+
+		record := &Record{}
+		if err := decoder.Decode(record); err != nil {
+			return nil, reader.line, &LineError{Line: reader.line, Err: fmt.Errorf("%w: %w", ErrInvalidRecord, err)}
+		}
+
+		return record, reader.line, nil
+	}
+
+	if err := reader.scanner.Err(); err != nil {
+		return nil, reader.line, fmt.Errorf("scan line: %w", err)
+	}
+
+	return nil, 0, io.EOF
+}
+
+// jsonlExportRecord mirrors Record, without the plaintext passkey.
+type jsonlExportRecord struct {
+	ID           string                 `json:"id,omitempty"`
+	Namespace    string                 `json:"namespace"`
+	EncryptedKey string                 `json:"encrypted_key,omitempty"`
+	Reward       map[string]interface{} `json:"reward,omitempty"`
+	SingleUse    *bool                  `json:"single_use,omitempty"`
+
+	RedeemedAt       *time.Time `json:"redeemed_at,omitempty"`
+	RedeemedBy       string     `json:"redeemed_by,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy        string     `json:"revoked_by,omitempty"`
+	RevocationReason string     `json:"revocation_reason,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+type jsonlWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLWriter(dst io.Writer) *jsonlWriter {
+	writer := bufio.NewWriter(dst)
+	return &jsonlWriter{writer: writer, encoder: json.NewEncoder(writer)}
+}
+
+func (writer *jsonlWriter) Write(record *Record) error {
+	err := writer.encoder.Encode(&jsonlExportRecord{
+		ID:           record.ID,
+		Namespace:    record.Namespace,
+		EncryptedKey: record.EncryptedKey,
+		Reward:       record.Reward,
+		SingleUse:    record.SingleUse,
+
+		RedeemedAt:       record.RedeemedAt,
+		RedeemedBy:       record.RedeemedBy,
+		RevokedAt:        record.RevokedAt,
+		RevokedBy:        record.RevokedBy,
+		RevocationReason: record.RevocationReason,
+		ExpiresAt:        record.ExpiresAt,
+		CreatedAt:        record.CreatedAt,
+		UpdatedAt:        record.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("encode record: %w", err)
+	}
+
+	return nil
+}
+
+func (writer *jsonlWriter) Flush() error {
+	if err := writer.writer.Flush(); err != nil {
+		return fmt.Errorf("flush writer: %w", err)
+	}
+
+	return nil
+}
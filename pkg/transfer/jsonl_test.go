@@ -0,0 +1,100 @@
+package transfer_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/transfer"
+)
+
+func TestJSONLReader(t *testing.T) {
+	content := `{"id":"00000000-0000-0000-0000-000000000001","namespace":"namespace","passkey":"secret"}
+
+{"namespace":"namespace-2","encrypted_key":"$argon2id$hash","expires_at":"2021-03-01T00:00:00Z"}
+{"namespace":"namespace","password":"secret"}
+not json
+{"namespace":"namespace-3","reward":{"key":"value"},"revoked_at":"2021-02-15T00:00:00Z","revoked_by":"admin"}
+`
+
+	reader, err := transfer.NewReader(transfer.FormatJSONL, strings.NewReader(content))
+	require.NoError(t, err)
+
+	var (
+		records     []*transfer.Record
+		recordLines []int
+		lineErrs    []int
+	)
+
+	for {
+		record, line, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var lineErr *transfer.LineError
+		if errors.As(err, &lineErr) {
+			require.ErrorIs(t, err, transfer.ErrInvalidRecord)
+			lineErrs = append(lineErrs, lineErr.Line)
+			continue
+		}
+
+		require.NoError(t, err)
+		records = append(records, record)
+		recordLines = append(recordLines, line)
+	}
+
+	require.Equal(t, []*transfer.Record{
+		{
+			ID:        "00000000-0000-0000-0000-000000000001",
+			Namespace: "namespace",
+			Passkey:   "secret",
+		},
+		{
+			Namespace:    "namespace-2",
+			EncryptedKey: "$argon2id$hash",
+			ExpiresAt:    lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			Namespace: "namespace-3",
+			Reward:    map[string]interface{}{"key": "value"},
+			RevokedAt: lo.ToPtr(time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC)),
+			RevokedBy: "admin",
+		},
+	}, records)
+	require.Equal(t, []int{1, 3, 6}, recordLines)
+	require.Equal(t, []int{4, 5}, lineErrs)
+}
+
+func TestJSONLWriter(t *testing.T) {
+	buffer := new(bytes.Buffer)
+
+	writer, err := transfer.NewWriter(transfer.FormatJSONL, buffer)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write(&transfer.Record{
+		ID:           "00000000-0000-0000-0000-000000000001",
+		Namespace:    "namespace",
+		Passkey:      "secret",
+		EncryptedKey: "$argon2id$hash",
+		Reward:       map[string]interface{}{"key": "value"},
+		SingleUse:    lo.ToPtr(true),
+		RedeemedAt:   lo.ToPtr(time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC)),
+		CreatedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+	}))
+	require.NoError(t, writer.Flush())
+
+	require.Equal(
+		t,
+		`{"id":"00000000-0000-0000-0000-000000000001","namespace":"namespace","encrypted_key":"$argon2id$hash",`+
+			`"reward":{"key":"value"},"single_use":true,"redeemed_at":"2021-02-15T00:00:00Z",`+
+			`"created_at":"2021-02-01T00:00:00Z"}`+"\n",
+		buffer.String(),
+	)
+}
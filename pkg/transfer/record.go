@@ -0,0 +1,104 @@
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format")
+	ErrInvalidRecord = errors.New("invalid record")
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// FormatFromPath guesses the format of a file from its extension.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownFormat, path)
+	}
+}
+
+// Record is the portable representation of a passkey. Passkey holds a plaintext secret, and is only ever read:
+// writers never output it.
+type Record struct {
+	ID           string                 `json:"id,omitempty"`
+	Namespace    string                 `json:"namespace"`
+	Passkey      string                 `json:"passkey,omitempty"`
+	EncryptedKey string                 `json:"encrypted_key,omitempty"`
+	Reward       map[string]interface{} `json:"reward,omitempty"`
+	// SingleUse defaults to the policy of the namespace when empty.
+	SingleUse *bool `json:"single_use,omitempty"`
+	// Redeemed and revoked passkeys are kept inactive when imported.
+	RedeemedAt       *time.Time `json:"redeemed_at,omitempty"`
+	RedeemedBy       string     `json:"redeemed_by,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy        string     `json:"revoked_by,omitempty"`
+	RevocationReason string     `json:"revocation_reason,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// LineError is returned by a Reader when a single line of the source is malformed. Reading can continue after such
+// an error.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (err *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", err.Line, err.Err)
+}
+
+func (err *LineError) Unwrap() error {
+	return err.Err
+}
+
+type Reader interface {
+	// Read returns the next record, along with the line it starts on. It returns io.EOF once the source is
+	// exhausted, and a *LineError for malformed lines.
+	Read() (record *Record, line int, err error)
+}
+
+type Writer interface {
+	Write(record *Record) error
+	// Flush must be called once every record has been written.
+	Flush() error
+}
+
+func NewReader(format Format, src io.Reader) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(src)
+	case FormatJSONL:
+		return newJSONLReader(src), nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownFormat, format)
+	}
+}
+
+func NewWriter(format Format, dst io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(dst)
+	case FormatJSONL:
+		return newJSONLWriter(dst), nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownFormat, format)
+	}
+}
@@ -0,0 +1,53 @@
+package transfer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-passkeys/pkg/transfer"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		path string
+
+		expect    transfer.Format
+		expectErr error
+	}{
+		{
+			name: "CSV",
+
+			path:   "/tmp/passkeys.csv",
+			expect: transfer.FormatCSV,
+		},
+		{
+			name: "JSONL",
+
+			path:   "passkeys.jsonl",
+			expect: transfer.FormatJSONL,
+		},
+		{
+			name: "NDJSON",
+
+			path:   "passkeys.NDJSON",
+			expect: transfer.FormatJSONL,
+		},
+		{
+			name: "Unknown",
+
+			path:      "passkeys.json",
+			expectErr: transfer.ErrUnknownFormat,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			format, err := transfer.FormatFromPath(testCase.path)
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, format)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package workersmocks
+
+import (
+	context "context"
+
+	workers "github.com/a-novel/uservice-passkeys/pkg/workers"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPurgePasskeys is an autogenerated mock type for the PurgePasskeys type
+type MockPurgePasskeys struct {
+	mock.Mock
+}
+
+type MockPurgePasskeys_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPurgePasskeys) EXPECT() *MockPurgePasskeys_Expecter {
+	return &MockPurgePasskeys_Expecter{mock: &_m.Mock}
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *MockPurgePasskeys) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockPurgePasskeys_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockPurgePasskeys_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPurgePasskeys_Expecter) Run(ctx interface{}) *MockPurgePasskeys_Run_Call {
+	return &MockPurgePasskeys_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockPurgePasskeys_Run_Call) Run(run func(ctx context.Context)) *MockPurgePasskeys_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockPurgePasskeys_Run_Call) Return() *MockPurgePasskeys_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPurgePasskeys_Run_Call) RunAndReturn(run func(context.Context)) *MockPurgePasskeys_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunOnce provides a mock function with given fields: ctx
+func (_m *MockPurgePasskeys) RunOnce(ctx context.Context) (*workers.PurgePasskeysReport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunOnce")
+	}
+
+	var r0 *workers.PurgePasskeysReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*workers.PurgePasskeysReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *workers.PurgePasskeysReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workers.PurgePasskeysReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPurgePasskeys_RunOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunOnce'
+type MockPurgePasskeys_RunOnce_Call struct {
+	*mock.Call
+}
+
+// RunOnce is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPurgePasskeys_Expecter) RunOnce(ctx interface{}) *MockPurgePasskeys_RunOnce_Call {
+	return &MockPurgePasskeys_RunOnce_Call{Call: _e.mock.On("RunOnce", ctx)}
+}
+
+func (_c *MockPurgePasskeys_RunOnce_Call) Run(run func(ctx context.Context)) *MockPurgePasskeys_RunOnce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockPurgePasskeys_RunOnce_Call) Return(_a0 *workers.PurgePasskeysReport, _a1 error) *MockPurgePasskeys_RunOnce_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPurgePasskeys_RunOnce_Call) RunAndReturn(run func(context.Context) (*workers.PurgePasskeysReport, error)) *MockPurgePasskeys_RunOnce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPurgePasskeys creates a new instance of MockPurgePasskeys. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPurgePasskeys(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPurgePasskeys {
+	mock := &MockPurgePasskeys{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
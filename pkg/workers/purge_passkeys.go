@@ -0,0 +1,223 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	"github.com/a-novel/uservice-passkeys/pkg/metrics"
+)
+
+const PurgePasskeysWorkerName = "purge_passkeys"
+
+const (
+	DefaultPurgeInterval  = time.Hour
+	DefaultPurgeRetention = 30 * 24 * time.Hour
+	DefaultPurgeBatchSize = 1000
+)
+
+type PurgePasskeysConfig struct {
+	// Interval between two runs.
+	Interval time.Duration
+	// Retention is how long expired and revoked passkeys are kept before being deleted.
+	Retention time.Duration
+	// BatchSize is the maximum number of rows deleted by a single query.
+	BatchSize int
+}
+
+// PurgePasskeysReport describes the outcome of a single run.
+type PurgePasskeysReport struct {
+	Deleted int
+	Batches int
+	// DeletedIdempotencyKeys is the number of expired idempotency keys removed during the run.
+	DeletedIdempotencyKeys int
+	// Skipped is true when another replica was already running the purge.
+	Skipped bool
+	Latency time.Duration
+}
+
+// PurgePasskeys periodically deletes the passkeys that expired or were revoked for longer than the retention period.
+// Expired idempotency keys are removed along the way.
+type PurgePasskeys interface {
+	// Run blocks until the context is canceled.
+	Run(ctx context.Context)
+	RunOnce(ctx context.Context) (*PurgePasskeysReport, error)
+}
+
+type purgePasskeysImpl struct {
+	lock            dao.LockPurge
+	dao             dao.PurgePasskeys
+	idempotencyKeys dao.PurgeIdempotencyKeys
+	config          PurgePasskeysConfig
+	logger          formatters.Formatter
+}
+
+func (worker *purgePasskeysImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(worker.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := worker.RunOnce(ctx)
+			worker.observe(report, err)
+			worker.report(report, err)
+		}
+	}
+}
+
+func (worker *purgePasskeysImpl) RunOnce(ctx context.Context) (*PurgePasskeysReport, error) {
+	start := time.Now()
+	report := new(PurgePasskeysReport)
+
+	err := worker.purgePasskeys(ctx, start, report)
+	if errors.Is(err, dao.ErrPurgeLocked) {
+		// Another replica is running the purge: let it finish.
+		report.Skipped = true
+	} else if err != nil {
+		report.Latency = time.Since(start)
+		return report, err
+	}
+
+	deletedKeys, err := worker.idempotencyKeys.Exec(ctx, &dao.PurgeIdempotencyKeysRequest{Before: start})
+	if err != nil {
+		report.Latency = time.Since(start)
+		return report, fmt.Errorf("purge idempotency keys: %w", err)
+	}
+
+	report.DeletedIdempotencyKeys = deletedKeys
+	report.Latency = time.Since(start)
+
+	return report, nil
+}
+
+// purgePasskeys deletes inactive passkeys batch by batch. The lock of the purge is held across every batch, so
+// replicas cannot interleave their runs.
+func (worker *purgePasskeysImpl) purgePasskeys(
+	ctx context.Context, start time.Time, report *PurgePasskeysReport,
+) (err error) {
+	unlock, err := worker.lock.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("lock purge: %w", err)
+	}
+
+	defer func() {
+		// The lock must be released even when the run was canceled.
+		if unlockErr := unlock(context.WithoutCancel(ctx)); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("unlock purge: %w", unlockErr))
+		}
+	}()
+
+	request := &dao.PurgePasskeysRequest{
+		Before:    start.Add(-worker.config.Retention),
+		BatchSize: worker.config.BatchSize,
+	}
+
+	for {
+		deleted, batchErr := worker.dao.Exec(ctx, request)
+		if batchErr != nil {
+			return fmt.Errorf("purge batch %d: %w", report.Batches+1, batchErr)
+		}
+
+		report.Deleted += deleted
+		report.Batches++
+
+		// A partial batch means there is nothing left to delete.
+		if deleted < worker.config.BatchSize {
+			return nil
+		}
+	}
+}
+
+// observe updates the metrics of the worker with the outcome of a run.
+func (worker *purgePasskeysImpl) observe(report *PurgePasskeysReport, err error) {
+	result := metrics.PurgeSuccess
+
+	switch {
+	case err != nil:
+		result = metrics.PurgeFailure
+	case report.Skipped:
+		result = metrics.PurgeSkipped
+	}
+
+	metrics.PurgeRuns.WithLabelValues(result).Inc()
+	metrics.PurgeDuration.Observe(report.Latency.Seconds())
+	metrics.PurgeDeleted.WithLabelValues(metrics.PurgedPasskeys).Add(float64(report.Deleted))
+	metrics.PurgeDeleted.WithLabelValues(metrics.PurgedIdempotencyKeys).Add(float64(report.DeletedIdempotencyKeys))
+}
+
+func (worker *purgePasskeysImpl) report(report *PurgePasskeysReport, err error) {
+	level := loggers.LogLevelInfo
+	color := lipgloss.Color("#00A7FF")
+	status := fmt.Sprintf(
+		"✓ deleted %d passkeys in %d batches, and %d idempotency keys",
+		report.Deleted, report.Batches, report.DeletedIdempotencyKeys,
+	)
+
+	switch {
+	case err != nil:
+		level = loggers.LogLevelError
+		color = "#FF3232"
+		status = fmt.Sprintf("✗ %s", err)
+	case report.Skipped:
+		status = "⟁ skipped, another replica is running"
+	}
+
+	message := formatters.NewSplit().
+		SetConsoleRenderer(func() string {
+			return lipgloss.NewStyle().Foreground(color).Bold(true).Render(status) +
+				lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf(" [%s]", PurgePasskeysWorkerName)) +
+				lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf(" (%s)", report.Latency)) +
+				"\n\n"
+		}).
+		SetJSONRenderer(func() interface{} {
+			output := map[string]interface{}{
+				"worker":  PurgePasskeysWorkerName,
+				"deleted": report.Deleted,
+				"batches": report.Batches,
+
+				"deleted_idempotency_keys": report.DeletedIdempotencyKeys,
+				"skipped":                  report.Skipped,
+				"latency":                  report.Latency,
+			}
+
+			if err != nil {
+				output["error"] = err.Error()
+			}
+
+			return output
+		})
+
+	worker.logger.Log(message, level)
+}
+
+// NewPurgePasskeys creates a new purge worker. Empty config values use their Default* counterpart.
+func NewPurgePasskeys(
+	lock dao.LockPurge,
+	dao dao.PurgePasskeys,
+	idempotencyKeys dao.PurgeIdempotencyKeys,
+	config PurgePasskeysConfig,
+	logger formatters.Formatter,
+) PurgePasskeys {
+	config.Interval = lo.CoalesceOrEmpty(config.Interval, DefaultPurgeInterval)
+	config.Retention = lo.CoalesceOrEmpty(config.Retention, DefaultPurgeRetention)
+	config.BatchSize = lo.CoalesceOrEmpty(config.BatchSize, DefaultPurgeBatchSize)
+
+	return &purgePasskeysImpl{
+		lock:            lock,
+		dao:             dao,
+		idempotencyKeys: idempotencyKeys,
+		config:          config,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,186 @@
+package workers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	formattersmocks "github.com/a-novel/golib/loggers/formatters/mocks"
+
+	"github.com/a-novel/uservice-passkeys/pkg/dao"
+	daomocks "github.com/a-novel/uservice-passkeys/pkg/dao/mocks"
+	"github.com/a-novel/uservice-passkeys/pkg/workers"
+)
+
+func TestPurgePasskeys(t *testing.T) {
+	type daoCall struct {
+		deleted int
+		err     error
+	}
+
+	testCases := []struct {
+		name string
+
+		lockErr   error
+		unlockErr error
+
+		daoCalls []daoCall
+
+		// callIdempotencyKeys is set when the passkeys purge succeeds, and the idempotency keys are purged next.
+		callIdempotencyKeys    bool
+		deletedIdempotencyKeys int
+		idempotencyKeysErr     error
+
+		expect    *workers.PurgePasskeysReport
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			daoCalls: []daoCall{{deleted: 10}, {deleted: 10}, {deleted: 3}},
+
+			callIdempotencyKeys:    true,
+			deletedIdempotencyKeys: 4,
+
+			expect: &workers.PurgePasskeysReport{Deleted: 23, Batches: 3, DeletedIdempotencyKeys: 4},
+		},
+		{
+			name: "OK/Empty",
+
+			daoCalls: []daoCall{{deleted: 0}},
+
+			callIdempotencyKeys: true,
+
+			expect: &workers.PurgePasskeysReport{Batches: 1},
+		},
+		{
+			name: "Locked",
+
+			lockErr: dao.ErrPurgeLocked,
+
+			callIdempotencyKeys: true,
+
+			expect: &workers.PurgePasskeysReport{Skipped: true},
+		},
+		{
+			name: "LockError",
+
+			lockErr: errors.New("uwups"),
+
+			expect:    &workers.PurgePasskeysReport{},
+			expectErr: errors.New("uwups"),
+		},
+		{
+			name: "UnlockError",
+
+			unlockErr: errors.New("uwups"),
+
+			daoCalls: []daoCall{{deleted: 3}},
+
+			expect:    &workers.PurgePasskeysReport{Deleted: 3, Batches: 1},
+			expectErr: errors.New("uwups"),
+		},
+		{
+			name: "Error",
+
+			daoCalls: []daoCall{{deleted: 10}, {err: errors.New("uwups")}},
+
+			expect:    &workers.PurgePasskeysReport{Deleted: 10, Batches: 1},
+			expectErr: errors.New("uwups"),
+		},
+		{
+			name: "IdempotencyKeysError",
+
+			daoCalls: []daoCall{{deleted: 3}},
+
+			callIdempotencyKeys: true,
+			idempotencyKeysErr:  errors.New("uwups"),
+
+			expect:    &workers.PurgePasskeysReport{Deleted: 3, Batches: 1},
+			expectErr: errors.New("uwups"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lockPurgeDAO := daomocks.NewMockLockPurge(t)
+			purgePasskeysDAO := daomocks.NewMockPurgePasskeys(t)
+			purgeIdempotencyKeysDAO := daomocks.NewMockPurgeIdempotencyKeys(t)
+			logger := formattersmocks.NewMockFormatter(t)
+
+			var unlocked bool
+
+			unlock := func(_ context.Context) error {
+				unlocked = true
+
+				return testCase.unlockErr
+			}
+
+			if testCase.lockErr != nil {
+				lockPurgeDAO.On("Exec", context.Background()).Return(nil, testCase.lockErr)
+			} else {
+				lockPurgeDAO.On("Exec", context.Background()).Return(dao.UnlockPurge(unlock), nil)
+			}
+
+			for _, call := range testCase.daoCalls {
+				purgePasskeysDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(request *dao.PurgePasskeysRequest) bool {
+							retention := time.Since(request.Before)
+
+							return request.BatchSize == 10 &&
+								retention > 24*time.Hour-time.Minute &&
+								retention < 24*time.Hour+time.Minute
+						}),
+					).
+					Return(call.deleted, call.err).
+					Once()
+			}
+
+			if testCase.callIdempotencyKeys {
+				purgeIdempotencyKeysDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(request *dao.PurgeIdempotencyKeysRequest) bool {
+							return time.Since(request.Before) < time.Minute
+						}),
+					).
+					Return(testCase.deletedIdempotencyKeys, testCase.idempotencyKeysErr)
+			}
+
+			worker := workers.NewPurgePasskeys(
+				lockPurgeDAO, purgePasskeysDAO, purgeIdempotencyKeysDAO, workers.PurgePasskeysConfig{
+					Retention: 24 * time.Hour,
+					BatchSize: 10,
+				}, logger,
+			)
+
+			report, err := worker.RunOnce(context.Background())
+
+			if testCase.expectErr != nil {
+				require.ErrorContains(t, err, testCase.expectErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, testCase.expect.Deleted, report.Deleted)
+			require.Equal(t, testCase.expect.Batches, report.Batches)
+			require.Equal(t, testCase.expect.Skipped, report.Skipped)
+			require.Equal(t, testCase.expect.DeletedIdempotencyKeys, report.DeletedIdempotencyKeys)
+			// The lock is released once the passkeys are purged, whatever the outcome.
+			require.Equal(t, testCase.lockErr == nil, unlocked)
+
+			lockPurgeDAO.AssertExpectations(t)
+
+			purgePasskeysDAO.AssertExpectations(t)
+			purgeIdempotencyKeysDAO.AssertExpectations(t)
+		})
+	}
+}